@@ -2,19 +2,337 @@ package keboola
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
 	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/logging"
 )
 
 //KBCClient is used for communicating with the Keboola Connection API
 type KBCClient struct {
-	APIKey string
+	APIKey           string
+	StorageURL       string
+	BranchID         string
+	MetadataProvider string
+	MaxRetries       int
+	MaxRetryWait     time.Duration
+	RequestTimeout   time.Duration
+	StopContext      context.Context
+	Token            *TokenVerification
+
+	clientOnce sync.Once
+	client     *http.Client
+
+	uploadClientOnce sync.Once
+	uploadClient     *http.Client
+
+	servicesMutex sync.Mutex
+	services      map[string]string
 }
 
-//CreateResourceResult holds the results from requesting creation of a Keboola resource.
+//CreateResourceResult holds the results from requesting creation of a Keboola resource. Most
+//resources have numeric IDs, but some (such as buckets) are identified by a string.
 type CreateResourceResult struct {
-	ID json.Number `json:"id,omitempty"`
+	ID KBCNumberString `json:"id,omitempty"`
+}
+
+//KBCAPIError is returned when one of the Keboola APIs responds with an error status.
+//It only holds the details parsed from the error response, and never the original
+//request, so it is safe to print (the request headers contain the Storage API token).
+type KBCAPIError struct {
+	StatusCode  int
+	Method      string
+	Endpoint    string
+	Message     string
+	Code        string
+	ExceptionID string
+}
+
+//kbcErrorBody is the JSON error body returned by the Keboola APIs.
+type kbcErrorBody struct {
+	Error       string      `json:"error"`
+	Message     string      `json:"message"`
+	Code        interface{} `json:"code"`
+	ExceptionID string      `json:"exceptionId"`
+}
+
+func newKBCAPIError(response *http.Response) *KBCAPIError {
+	apiError := &KBCAPIError{
+		StatusCode: response.StatusCode,
+	}
+
+	if response.Request != nil {
+		apiError.Method = response.Request.Method
+		apiError.Endpoint = response.Request.URL.Path
+	}
+
+	content, _ := ioutil.ReadAll(response.Body)
+
+	var errorBody kbcErrorBody
+
+	if json.Unmarshal(content, &errorBody) == nil {
+		apiError.Message = errorBody.Error
+		if apiError.Message == "" {
+			apiError.Message = errorBody.Message
+		}
+
+		apiError.ExceptionID = errorBody.ExceptionID
+
+		if errorBody.Code != nil {
+			apiError.Code = fmt.Sprint(errorBody.Code)
+		}
+	} else {
+		apiError.Message = strings.TrimSpace(string(content))
+	}
+
+	return apiError
+}
+
+func (e *KBCAPIError) Error() string {
+	message := fmt.Sprintf("Keboola API error (%d) on %s %s", e.StatusCode, e.Method, e.Endpoint)
+
+	if e.Message != "" {
+		message += ": " + e.Message
+	}
+
+	if e.Code != "" {
+		message += fmt.Sprintf(" [code: %s]", e.Code)
+	}
+
+	if e.ExceptionID != "" {
+		message += fmt.Sprintf(" [exception ID: %s]", e.ExceptionID)
+	}
+
+	return message
+}
+
+//isNotFoundError returns whether an error is a Keboola API error for a resource which does not exist.
+func isNotFoundError(err error) bool {
+	apiError, ok := err.(*KBCAPIError)
+	return ok && apiError.StatusCode == http.StatusNotFound
+}
+
+//hasErrorCode returns whether an error is a Keboola API error with the given error code (e.g. storage.tables.notFound).
+func hasErrorCode(err error, code string) bool {
+	apiError, ok := err.(*KBCAPIError)
+	return ok && apiError.Code == code
+}
+
+//StorageIndex is the data model for the Storage API index, which
+//lists the URLs of all the other services running on the same stack.
+type StorageIndex struct {
+	Services []struct {
+		ID  string `json:"id"`
+		URL string `json:"url"`
+	} `json:"services"`
+}
+
+//defaultStack is the Keboola stack used when neither a stack nor a host is configured.
+const defaultStack = "us-east-1"
+
+//kbcStacks maps the names of the multi-tenant Keboola stacks to their Connection host.
+var kbcStacks = map[string]string{
+	"us-east-1":          "connection.keboola.com",
+	"eu-central-1":       "connection.eu-central-1.keboola.com",
+	"north-europe-azure": "connection.north-europe.azure.keboola.com",
+	"europe-west3-gcp":   "connection.europe-west3.gcp.keboola.com",
+	"us-east4-gcp":       "connection.us-east4.gcp.keboola.com",
+}
+
+//storageURLFor builds the Storage API base URL for either an explicit host
+//(e.g. for single-tenant stacks) or one of the known multi-tenant stacks.
+func storageURLFor(stack string, host string) (string, error) {
+	if host == "" {
+		if stack == "" {
+			stack = defaultStack
+		}
+
+		stackHost, ok := kbcStacks[stack]
+		if !ok {
+			return "", fmt.Errorf("unknown Keboola stack %q", stack)
+		}
+
+		host = stackHost
+	}
+
+	if !strings.HasPrefix(host, "http://") && !strings.HasPrefix(host, "https://") {
+		host = "https://" + host
+	}
+
+	return strings.TrimRight(host, "/") + "/v2/", nil
+}
+
+//serviceURL returns the base URL of a Keboola service (e.g. syrup, import), as listed in the
+//Storage API index. The index is requested until it has been read once, so that a failure to
+//read it is retried by the next request, rather than failing every request made by the client.
+func (c *KBCClient) serviceURL(serviceID string) (string, error) {
+	c.servicesMutex.Lock()
+	defer c.servicesMutex.Unlock()
+
+	if c.services == nil {
+		services, err := c.discoverServices()
+
+		if err != nil {
+			return "", err
+		}
+
+		c.services = services
+	}
+
+	serviceURL, ok := c.services[serviceID]
+	if !ok {
+		return "", fmt.Errorf("service %q is not available on the Keboola stack at %s", serviceID, c.StorageURL)
+	}
+
+	return serviceURL, nil
+}
+
+func (c *KBCClient) discoverServices() (map[string]string, error) {
+	indexResponse, err := c.GetFromStorage("storage")
+
+	if hasErrors(err, indexResponse) {
+		return nil, extractError(err, indexResponse)
+	}
+
+	var storageIndex StorageIndex
+
+	decoder := json.NewDecoder(indexResponse.Body)
+	err = decoder.Decode(&storageIndex)
+
+	if err != nil {
+		return nil, err
+	}
+
+	services := make(map[string]string, len(storageIndex.Services))
+	for _, service := range storageIndex.Services {
+		services[service.ID] = strings.TrimRight(service.URL, "/") + "/"
+	}
+
+	return services, nil
+}
+
+const (
+	defaultRequestTimeout = 5 * time.Minute
+	connectTimeout        = 10 * time.Second
+)
+
+//httpClient returns the HTTP client shared by all requests made by this KBCClient. It keeps
+//connections alive between requests, retries requests that fail due to transient errors,
+//and logs every request and response when debug logging is enabled.
+func (c *KBCClient) httpClient() *http.Client {
+	c.clientOnce.Do(func() {
+		requestTimeout := c.RequestTimeout
+		if requestTimeout <= 0 {
+			requestTimeout = defaultRequestTimeout
+		}
+
+		c.client = c.newHTTPClient(requestTimeout)
+	})
+
+	return c.client
+}
+
+//uploadHTTPClient returns the HTTP client used to upload files to the file storage of the stack. Uploading
+//a large file can take much longer than any request to the Keboola APIs, so its attempts are not limited by
+//the request timeout. Uploads still stop when Terraform asks the provider to stop.
+func (c *KBCClient) uploadHTTPClient() *http.Client {
+	c.uploadClientOnce.Do(func() {
+		c.uploadClient = c.newHTTPClient(0)
+	})
+
+	return c.uploadClient
+}
+
+//newHTTPClient creates a pooled HTTP client which retries and logs its requests, abandoning
+//each attempt at a request after attemptTimeout (unless it is zero).
+func (c *KBCClient) newHTTPClient(attemptTimeout time.Duration) *http.Client {
+	transport := &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   connectTimeout,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		MaxIdleConns:          100,
+		MaxIdleConnsPerHost:   10,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   connectTimeout,
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+
+	return &http.Client{
+		Transport: &retryTransport{
+			Transport: &loggingTransport{
+				Transport: transport,
+				Enabled:   logging.IsDebugOrHigher(),
+			},
+			MaxRetries:     c.MaxRetries,
+			MaxRetryWait:   c.MaxRetryWait,
+			AttemptTimeout: attemptTimeout,
+		},
+	}
+}
+
+//stopContext returns the context which is cancelled when Terraform asks the provider to stop.
+func (c *KBCClient) stopContext() context.Context {
+	if c.StopContext == nil {
+		return context.Background()
+	}
+
+	return c.StopContext
+}
+
+//send makes a request to one of the Keboola APIs. The response body is read in full
+//and closed before returning, so callers are free to decode it (or not) without
+//leaking the underlying connection.
+func (c *KBCClient) send(method string, requestURL string, body *bytes.Buffer, contentType string) (*http.Response, error) {
+	return c.sendWithHeaders(c.httpClient(), method, requestURL, body, contentType, map[string]string{"X-StorageApi-Token": c.APIKey})
+}
+
+//sendWithHeaders sends a request with the given client and headers, which (unlike send) need not include the Storage API token.
+func (c *KBCClient) sendWithHeaders(client *http.Client, method string, requestURL string, body *bytes.Buffer, contentType string, headers map[string]string) (*http.Response, error) {
+	var requestBody io.Reader
+	if body != nil {
+		requestBody = body
+	}
+
+	req, err := http.NewRequest(method, requestURL, requestBody)
+	if err != nil {
+		return nil, err
+	}
+
+	req = req.WithContext(c.stopContext())
+
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	response, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer response.Body.Close()
+
+	content, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	response.Body = ioutil.NopCloser(bytes.NewReader(content))
+
+	return response, nil
 }
 
 func hasErrors(err error, response *http.Response) bool {
@@ -26,8 +344,5 @@ func extractError(err error, response *http.Response) error {
 		return err
 	}
 
-	contentBuffer := new(bytes.Buffer)
-	contentBuffer.ReadFrom(response.Body)
-
-	return fmt.Errorf("%v %s\n%v", response.StatusCode, contentBuffer.String(), response.Request)
+	return newKBCAPIError(response)
 }
@@ -0,0 +1,16 @@
+package keboola
+
+import (
+	"bytes"
+	"net/http"
+)
+
+//PostToEncryption posts a plain text value to the Keboola Encryption API to be encrypted.
+func (c *KBCClient) PostToEncryption(endpoint string, plaintext *bytes.Buffer) (*http.Response, error) {
+	encryptionURL, err := c.serviceURL("encryption")
+	if err != nil {
+		return nil, err
+	}
+
+	return c.send("POST", encryptionURL+endpoint, plaintext, "text/plain")
+}
@@ -0,0 +1,170 @@
+package keboola
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	redacted           = "[REDACTED]"
+	maxLoggedBodyBytes = 64 * 1024
+)
+
+//sensitiveJSONFields matches string values of JSON fields that hold secrets: Keboola encrypts
+//every field prefixed with #, the Storage and Provisioning APIs return tokens and passwords,
+//and prepared Storage files hold the credentials for uploading them to the file storage.
+var sensitiveJSONFields = regexp.MustCompile(`("(?:#[^"]*|token|password|access_token|SASConnectionString)"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+
+//sensitiveQueryParameters are the query parameters of a URL which hold secrets, such as
+//the signature of a shared access signature used to upload files to Azure Blob Storage.
+var sensitiveQueryParameters = []string{"sig"}
+
+//loggingTransport is a http.RoundTripper which logs every request to, and response from,
+//the Keboola APIs when Terraform is run with TF_LOG=DEBUG or TF_LOG=TRACE. The Storage API
+//token, and any secrets in the request and response bodies, are redacted.
+type loggingTransport struct {
+	Transport http.RoundTripper
+	Enabled   bool
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !t.Enabled {
+		return t.Transport.RoundTrip(req)
+	}
+
+	requestBody := ""
+	if req.Body != nil && req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			content, _ := ioutil.ReadAll(body)
+			body.Close()
+			requestBody = redactBody(req.Header.Get("Content-Type"), content)
+		}
+	}
+
+	requestURL := redactURL(req.URL)
+
+	log.Printf("[DEBUG] Keboola API request: %s %s\nHeaders: %s\nBody: %s", req.Method, requestURL, redactHeaders(req.Header), requestBody)
+
+	started := time.Now()
+	response, err := t.Transport.RoundTrip(req)
+	latency := time.Since(started)
+
+	if err != nil {
+		log.Printf("[DEBUG] Keboola API request failed: %s %s (%s): %s", req.Method, requestURL, latency, err)
+		return response, err
+	}
+
+	content, err := ioutil.ReadAll(response.Body)
+	response.Body.Close()
+
+	if err != nil {
+		return nil, err
+	}
+
+	response.Body = ioutil.NopCloser(bytes.NewReader(content))
+
+	log.Printf("[DEBUG] Keboola API response: %s %s returned %d (%s)\nBody: %s", req.Method, requestURL, response.StatusCode, latency, redactBody(response.Header.Get("Content-Type"), content))
+
+	return response, nil
+}
+
+func redactHeaders(headers http.Header) string {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var logged []string
+	for _, name := range names {
+		value := strings.Join(headers[name], ", ")
+		if strings.EqualFold(name, "X-StorageApi-Token") || strings.EqualFold(name, "Authorization") {
+			value = redacted
+		}
+
+		logged = append(logged, fmt.Sprintf("%s: %s", name, value))
+	}
+
+	return strings.Join(logged, "; ")
+}
+
+//redactBody returns a loggable version of a request or response body, with any secrets redacted.
+func redactBody(contentType string, content []byte) string {
+	if strings.HasPrefix(contentType, "multipart/") {
+		return fmt.Sprintf("(multipart body, %d bytes)", len(content))
+	}
+
+	//Plain text bodies are only sent to the Encryption API, and hold the secret being encrypted.
+	if strings.HasPrefix(contentType, "text/plain") {
+		return fmt.Sprintf("(plain text body, %d bytes)", len(content))
+	}
+
+	//Binary bodies are the content of files uploaded to the file storage.
+	if strings.HasPrefix(contentType, "application/octet-stream") {
+		return fmt.Sprintf("(binary body, %d bytes)", len(content))
+	}
+
+	body := string(content)
+
+	if strings.HasPrefix(contentType, "application/x-www-form-urlencoded") {
+		if form, err := url.ParseQuery(body); err == nil {
+			body = redactForm(form)
+		}
+	}
+
+	body = redactJSON(body)
+
+	if len(body) > maxLoggedBodyBytes {
+		body = fmt.Sprintf("%s... (truncated, %d bytes)", body[:maxLoggedBodyBytes], len(body))
+	}
+
+	return body
+}
+
+//redactURL returns a loggable version of a request URL, with any secrets in its query redacted.
+func redactURL(requestURL *url.URL) string {
+	query := requestURL.Query()
+	redactedURL := *requestURL
+
+	for _, parameter := range sensitiveQueryParameters {
+		if _, ok := query[parameter]; ok {
+			query.Set(parameter, redacted)
+			redactedURL.RawQuery = query.Encode()
+		}
+	}
+
+	return redactedURL.String()
+}
+
+func redactForm(form url.Values) string {
+	keys := make([]string, 0, len(form))
+	for key := range form {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var fields []string
+	for _, key := range keys {
+		for _, value := range form[key] {
+			if strings.HasPrefix(key, "#") || key == "password" || key == "token" {
+				value = redacted
+			}
+
+			fields = append(fields, fmt.Sprintf("%s=%s", key, value))
+		}
+	}
+
+	return strings.Join(fields, "&")
+}
+
+func redactJSON(body string) string {
+	return sensitiveJSONFields.ReplaceAllString(body, fmt.Sprintf(`$1"%s"`, redacted))
+}
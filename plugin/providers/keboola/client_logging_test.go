@@ -0,0 +1,102 @@
+package keboola
+
+import (
+	"bytes"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"testing"
+
+	"github.com/paybyphone/terraform-provider-keboola/plugin/providers/keboola/buffer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedactBody_JSON(t *testing.T) {
+	body := `{"parameters":{"db":{"host":"example.com","user":"keboola","#password":"KBC::ProjectSecure::abc","#privateKey":"-----BEGIN\"KEY"}}}`
+
+	redactedBody := redactBody("application/json", []byte(body))
+
+	assert.Equal(t, `{"parameters":{"db":{"host":"example.com","user":"keboola","#password":"[REDACTED]","#privateKey":"[REDACTED]"}}}`, redactedBody)
+}
+
+func TestRedactBody_Form(t *testing.T) {
+	form := url.Values{}
+	form.Add("name", "Snowflake Writer")
+	form.Add("configuration", `{"parameters":{"db":{"#password":"secret"}}}`)
+
+	redactedBody := redactBody("application/x-www-form-urlencoded", buffer.FromForm(form).Bytes())
+
+	assert.Equal(t, `configuration={"parameters":{"db":{"#password":"[REDACTED]"}}}&name=Snowflake Writer`, redactedBody)
+}
+
+func TestRedactBody_ReturnedTokensAndPasswords(t *testing.T) {
+	body := `{"id":"123","token":"1234-abcdefg","connection":{"user":"WORKSPACE","password":"hunter2"}}`
+
+	assert.Equal(t, `{"id":"123","token":"[REDACTED]","connection":{"user":"WORKSPACE","password":"[REDACTED]"}}`, redactBody("application/json", []byte(body)))
+}
+
+func TestRedactBody_PlainText(t *testing.T) {
+	assert.Equal(t, "(plain text body, 7 bytes)", redactBody("text/plain", []byte("hunter2")))
+}
+
+func TestRedactBody_UploadCredentials(t *testing.T) {
+	body := `{"id":123,"gcsUploadParams":{"key":"files/123","access_token":"ya29.secret"},"absUploadParams":{"absCredentials":{"SASConnectionString":"BlobEndpoint=https://kbc.blob.core.windows.net;SharedAccessSignature=sig=secret"}}}`
+
+	assert.Equal(t, `{"id":123,"gcsUploadParams":{"key":"files/123","access_token":"[REDACTED]"},"absUploadParams":{"absCredentials":{"SASConnectionString":"[REDACTED]"}}}`, redactBody("application/json", []byte(body)))
+	assert.Equal(t, "(binary body, 7 bytes)", redactBody("application/octet-stream", []byte("hunter2")))
+}
+
+func TestLoggingTransport_RedactsUploadCredentials(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	var logOutput bytes.Buffer
+	log.SetOutput(&logOutput)
+	defer log.SetOutput(os.Stderr)
+
+	transport := &loggingTransport{Transport: http.DefaultTransport, Enabled: true}
+	req, _ := http.NewRequest("PUT", server.URL+"/kbc-files/files/123?sv=2017-11-09&sig=secret-signature", bytes.NewBufferString("file content"))
+	req.Header.Set("Authorization", "Bearer secret-access-token")
+	req.Header.Set("Content-Type", "application/octet-stream")
+
+	response, err := transport.RoundTrip(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, response.StatusCode)
+	assert.Contains(t, logOutput.String(), "PUT "+server.URL+"/kbc-files/files/123?")
+	assert.Contains(t, logOutput.String(), "sv=2017-11-09")
+	assert.NotContains(t, logOutput.String(), "secret-signature")
+	assert.NotContains(t, logOutput.String(), "secret-access-token")
+	assert.NotContains(t, logOutput.String(), "file content")
+}
+
+func TestLoggingTransport_RedactsToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"1","#password":"KBC::ProjectSecure::xyz"}`))
+	}))
+	defer server.Close()
+
+	var logOutput bytes.Buffer
+	log.SetOutput(&logOutput)
+	defer log.SetOutput(os.Stderr)
+
+	transport := &loggingTransport{Transport: http.DefaultTransport, Enabled: true}
+	req, _ := http.NewRequest("POST", server.URL+"/v2/storage/components/keboola.wr-db-snowflake/configs", bytes.NewBufferString(`{"#password":"secret"}`))
+	req.Header.Set("X-StorageApi-Token", "1234-secret-token")
+	req.Header.Set("Content-Type", "application/json")
+
+	response, err := transport.RoundTrip(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Contains(t, logOutput.String(), "POST "+server.URL+"/v2/storage/components/keboola.wr-db-snowflake/configs")
+	assert.Contains(t, logOutput.String(), "returned 200")
+	assert.NotContains(t, logOutput.String(), "1234-secret-token")
+	assert.NotContains(t, logOutput.String(), "secret\"")
+	assert.NotContains(t, logOutput.String(), "KBC::ProjectSecure::xyz")
+}
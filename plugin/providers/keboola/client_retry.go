@@ -0,0 +1,171 @@
+package keboola
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultMaxRetries   = 5
+	defaultMaxRetryWait = 30 * time.Second
+	baseRetryWait       = 1 * time.Second
+)
+
+//retryTransport is a http.RoundTripper which retries requests that failed due to
+//throttling or a transient outage of the Keboola APIs, using exponential backoff.
+//
+//Idempotent requests (GET, HEAD, OPTIONS, PUT, DELETE) are retried after network errors
+//and 429, 502, 503 and 504 responses. Other requests (POST, PATCH) are only retried
+//after 429 and 503 responses, as Keboola rejects those before processing the request,
+//so retrying them can never create a resource twice.
+//
+//Each attempt is abandoned once AttemptTimeout has passed (including the time taken to read
+//the response), after which an idempotent request is retried like after any other network error.
+type retryTransport struct {
+	Transport      http.RoundTripper
+	MaxRetries     int
+	MaxRetryWait   time.Duration
+	AttemptTimeout time.Duration
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	attemptReq := req
+
+	for attempt := 0; ; attempt++ {
+		response, err := t.roundTripAttempt(attemptReq)
+
+		if attempt >= t.MaxRetries || !shouldRetry(req, response, err) {
+			return response, err
+		}
+
+		if req.Body != nil && req.GetBody == nil {
+			return response, err
+		}
+
+		wait := t.retryWait(attempt, response)
+
+		if err != nil {
+			log.Printf("[WARN] %s %s failed (%s), retrying in %s", req.Method, req.URL.Path, err, wait)
+		} else {
+			log.Printf("[WARN] %s %s returned %d, retrying in %s", req.Method, req.URL.Path, response.StatusCode, wait)
+			io.Copy(ioutil.Discard, response.Body)
+			response.Body.Close()
+		}
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(wait):
+		}
+
+		attemptReq = req.Clone(req.Context())
+
+		if req.Body != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+
+			attemptReq.Body = body
+		}
+	}
+}
+
+//roundTripAttempt makes a single attempt at a request, limited to AttemptTimeout. The timeout
+//is only released once the response body has been closed, so it also covers reading the body.
+func (t *retryTransport) roundTripAttempt(req *http.Request) (*http.Response, error) {
+	if t.AttemptTimeout <= 0 {
+		return t.Transport.RoundTrip(req)
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), t.AttemptTimeout)
+	response, err := t.Transport.RoundTrip(req.WithContext(ctx))
+
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	response.Body = &cancelOnCloseBody{ReadCloser: response.Body, cancel: cancel}
+
+	return response, nil
+}
+
+//cancelOnCloseBody is a response body which releases the timeout of its attempt once it is closed.
+type cancelOnCloseBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnCloseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+func shouldRetry(req *http.Request, response *http.Response, err error) bool {
+	idempotent := isIdempotent(req.Method)
+
+	if err != nil {
+		return idempotent && req.Context().Err() == nil
+	}
+
+	switch response.StatusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return true
+	case http.StatusBadGateway, http.StatusGatewayTimeout:
+		return idempotent
+	}
+
+	return false
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case "GET", "HEAD", "OPTIONS", "PUT", "DELETE":
+		return true
+	}
+
+	return false
+}
+
+//retryWait works out how long to wait before the next attempt, honouring any
+//Retry-After header sent by Keboola, and never waiting longer than MaxRetryWait.
+func (t *retryTransport) retryWait(attempt int, response *http.Response) time.Duration {
+	wait := time.Duration(math.Pow(2, float64(attempt))) * baseRetryWait
+
+	if response != nil {
+		if retryAfter := parseRetryAfter(response.Header.Get("Retry-After")); retryAfter > 0 {
+			wait = retryAfter
+		}
+	}
+
+	if wait > t.MaxRetryWait {
+		wait = t.MaxRetryWait
+	}
+
+	return wait
+}
+
+//parseRetryAfter reads a Retry-After header, which can either be a number of seconds or a HTTP date.
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+
+	if retryAt, err := http.ParseTime(value); err == nil {
+		return time.Until(retryAt)
+	}
+
+	return 0
+}
@@ -0,0 +1,109 @@
+package keboola
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newFlakyServer(failures int, failureStatus int) (*httptest.Server, *int) {
+	attempts := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		body, _ := ioutil.ReadAll(r.Body)
+
+		if attempts <= failures {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(failureStatus)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write(body)
+	}))
+
+	return server, &attempts
+}
+
+func TestRetryTransport_RetriesTransientFailures(t *testing.T) {
+	server, attempts := newFlakyServer(2, http.StatusServiceUnavailable)
+	defer server.Close()
+
+	client := &KBCClient{StorageURL: server.URL + "/", MaxRetries: 3, MaxRetryWait: time.Millisecond}
+	response, err := client.PutToStorage("storage/tables/in.c-test.test", bytes.NewBufferString("name=test"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, response.StatusCode, "Request should succeed once the transient failures have passed")
+	assert.Equal(t, 3, *attempts, "Request should have been retried twice")
+
+	body, _ := ioutil.ReadAll(response.Body)
+	assert.Equal(t, "name=test", string(body), "Request body should be resent on every attempt")
+}
+
+func TestRetryTransport_GivesUpAfterMaxRetries(t *testing.T) {
+	server, attempts := newFlakyServer(10, http.StatusTooManyRequests)
+	defer server.Close()
+
+	client := &KBCClient{StorageURL: server.URL + "/", MaxRetries: 2, MaxRetryWait: time.Millisecond}
+	response, err := client.GetFromStorage("storage/buckets")
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusTooManyRequests, response.StatusCode, "Last failed response should be returned")
+	assert.Equal(t, 3, *attempts, "Request should have been attempted once, then retried twice")
+}
+
+func TestRetryTransport_DoesNotRetryUnsafePost(t *testing.T) {
+	server, attempts := newFlakyServer(1, http.StatusBadGateway)
+	defer server.Close()
+
+	client := &KBCClient{StorageURL: server.URL + "/", MaxRetries: 3, MaxRetryWait: time.Millisecond}
+	response, err := client.PostToStorage("storage/buckets", bytes.NewBufferString("name=test"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadGateway, response.StatusCode)
+	assert.Equal(t, 1, *attempts, "POST should not be retried after a 502, as it may have been processed")
+}
+
+func TestRetryTransport_AppliesTimeoutToEachAttempt(t *testing.T) {
+	attempts := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+
+		//The first attempt of the first request, and every attempt of the second, time out.
+		if attempts != 2 {
+			time.Sleep(200 * time.Millisecond)
+		}
+
+		w.Write([]byte("done"))
+	}))
+	defer server.Close()
+
+	client := &KBCClient{StorageURL: server.URL + "/", MaxRetries: 1, MaxRetryWait: time.Millisecond, RequestTimeout: 100 * time.Millisecond}
+	response, err := client.GetFromStorage("storage/buckets")
+
+	assert.NoError(t, err, "The request should only fail if every attempt times out")
+	assert.Equal(t, 2, attempts, "The attempt which timed out should have been retried")
+
+	body, _ := ioutil.ReadAll(response.Body)
+	assert.Equal(t, "done", string(body))
+
+	_, err = client.GetFromStorage("storage/buckets")
+
+	assert.Error(t, err, "The request should fail once every attempt has timed out")
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	assert.Equal(t, 5*time.Second, parseRetryAfter("5"))
+	assert.Equal(t, time.Duration(0), parseRetryAfter(""))
+	assert.Equal(t, time.Duration(0), parseRetryAfter("soon"))
+
+	retryAt := time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)
+	assert.InDelta(t, float64(time.Minute), float64(parseRetryAfter(retryAt)), float64(2*time.Second))
+}
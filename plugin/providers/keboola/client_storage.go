@@ -5,54 +5,44 @@ import (
 	"net/http"
 )
 
-const storageURL = "https://connection.keboola.com/v2/"
-
 //GetFromStorage requests an object from the Keboola Storage API.
 func (c *KBCClient) GetFromStorage(endpoint string) (*http.Response, error) {
-	client := &http.Client{}
-	req, err := http.NewRequest("GET", storageURL+endpoint, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	req.Header.Set("X-StorageApi-Token", c.APIKey)
-	return client.Do(req)
+	return c.send("GET", c.StorageURL+endpoint, nil, "")
 }
 
 //PostToStorage posts a new object to the Keboola Storage API.
 func (c *KBCClient) PostToStorage(endpoint string, formdata *bytes.Buffer) (*http.Response, error) {
-	client := &http.Client{}
-	req, err := http.NewRequest("POST", storageURL+endpoint, formdata)
-	if err != nil {
-		return nil, err
-	}
-
-	req.Header.Set("X-StorageApi-Token", c.APIKey)
-	req.Header.Add("content-type", "application/x-www-form-urlencoded")
-	return client.Do(req)
+	return c.send("POST", c.StorageURL+endpoint, formdata, "application/x-www-form-urlencoded")
 }
 
 //PutToStorage puts an existing object to the Keboola Storage API for update.
 func (c *KBCClient) PutToStorage(endpoint string, formData *bytes.Buffer) (*http.Response, error) {
-	client := &http.Client{}
-	req, err := http.NewRequest("PUT", storageURL+endpoint, formData)
-	if err != nil {
-		return nil, err
-	}
+	return c.send("PUT", c.StorageURL+endpoint, formData, "application/x-www-form-urlencoded")
+}
+
+//PostJSONToStorage posts a new object in JSON format to the Keboola Storage API.
+func (c *KBCClient) PostJSONToStorage(endpoint string, jsonpayload *bytes.Buffer) (*http.Response, error) {
+	return c.send("POST", c.StorageURL+endpoint, jsonpayload, "application/json")
+}
+
+//PutJSONToStorage puts an existing object in JSON format to the Keboola Storage API for update.
+func (c *KBCClient) PutJSONToStorage(endpoint string, jsonpayload *bytes.Buffer) (*http.Response, error) {
+	return c.send("PUT", c.StorageURL+endpoint, jsonpayload, "application/json")
+}
+
+//PostToFileUpload posts a file (or a multipart form holding it) to the file storage of the stack (such as S3),
+//for a prepared Storage file. The Storage API token is not sent with the request, only the given headers.
+func (c *KBCClient) PostToFileUpload(uploadURL string, content *bytes.Buffer, contentType string, headers map[string]string) (*http.Response, error) {
+	return c.sendWithHeaders(c.uploadHTTPClient(), "POST", uploadURL, content, contentType, headers)
+}
 
-	req.Header.Set("X-StorageApi-Token", c.APIKey)
-	req.Header.Add("content-type", "application/x-www-form-urlencoded")
-	return client.Do(req)
+//PutToFileUpload puts a file to the file storage of the stack (such as Azure Blob Storage), for a prepared
+//Storage file. The Storage API token is not sent with the request, only the given headers.
+func (c *KBCClient) PutToFileUpload(uploadURL string, content *bytes.Buffer, contentType string, headers map[string]string) (*http.Response, error) {
+	return c.sendWithHeaders(c.uploadHTTPClient(), "PUT", uploadURL, content, contentType, headers)
 }
 
 //DeleteFromStorage removes an existing object from the Keboola Storage API.
 func (c *KBCClient) DeleteFromStorage(endpoint string) (*http.Response, error) {
-	client := &http.Client{}
-	req, err := http.NewRequest("DELETE", storageURL+endpoint, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	req.Header.Set("X-StorageApi-Token", c.APIKey)
-	return client.Do(req)
+	return c.send("DELETE", c.StorageURL+endpoint, nil, "")
 }
@@ -5,80 +5,42 @@ import (
 	"net/http"
 )
 
-const syrupURL = "https://syrup.keboola.com/"
-
-//GetFromSyrup requests an object from the Keboola Syrup API.
-func (c *KBCClient) GetFromSyrup(endpoint string) (*http.Response, error) {
-	client := &http.Client{}
-	req, err := http.NewRequest("GET", syrupURL+endpoint, nil)
+//sendToSyrup makes a request to the Keboola Syrup API, using the Syrup URL for the configured stack.
+func (c *KBCClient) sendToSyrup(method string, endpoint string, body *bytes.Buffer, contentType string) (*http.Response, error) {
+	syrupURL, err := c.serviceURL("syrup")
 	if err != nil {
 		return nil, err
 	}
 
-	req.Header.Set("X-StorageApi-Token", c.APIKey)
-	return client.Do(req)
+	return c.send(method, syrupURL+endpoint, body, contentType)
+}
+
+//GetFromSyrup requests an object from the Keboola Syrup API.
+func (c *KBCClient) GetFromSyrup(endpoint string) (*http.Response, error) {
+	return c.sendToSyrup("GET", endpoint, nil, "")
 }
 
 //PostToSyrup posts a new object to the Keboola Syrup API.
 func (c *KBCClient) PostToSyrup(endpoint string, jsonpayload *bytes.Buffer) (*http.Response, error) {
-	client := &http.Client{}
-	req, err := http.NewRequest("POST", syrupURL+endpoint, jsonpayload)
-	if err != nil {
-		return nil, err
-	}
-
-	req.Header.Set("X-StorageApi-Token", c.APIKey)
-	req.Header.Add("content-type", "application/json")
-	return client.Do(req)
+	return c.sendToSyrup("POST", endpoint, jsonpayload, "application/json")
 }
 
 //PutToSyrup puts an existing object to the Keboola Syrup API for update.
 func (c *KBCClient) PutToSyrup(endpoint string, jsonpayload *bytes.Buffer) (*http.Response, error) {
-	client := &http.Client{}
-	req, err := http.NewRequest("PUT", syrupURL+endpoint, jsonpayload)
-	if err != nil {
-		return nil, err
-	}
-
-	req.Header.Set("X-StorageApi-Token", c.APIKey)
-	req.Header.Add("content-type", "application/json")
-	return client.Do(req)
+	return c.sendToSyrup("PUT", endpoint, jsonpayload, "application/json")
 }
 
 //PutFormToSyrup puts an existing object in Form encoded format to the Keboola Storage API for update.
 func (c *KBCClient) PutFormToSyrup(endpoint string, formdata *bytes.Buffer) (*http.Response, error) {
-	client := &http.Client{}
-	req, err := http.NewRequest("PUT", syrupURL+endpoint, formdata)
-	if err != nil {
-		return nil, err
-	}
-
-	req.Header.Set("X-StorageApi-Token", c.APIKey)
-	req.Header.Add("content-type", "application/x-www-form-urlencoded")
-	return client.Do(req)
+	return c.sendToSyrup("PUT", endpoint, formdata, "application/x-www-form-urlencoded")
 }
 
 //PatchOnSyrup applies a patch/changeset to an existing object on the Keboola Storage API.
 func (c *KBCClient) PatchOnSyrup(endpoint string, jsonpayload *bytes.Buffer) (*http.Response, error) {
-	client := &http.Client{}
-	req, err := http.NewRequest("PATCH", syrupURL+endpoint, jsonpayload)
-	if err != nil {
-		return nil, err
-	}
-
-	req.Header.Set("X-StorageApi-Token", c.APIKey)
-	req.Header.Add("content-type", "application/json")
-	return client.Do(req)
+	return c.sendToSyrup("PATCH", endpoint, jsonpayload, "application/json")
 }
 
 //DeleteFromSyrup removes an existing object from the Keboola Syrup API.
 func (c *KBCClient) DeleteFromSyrup(endpoint string) (*http.Response, error) {
-	client := &http.Client{}
-	req, err := http.NewRequest("DELETE", syrupURL+endpoint, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	req.Header.Set("X-StorageApi-Token", c.APIKey)
-	return client.Do(req)
+	return c.sendToSyrup("DELETE", endpoint, nil, "")
 }
@@ -0,0 +1,149 @@
+package keboola
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKBCClient_SharesHTTPClient(t *testing.T) {
+	client := &KBCClient{RequestTimeout: 30 * time.Second}
+
+	assert.True(t, client.httpClient() == client.httpClient(), "The same HTTP client should be used for every request")
+	assert.Equal(t, time.Duration(0), client.httpClient().Timeout, "The request timeout should not limit the retries of a request")
+	assert.Equal(t, 30*time.Second, client.httpClient().Transport.(*retryTransport).AttemptTimeout, "The configured request timeout should be applied to each attempt")
+	assert.Equal(t, defaultRequestTimeout, (&KBCClient{}).httpClient().Transport.(*retryTransport).AttemptTimeout, "The default request timeout should be applied when none is configured")
+}
+
+func TestKBCClient_UploadsWithoutRequestTimeout(t *testing.T) {
+	client := &KBCClient{RequestTimeout: 30 * time.Second}
+
+	assert.True(t, client.uploadHTTPClient() == client.uploadHTTPClient(), "The same HTTP client should be used for every upload")
+	assert.True(t, client.uploadHTTPClient() != client.httpClient(), "Uploads should not use the HTTP client of the Keboola APIs")
+	assert.Equal(t, time.Duration(0), client.uploadHTTPClient().Timeout, "No overall timeout should limit an upload")
+	assert.Equal(t, time.Duration(0), client.uploadHTTPClient().Transport.(*retryTransport).AttemptTimeout, "The request timeout should not limit an upload")
+}
+
+func TestKBCClient_RetriesFailedServiceDiscovery(t *testing.T) {
+	indexRequests := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		indexRequests++
+
+		if indexRequests == 1 {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"error":"Access denied","code":"accessDenied"}`))
+			return
+		}
+
+		w.Write([]byte(`{"services":[{"id":"syrup","url":"https://syrup.keboola.com"}]}`))
+	}))
+	defer server.Close()
+
+	client := &KBCClient{StorageURL: server.URL + "/v2/"}
+
+	_, err := client.serviceURL("syrup")
+	assert.Error(t, err)
+
+	syrupURL, err := client.serviceURL("syrup")
+	assert.NoError(t, err, "A failed service discovery should be retried")
+	assert.Equal(t, "https://syrup.keboola.com/", syrupURL)
+
+	_, err = client.serviceURL("import")
+	assert.Error(t, err)
+	assert.Equal(t, 2, indexRequests, "A successful service discovery should be kept")
+}
+
+func TestKBCClient_BuffersResponseBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "test-token", r.Header.Get("X-StorageApi-Token"))
+		w.Write([]byte(`{"id":"in.c-test"}`))
+	}))
+	defer server.Close()
+
+	client := &KBCClient{APIKey: "test-token", StorageURL: server.URL + "/v2/"}
+	response, err := client.GetFromStorage("storage/buckets/in.c-test")
+
+	assert.NoError(t, err)
+
+	content, err := ioutil.ReadAll(response.Body)
+
+	assert.NoError(t, err)
+	assert.Equal(t, `{"id":"in.c-test"}`, string(content), "Response body should still be readable after the connection is released")
+}
+
+func TestKBCAPIError_ParsesErrorBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error":"The table \"test\" was not found in the bucket \"in.c-test\"","code":"storage.tables.notFound","status":"error","exceptionId":"exception-1234"}`))
+	}))
+	defer server.Close()
+
+	client := &KBCClient{APIKey: "secret-token", StorageURL: server.URL + "/v2/"}
+	response, err := client.GetFromStorage("storage/tables/in.c-test.test")
+
+	assert.True(t, hasErrors(err, response))
+
+	err = extractError(err, response)
+	apiError, ok := err.(*KBCAPIError)
+
+	assert.True(t, ok, "Error should be a KBCAPIError")
+	assert.Equal(t, http.StatusNotFound, apiError.StatusCode)
+	assert.Equal(t, "storage.tables.notFound", apiError.Code)
+	assert.Equal(t, "exception-1234", apiError.ExceptionID)
+	assert.Equal(t, "GET", apiError.Method)
+	assert.Equal(t, "/v2/storage/tables/in.c-test.test", apiError.Endpoint)
+	assert.True(t, isNotFoundError(err))
+	assert.True(t, hasErrorCode(err, "storage.tables.notFound"))
+	assert.NotContains(t, err.Error(), "secret-token", "Error message should never contain the Storage API token")
+}
+
+func TestKBCAPIError_NonJSONBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("Bad Request\n"))
+	}))
+	defer server.Close()
+
+	client := &KBCClient{StorageURL: server.URL + "/v2/"}
+	response, err := client.PostToStorage("storage/buckets", nil)
+
+	err = extractError(err, response)
+
+	assert.Equal(t, "Keboola API error (400) on POST /v2/storage/buckets: Bad Request", err.Error())
+	assert.False(t, isNotFoundError(err))
+}
+
+func TestKBCAPIError_NumericErrorCode(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":"Table orders not found in writer testwriter","code":400,"status":"error"}`))
+	}))
+	defer server.Close()
+
+	client := &KBCClient{StorageURL: server.URL + "/v2/"}
+	response, err := client.GetFromStorage("storage/tables/in.c-test.test")
+
+	err = extractError(err, response)
+
+	assert.False(t, isNotFoundError(err))
+	assert.True(t, hasErrorCode(err, "400"))
+	assert.True(t, isGoodDataTableNotFoundError(err, "orders"))
+	assert.False(t, isGoodDataTableNotFoundError(err, "order"), "Only the table which was not found should be reported as missing")
+	assert.False(t, hasErrorCode(err, "storage.tables.notFound"))
+}
+
+func TestCreateResourceResult_AcceptsNumericAndStringIDs(t *testing.T) {
+	var numericResult, stringResult CreateResourceResult
+
+	assert.NoError(t, json.Unmarshal([]byte(`{"id": 1234}`), &numericResult))
+	assert.NoError(t, json.Unmarshal([]byte(`{"id": "in.c-test"}`), &stringResult), "String IDs (such as bucket IDs) should be accepted")
+
+	assert.Equal(t, "1234", string(numericResult.ID))
+	assert.Equal(t, "in.c-test", string(stringResult.ID))
+}
@@ -0,0 +1,76 @@
+package keboola
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+//TokenVerification holds the details of the Storage API token used by the provider,
+//and of the project (the token owner) that it gives access to.
+type TokenVerification struct {
+	ID                    string            `json:"id"`
+	Description           string            `json:"description"`
+	IsMasterToken         bool              `json:"isMasterToken"`
+	CanManageBuckets      bool              `json:"canManageBuckets"`
+	CanManageTokens       bool              `json:"canManageTokens"`
+	CanReadAllFileUploads bool              `json:"canReadAllFileUploads"`
+	BucketPermissions     map[string]string `json:"bucketPermissions"`
+	ComponentAccess       []string          `json:"componentAccess"`
+	Owner                 struct {
+		ID             int    `json:"id"`
+		Name           string `json:"name"`
+		DefaultBackend string `json:"defaultBackend"`
+	} `json:"owner"`
+	Admin *struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	} `json:"admin,omitempty"`
+}
+
+//verifyToken checks that the Storage API token is valid for the configured stack, and
+//keeps the details of the token and its project on the client for later use.
+func (c *KBCClient) verifyToken() error {
+	verifyResponse, err := c.GetFromStorage("storage/tokens/verify")
+
+	if hasErrors(err, verifyResponse) {
+		err = extractError(err, verifyResponse)
+
+		if apiError, ok := err.(*KBCAPIError); ok && (apiError.StatusCode == http.StatusUnauthorized || apiError.StatusCode == http.StatusForbidden) {
+			return fmt.Errorf("the Storage API token (api_key) is not valid for the Keboola stack at %s: %s", c.StorageURL, apiError.Message)
+		}
+
+		return fmt.Errorf("unable to verify the Storage API token (api_key) against the Keboola stack at %s: %s", c.StorageURL, err)
+	}
+
+	var tokenVerification TokenVerification
+
+	decoder := json.NewDecoder(verifyResponse.Body)
+	err = decoder.Decode(&tokenVerification)
+
+	if err != nil {
+		return err
+	}
+
+	c.Token = &tokenVerification
+
+	return nil
+}
+
+//ProjectID returns the ID of the Keboola project the Storage API token belongs to.
+func (c *KBCClient) ProjectID() int {
+	if c.Token == nil {
+		return 0
+	}
+
+	return c.Token.Owner.ID
+}
+
+//ProjectName returns the name of the Keboola project the Storage API token belongs to.
+func (c *KBCClient) ProjectName() string {
+	if c.Token == nil {
+		return ""
+	}
+
+	return c.Token.Owner.Name
+}
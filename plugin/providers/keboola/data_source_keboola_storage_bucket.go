@@ -0,0 +1,140 @@
+package keboola
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+//storageBucketDataSourceAttributes returns the attributes which the storage bucket data sources expose for each bucket.
+func storageBucketDataSourceAttributes() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"id": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"name": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"stage": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"description": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"backend": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"is_linked": {
+			Type:     schema.TypeBool,
+			Computed: true,
+		},
+		"source_bucket_id": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"rows_count": {
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+		"data_size_bytes": {
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+		"created": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"last_change_date": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"metadata": &storageMetadataDataSourceSchema,
+	}
+}
+
+//flattenStorageBucket converts a bucket to the attributes exposed by the storage bucket data sources.
+func flattenStorageBucket(storageBucket StorageBucket) map[string]interface{} {
+	sourceBucketID := ""
+	if storageBucket.SourceBucket != nil {
+		sourceBucketID = storageBucket.SourceBucket.ID
+	}
+
+	return map[string]interface{}{
+		"id":               storageBucket.ID,
+		"name":             strings.TrimPrefix(storageBucket.Name, "c-"),
+		"stage":            storageBucket.Stage,
+		"description":      storageBucket.Description,
+		"backend":          storageBucket.Backend,
+		"is_linked":        storageBucket.SourceBucket != nil,
+		"source_bucket_id": sourceBucketID,
+		"rows_count":       storageBucket.RowsCount,
+		"data_size_bytes":  storageBucket.DataSizeBytes,
+		"created":          storageBucket.Created,
+		"last_change_date": storageBucket.LastChangeDate,
+		"metadata":         flattenStorageMetadata(storageBucket.Metadata),
+	}
+}
+
+func dataSourceKeboolaStorageBucket() *schema.Resource {
+	attributes := storageBucketDataSourceAttributes()
+
+	attributes["id"].Optional = true
+	attributes["id"].Description = "The ID of the bucket (e.g. in.c-main). Either id, or both stage and name, have to be set."
+	attributes["name"].Optional = true
+	attributes["name"].Description = "The name of the bucket, without its c- prefix."
+	attributes["stage"].Optional = true
+	attributes["stage"].ValidateFunc = validateStorageBucketStage
+
+	return &schema.Resource{
+		Read:   dataSourceKeboolaStorageBucketRead,
+		Schema: attributes,
+	}
+}
+
+func dataSourceKeboolaStorageBucketRead(d *schema.ResourceData, meta interface{}) error {
+	bucketID := d.Get("id").(string)
+
+	if bucketID == "" {
+		name, stage := d.Get("name").(string), d.Get("stage").(string)
+
+		if name == "" || stage == "" {
+			return fmt.Errorf("either id, or both stage and name, have to be set to look up a storage bucket")
+		}
+
+		bucketID = fmt.Sprintf("%s.c-%s", stage, strings.TrimPrefix(name, "c-"))
+	}
+
+	log.Printf("[INFO] Looking up Storage Bucket %s in Keboola.", bucketID)
+
+	client := meta.(*KBCClient)
+	getResponse, err := client.GetFromStorage(fmt.Sprintf("storage/buckets/%s", bucketID))
+
+	if hasErrors(err, getResponse) {
+		return extractError(err, getResponse)
+	}
+
+	var storageBucket StorageBucket
+
+	decoder := json.NewDecoder(getResponse.Body)
+	err = decoder.Decode(&storageBucket)
+
+	if err != nil {
+		return err
+	}
+
+	for key, value := range flattenStorageBucket(storageBucket) {
+		d.Set(key, value)
+	}
+
+	d.SetId(storageBucket.ID)
+
+	return nil
+}
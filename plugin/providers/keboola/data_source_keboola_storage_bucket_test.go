@@ -0,0 +1,136 @@
+package keboola
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+const testStorageBucketDataSourceResources = `
+	resource "keboola_storage_bucket" "sales" {
+		name = "sales"
+		stage = "in"
+		backend = "snowflake"
+
+		metadata = {
+			"KBC.description" = "Sales data"
+		}
+	}
+
+	resource "keboola_storage_bucket" "sales_reports" {
+		name = "sales_reports"
+		stage = "out"
+		backend = "snowflake"
+	}
+
+	resource "keboola_storage_bucket" "marketing" {
+		name = "marketing"
+		stage = "in"
+		backend = "snowflake"
+	}
+
+	resource "keboola_storage_table" "orders" {
+		bucket_id = "${keboola_storage_bucket.sales.id}"
+		name = "orders"
+		columns = [ "id", "amount" ]
+	}`
+
+const testStorageBucketDataSources = testStorageBucketDataSourceResources + `
+
+	data "keboola_storage_bucket" "by_id" {
+		id = "${keboola_storage_bucket.sales.id}"
+	}
+
+	data "keboola_storage_bucket" "by_name" {
+		stage = "${keboola_storage_bucket.sales_reports.stage}"
+		name = "${keboola_storage_bucket.sales_reports.name}"
+	}
+
+	data "keboola_storage_buckets" "all" {
+	}
+
+	data "keboola_storage_buckets" "input_sales" {
+		stage = "in"
+		name_regex = "^sales"
+	}`
+
+func TestAccStorageBucketDataSource_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckStorageBucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testStorageBucketDataSourceResources,
+			},
+			{
+				Config: testStorageBucketDataSources,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.keboola_storage_bucket.by_id", "name", "sales"),
+					resource.TestCheckResourceAttr("data.keboola_storage_buckets.input_sales", "ids.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitStorageBucketDataSource_Basic(t *testing.T) {
+	mock := newMockKeboolaAPI()
+	defer mock.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    mock.providers(),
+		CheckDestroy: mock.testCheckDestroy("keboola_storage_bucket", "/v2/storage/buckets/%s", "id"),
+		Steps: []resource.TestStep{
+			{
+				Config: mock.config(testStorageBucketDataSourceResources),
+			},
+			{
+				PreConfig: func() {
+					mock.mutex.Lock()
+					defer mock.mutex.Unlock()
+
+					mock.tables["in.c-sales.orders"].RowsCount = 3
+					mock.tables["in.c-sales.orders"].DataSizeBytes = 1024
+				},
+				Config: mock.config(testStorageBucketDataSources),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.keboola_storage_bucket.by_id", "id", "in.c-sales"),
+					resource.TestCheckResourceAttr("data.keboola_storage_bucket.by_id", "name", "sales"),
+					resource.TestCheckResourceAttr("data.keboola_storage_bucket.by_id", "stage", "in"),
+					resource.TestCheckResourceAttr("data.keboola_storage_bucket.by_id", "backend", "snowflake"),
+					resource.TestCheckResourceAttr("data.keboola_storage_bucket.by_id", "rows_count", "3"),
+					resource.TestCheckResourceAttr("data.keboola_storage_bucket.by_id", "data_size_bytes", "1024"),
+					resource.TestCheckResourceAttr("data.keboola_storage_bucket.by_id", "metadata.#", "1"),
+					resource.TestCheckResourceAttr("data.keboola_storage_bucket.by_id", "metadata.0.key", "KBC.description"),
+					resource.TestCheckResourceAttr("data.keboola_storage_bucket.by_id", "metadata.0.value", "Sales data"),
+					resource.TestCheckResourceAttr("data.keboola_storage_bucket.by_id", "metadata.0.provider", "terraform"),
+					resource.TestCheckResourceAttr("data.keboola_storage_bucket.by_name", "id", "out.c-sales_reports"),
+					resource.TestCheckResourceAttr("data.keboola_storage_bucket.by_name", "is_linked", "false"),
+					resource.TestCheckResourceAttr("data.keboola_storage_buckets.all", "ids.#", "3"),
+					resource.TestCheckResourceAttr("data.keboola_storage_buckets.input_sales", "ids.#", "1"),
+					resource.TestCheckResourceAttr("data.keboola_storage_buckets.input_sales", "ids.0", "in.c-sales"),
+					resource.TestCheckResourceAttr("data.keboola_storage_buckets.input_sales", "buckets.0.name", "sales"),
+					resource.TestCheckResourceAttr("data.keboola_storage_buckets.input_sales", "buckets.0.rows_count", "3"),
+				),
+			},
+			{
+				Config: mock.config(testStorageBucketDataSourceResources + `
+
+	data "keboola_storage_bucket" "missing" {
+		id = "in.c-missing"
+	}`),
+				ExpectError: regexp.MustCompile("Bucket in.c-missing not found"),
+			},
+			{
+				Config: mock.config(testStorageBucketDataSourceResources + `
+
+	data "keboola_storage_bucket" "incomplete" {
+		stage = "in"
+	}`),
+				ExpectError: regexp.MustCompile("either id, or both stage and name, have to be set"),
+			},
+		},
+	})
+}
@@ -0,0 +1,91 @@
+package keboola
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/hashcode"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataSourceKeboolaStorageBuckets() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceKeboolaStorageBucketsRead,
+
+		Schema: map[string]*schema.Schema{
+			"stage": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateStorageBucketStage,
+				Description:  "Only list the buckets of this stage (in or out).",
+			},
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateRegexp,
+				Description:  "Only list the buckets whose name (without its c- prefix) matches this regular expression.",
+			},
+			"ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"buckets": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: storageBucketDataSourceAttributes(),
+				},
+			},
+		},
+	}
+}
+
+func dataSourceKeboolaStorageBucketsRead(d *schema.ResourceData, meta interface{}) error {
+	log.Println("[INFO] Listing Storage Buckets in Keboola.")
+
+	stage := d.Get("stage").(string)
+	nameRegex := regexp.MustCompile(d.Get("name_regex").(string))
+
+	client := meta.(*KBCClient)
+	getResponse, err := client.GetFromStorage("storage/buckets?include=metadata")
+
+	if hasErrors(err, getResponse) {
+		return extractError(err, getResponse)
+	}
+
+	var storageBuckets []StorageBucket
+
+	decoder := json.NewDecoder(getResponse.Body)
+	err = decoder.Decode(&storageBuckets)
+
+	if err != nil {
+		return err
+	}
+
+	var ids []string
+	var buckets []map[string]interface{}
+
+	for _, storageBucket := range storageBuckets {
+		if stage != "" && storageBucket.Stage != stage {
+			continue
+		}
+
+		if !nameRegex.MatchString(strings.TrimPrefix(storageBucket.Name, "c-")) {
+			continue
+		}
+
+		ids = append(ids, storageBucket.ID)
+		buckets = append(buckets, flattenStorageBucket(storageBucket))
+	}
+
+	d.Set("ids", ids)
+	d.Set("buckets", buckets)
+	d.SetId(strconv.Itoa(hashcode.String(fmt.Sprintf("%s/%s", stage, d.Get("name_regex")))))
+
+	return nil
+}
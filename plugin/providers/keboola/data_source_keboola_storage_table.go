@@ -0,0 +1,139 @@
+package keboola
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+//storageTableDataSourceAttributes returns the attributes which the storage table data sources expose for each table.
+func storageTableDataSourceAttributes() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"id": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"bucket_id": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"name": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"columns": {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+		"primary_key": {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+		"is_alias": {
+			Type:     schema.TypeBool,
+			Computed: true,
+		},
+		"is_typed": {
+			Type:     schema.TypeBool,
+			Computed: true,
+		},
+		"rows_count": {
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+		"data_size_bytes": {
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+		"created": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"last_import_date": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"last_change_date": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"metadata": &storageMetadataDataSourceSchema,
+	}
+}
+
+//flattenStorageTable converts a table to the attributes exposed by the storage table data sources.
+func flattenStorageTable(storageTable StorageTable) map[string]interface{} {
+	return map[string]interface{}{
+		"id":               storageTable.ID,
+		"bucket_id":        storageTable.Bucket.ID,
+		"name":             storageTable.Name,
+		"columns":          storageTable.Columns,
+		"primary_key":      storageTable.PrimaryKey,
+		"is_alias":         storageTable.IsAlias,
+		"is_typed":         storageTable.IsTyped,
+		"rows_count":       storageTable.RowsCount,
+		"data_size_bytes":  storageTable.DataSizeBytes,
+		"created":          storageTable.Created,
+		"last_import_date": storageTable.LastImportDate,
+		"last_change_date": storageTable.LastChangeDate,
+		"metadata":         flattenStorageMetadata(storageTable.Metadata),
+	}
+}
+
+func dataSourceKeboolaStorageTable() *schema.Resource {
+	attributes := storageTableDataSourceAttributes()
+
+	attributes["id"].Optional = true
+	attributes["id"].Description = "The ID of the table (e.g. in.c-main.customers). Either id, or both bucket_id and name, have to be set."
+	attributes["bucket_id"].Optional = true
+	attributes["name"].Optional = true
+
+	return &schema.Resource{
+		Read:   dataSourceKeboolaStorageTableRead,
+		Schema: attributes,
+	}
+}
+
+func dataSourceKeboolaStorageTableRead(d *schema.ResourceData, meta interface{}) error {
+	tableID := d.Get("id").(string)
+
+	if tableID == "" {
+		bucketID, name := d.Get("bucket_id").(string), d.Get("name").(string)
+
+		if bucketID == "" || name == "" {
+			return fmt.Errorf("either id, or both bucket_id and name, have to be set to look up a storage table")
+		}
+
+		tableID = fmt.Sprintf("%s.%s", bucketID, name)
+	}
+
+	log.Printf("[INFO] Looking up Storage Table %s in Keboola.", tableID)
+
+	client := meta.(*KBCClient)
+	getResponse, err := client.GetFromStorage(fmt.Sprintf("storage/tables/%s", tableID))
+
+	if hasErrors(err, getResponse) {
+		return extractError(err, getResponse)
+	}
+
+	var storageTable StorageTable
+
+	decoder := json.NewDecoder(getResponse.Body)
+	err = decoder.Decode(&storageTable)
+
+	if err != nil {
+		return err
+	}
+
+	for key, value := range flattenStorageTable(storageTable) {
+		d.Set(key, value)
+	}
+
+	d.SetId(storageTable.ID)
+
+	return nil
+}
@@ -0,0 +1,141 @@
+package keboola
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+const testStorageTableDataSourceResources = `
+	resource "keboola_storage_bucket" "sales" {
+		name = "sales"
+		stage = "in"
+		backend = "snowflake"
+	}
+
+	resource "keboola_storage_bucket" "marketing" {
+		name = "marketing"
+		stage = "in"
+		backend = "snowflake"
+	}
+
+	resource "keboola_storage_table" "orders" {
+		bucket_id = "${keboola_storage_bucket.sales.id}"
+		name = "orders"
+		columns = [ "id", "amount" ]
+		primary_key = [ "id" ]
+		deletion_protection = false
+
+		metadata = {
+			"KBC.description" = "Orders"
+		}
+	}
+
+	resource "keboola_storage_table" "order_items" {
+		bucket_id = "${keboola_storage_bucket.sales.id}"
+		name = "order_items"
+		columns = [ "order_id", "product" ]
+	}
+
+	resource "keboola_storage_table" "campaigns" {
+		bucket_id = "${keboola_storage_bucket.marketing.id}"
+		name = "campaigns"
+		columns = [ "id" ]
+	}`
+
+const testStorageTableDataSources = testStorageTableDataSourceResources + `
+
+	data "keboola_storage_table" "by_id" {
+		id = "${keboola_storage_table.orders.id}"
+	}
+
+	data "keboola_storage_table" "by_name" {
+		bucket_id = "${keboola_storage_bucket.marketing.id}"
+		name = "${keboola_storage_table.campaigns.name}"
+	}
+
+	data "keboola_storage_tables" "all" {
+	}
+
+	data "keboola_storage_tables" "sales_orders" {
+		bucket_id = "${keboola_storage_bucket.sales.id}"
+		name_regex = "^order"
+	}`
+
+func TestAccStorageTableDataSource_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testAccCheckStorageTableDestroy,
+			testAccCheckStorageBucketDestroy,
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: testStorageTableDataSourceResources,
+			},
+			{
+				Config: testStorageTableDataSources,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.keboola_storage_table.by_id", "columns.#", "2"),
+					resource.TestCheckResourceAttr("data.keboola_storage_tables.sales_orders", "ids.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitStorageTableDataSource_Basic(t *testing.T) {
+	mock := newMockKeboolaAPI()
+	defer mock.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    mock.providers(),
+		CheckDestroy: mock.testCheckDestroy("keboola_storage_table", "/v2/storage/tables/%s", "id"),
+		Steps: []resource.TestStep{
+			{
+				Config: mock.config(testStorageTableDataSourceResources + `
+
+	data "keboola_storage_tables" "invalid" {
+		name_regex = "("
+	}`),
+				ExpectError: regexp.MustCompile(`"name_regex" must be a valid regular expression`),
+			},
+			{
+				Config: mock.config(testStorageTableDataSourceResources),
+			},
+			{
+				PreConfig: func() {
+					mock.mutex.Lock()
+					defer mock.mutex.Unlock()
+
+					mock.tables["in.c-sales.orders"].RowsCount = 42
+					mock.tables["in.c-sales.orders"].DataSizeBytes = 2048
+					mock.tables["in.c-sales.orders"].LastImportDate = "2020-03-01T10:00:00+0100"
+				},
+				Config: mock.config(testStorageTableDataSources),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.keboola_storage_table.by_id", "bucket_id", "in.c-sales"),
+					resource.TestCheckResourceAttr("data.keboola_storage_table.by_id", "name", "orders"),
+					resource.TestCheckResourceAttr("data.keboola_storage_table.by_id", "columns.#", "2"),
+					resource.TestCheckResourceAttr("data.keboola_storage_table.by_id", "primary_key.#", "1"),
+					resource.TestCheckResourceAttr("data.keboola_storage_table.by_id", "primary_key.0", "id"),
+					resource.TestCheckResourceAttr("data.keboola_storage_table.by_id", "rows_count", "42"),
+					resource.TestCheckResourceAttr("data.keboola_storage_table.by_id", "data_size_bytes", "2048"),
+					resource.TestCheckResourceAttr("data.keboola_storage_table.by_id", "last_import_date", "2020-03-01T10:00:00+0100"),
+					resource.TestCheckResourceAttr("data.keboola_storage_table.by_id", "is_alias", "false"),
+					resource.TestCheckResourceAttr("data.keboola_storage_table.by_id", "metadata.#", "1"),
+					resource.TestCheckResourceAttr("data.keboola_storage_table.by_id", "metadata.0.value", "Orders"),
+					resource.TestCheckResourceAttr("data.keboola_storage_table.by_name", "id", "in.c-marketing.campaigns"),
+					resource.TestCheckResourceAttr("data.keboola_storage_table.by_name", "columns.0", "id"),
+					resource.TestCheckResourceAttr("data.keboola_storage_tables.all", "ids.#", "3"),
+					resource.TestCheckResourceAttr("data.keboola_storage_tables.sales_orders", "ids.#", "2"),
+					resource.TestCheckResourceAttr("data.keboola_storage_tables.sales_orders", "ids.0", "in.c-sales.order_items"),
+					resource.TestCheckResourceAttr("data.keboola_storage_tables.sales_orders", "tables.1.name", "orders"),
+					resource.TestCheckResourceAttr("data.keboola_storage_tables.sales_orders", "tables.1.rows_count", "42"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,90 @@
+package keboola
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"regexp"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/hashcode"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataSourceKeboolaStorageTables() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceKeboolaStorageTablesRead,
+
+		Schema: map[string]*schema.Schema{
+			"bucket_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only list the tables of this bucket.",
+			},
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateRegexp,
+				Description:  "Only list the tables whose name matches this regular expression.",
+			},
+			"ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"tables": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: storageTableDataSourceAttributes(),
+				},
+			},
+		},
+	}
+}
+
+func dataSourceKeboolaStorageTablesRead(d *schema.ResourceData, meta interface{}) error {
+	log.Println("[INFO] Listing Storage Tables in Keboola.")
+
+	bucketID := d.Get("bucket_id").(string)
+	nameRegex := regexp.MustCompile(d.Get("name_regex").(string))
+
+	tablesURI := "storage/tables?include=columns,metadata"
+	if bucketID != "" {
+		tablesURI = fmt.Sprintf("storage/buckets/%s/tables?include=columns,metadata", bucketID)
+	}
+
+	client := meta.(*KBCClient)
+	getResponse, err := client.GetFromStorage(tablesURI)
+
+	if hasErrors(err, getResponse) {
+		return extractError(err, getResponse)
+	}
+
+	var storageTables []StorageTable
+
+	decoder := json.NewDecoder(getResponse.Body)
+	err = decoder.Decode(&storageTables)
+
+	if err != nil {
+		return err
+	}
+
+	var ids []string
+	var tables []map[string]interface{}
+
+	for _, storageTable := range storageTables {
+		if !nameRegex.MatchString(storageTable.Name) {
+			continue
+		}
+
+		ids = append(ids, storageTable.ID)
+		tables = append(tables, flattenStorageTable(storageTable))
+	}
+
+	d.Set("ids", ids)
+	d.Set("tables", tables)
+	d.SetId(strconv.Itoa(hashcode.String(fmt.Sprintf("%s/%s", bucketID, d.Get("name_regex")))))
+
+	return nil
+}
@@ -29,6 +29,11 @@ type KBCNumberString string
 //UnmarshalJSON handles unmarshaling a KBCNumberString in to JSON.
 func (kns *KBCNumberString) UnmarshalJSON(data []byte) error {
 	asString := string(data)
+
+	if unquoted, err := strconv.Unquote(asString); err == nil {
+		asString = unquoted
+	}
+
 	*kns = KBCNumberString(asString)
 	return nil
 }
@@ -1,14 +1,51 @@
 package keboola
 
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	defaultJobTimeout     = 10 * time.Minute
+	minJobPollingInterval = 250 * time.Millisecond
+	maxJobPollingInterval = 10 * time.Second
+)
+
 //StorageJobStatus contains the job status and results for Storage API based jobs.
 type StorageJobStatus struct {
-	ID      int    `json:"id"`
-	URL     string `json:"url"`
-	Status  string `json:"status"`
-	Results struct {
-		ID   string `json:"id"`
-		Name string `json:"name"`
-	} `json:"results"`
+	ID      int               `json:"id"`
+	URL     string            `json:"url"`
+	Status  string            `json:"status"`
+	Results StorageJobResults `json:"results"`
+	Error   struct {
+		Code        string `json:"code"`
+		Message     string `json:"message"`
+		ExceptionID string `json:"exceptionId"`
+	} `json:"error"`
+}
+
+//StorageJobResults contains the results of a Storage API job. Only jobs which create
+//or update an object return an object here, other jobs return null or an empty array.
+//Most objects have a string ID, but some (such as development branches) are numbered.
+type StorageJobResults struct {
+	ID   KBCNumberString `json:"id"`
+	Name string          `json:"name"`
+}
+
+//UnmarshalJSON handles unmarshaling StorageJobResults, ignoring results which are not objects.
+func (r *StorageJobResults) UnmarshalJSON(data []byte) error {
+	if !bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		return nil
+	}
+
+	type results StorageJobResults
+	return json.Unmarshal(data, (*results)(r))
 }
 
 //SyrupJobStatus contains the job status and results for Syrup API based jobs.
@@ -16,4 +53,180 @@ type SyrupJobStatus struct {
 	ID     int    `json:"id"`
 	URL    string `json:"url"`
 	Status string `json:"status"`
+	Result struct {
+		Message string `json:"message"`
+	} `json:"result"`
+}
+
+//KBCJobError is returned when an asynchronous Keboola job finishes without succeeding.
+type KBCJobError struct {
+	JobID       int
+	Status      string
+	Message     string
+	Code        string
+	ExceptionID string
+}
+
+func (e *KBCJobError) Error() string {
+	message := fmt.Sprintf("Keboola job %d finished with status %s", e.JobID, e.Status)
+
+	if e.Message != "" {
+		message += ": " + e.Message
+	}
+
+	if e.Code != "" {
+		message += fmt.Sprintf(" [code: %s]", e.Code)
+	}
+
+	if e.ExceptionID != "" {
+		message += fmt.Sprintf(" [exception ID: %s]", e.ExceptionID)
+	}
+
+	return message
+}
+
+//pollJob calls checkStatus until it reports that the job has finished, backing off exponentially
+//between calls. Polling stops with an error once the timeout elapses, or if the provider is stopped.
+func (c *KBCClient) pollJob(description string, timeout time.Duration, checkStatus func() (bool, error)) error {
+	if timeout <= 0 {
+		timeout = defaultJobTimeout
+	}
+
+	deadline := time.Now().Add(timeout)
+	interval := minJobPollingInterval
+
+	for {
+		finished, err := checkStatus()
+
+		if err != nil || finished {
+			return err
+		}
+
+		if time.Now().Add(interval).After(deadline) {
+			return fmt.Errorf("timed out after %s waiting for %s to finish", timeout, description)
+		}
+
+		log.Printf("[DEBUG] Waiting %s for %s to finish", interval, description)
+
+		select {
+		case <-c.stopContext().Done():
+			return fmt.Errorf("cancelled while waiting for %s to finish", description)
+		case <-time.After(interval):
+		}
+
+		interval *= 2
+		if interval > maxJobPollingInterval {
+			interval = maxJobPollingInterval
+		}
+	}
+}
+
+//waitForStorageJob waits for a Storage API job to finish, returning a KBCJobError
+//with the job's error message if it did not succeed.
+func (c *KBCClient) waitForStorageJob(jobID int, timeout time.Duration) (*StorageJobStatus, error) {
+	var jobStatus StorageJobStatus
+
+	err := c.pollJob(fmt.Sprintf("storage job %d", jobID), timeout, func() (bool, error) {
+		jobStatusResponse, err := c.GetFromStorage(fmt.Sprintf("storage/jobs/%v", jobID))
+
+		if hasErrors(err, jobStatusResponse) {
+			return false, extractError(err, jobStatusResponse)
+		}
+
+		decoder := json.NewDecoder(jobStatusResponse.Body)
+		err = decoder.Decode(&jobStatus)
+
+		if err != nil {
+			return false, err
+		}
+
+		return jobStatus.Status == "success" || jobStatus.Status == "error", nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if jobStatus.Status != "success" {
+		return &jobStatus, &KBCJobError{
+			JobID:       jobStatus.ID,
+			Status:      jobStatus.Status,
+			Message:     jobStatus.Error.Message,
+			Code:        jobStatus.Error.Code,
+			ExceptionID: jobStatus.Error.ExceptionID,
+		}
+	}
+
+	return &jobStatus, nil
+}
+
+//waitForAcceptedStorageJob waits for the Storage API job started by a request, if the request was
+//accepted as an asynchronous job (202 Accepted) rather than being completed straight away.
+func (c *KBCClient) waitForAcceptedStorageJob(response *http.Response, timeout time.Duration) (*StorageJobStatus, error) {
+	if response.StatusCode != http.StatusAccepted {
+		return nil, nil
+	}
+
+	var acceptedJob StorageJobStatus
+
+	decoder := json.NewDecoder(response.Body)
+	err := decoder.Decode(&acceptedJob)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return c.waitForStorageJob(acceptedJob.ID, timeout)
+}
+
+//waitForSyrupJob waits for a Syrup API job (identified by the job URL returned when it was
+//created) to finish, returning a KBCJobError with the job's message if it did not succeed.
+func (c *KBCClient) waitForSyrupJob(jobURL string, timeout time.Duration) (*SyrupJobStatus, error) {
+	parsedJobURL, err := url.Parse(jobURL)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var jobStatus SyrupJobStatus
+
+	err = c.pollJob(fmt.Sprintf("syrup job %s", jobURL), timeout, func() (bool, error) {
+		jobStatusResponse, err := c.GetFromSyrup(strings.TrimLeft(parsedJobURL.Path, "/"))
+
+		if hasErrors(err, jobStatusResponse) {
+			return false, extractError(err, jobStatusResponse)
+		}
+
+		decoder := json.NewDecoder(jobStatusResponse.Body)
+		err = decoder.Decode(&jobStatus)
+
+		if err != nil {
+			return false, err
+		}
+
+		return isFinishedSyrupJobStatus(jobStatus.Status), nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if jobStatus.Status != "success" && jobStatus.Status != "warning" {
+		return &jobStatus, &KBCJobError{
+			JobID:   jobStatus.ID,
+			Status:  jobStatus.Status,
+			Message: jobStatus.Result.Message,
+		}
+	}
+
+	return &jobStatus, nil
+}
+
+func isFinishedSyrupJobStatus(status string) bool {
+	switch status {
+	case "success", "error", "warning", "cancelled", "terminated":
+		return true
+	}
+
+	return false
 }
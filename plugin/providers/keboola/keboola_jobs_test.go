@@ -0,0 +1,90 @@
+package keboola
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newStorageJobServer(statuses ...string) *httptest.Server {
+	polls := 0
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		status := statuses[polls]
+		if polls < len(statuses)-1 {
+			polls++
+		}
+
+		if status == "error" {
+			fmt.Fprint(w, `{"id":42,"status":"error","results":null,"error":{"code":"storage.tables.validation","message":"Invalid columns","exceptionId":"exception-42"}}`)
+			return
+		}
+
+		fmt.Fprintf(w, `{"id":42,"status":"%s","results":{"id":"in.c-test.test","name":"test"},"error":null}`, status)
+	}))
+}
+
+func TestWaitForStorageJob_Success(t *testing.T) {
+	server := newStorageJobServer("waiting", "processing", "success")
+	defer server.Close()
+
+	client := &KBCClient{StorageURL: server.URL + "/v2/"}
+	jobStatus, err := client.waitForStorageJob(42, time.Minute)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "in.c-test.test", string(jobStatus.Results.ID))
+}
+
+func TestWaitForStorageJob_SurfacesJobError(t *testing.T) {
+	server := newStorageJobServer("processing", "error")
+	defer server.Close()
+
+	client := &KBCClient{StorageURL: server.URL + "/v2/"}
+	_, err := client.waitForStorageJob(42, time.Minute)
+
+	jobError, ok := err.(*KBCJobError)
+
+	assert.True(t, ok, "Error should be a KBCJobError")
+	assert.Equal(t, "Invalid columns", jobError.Message)
+	assert.Equal(t, "exception-42", jobError.ExceptionID)
+	assert.Equal(t, "storage.tables.validation", jobError.Code)
+}
+
+func TestWaitForStorageJob_Timeout(t *testing.T) {
+	server := newStorageJobServer("processing")
+	defer server.Close()
+
+	client := &KBCClient{StorageURL: server.URL + "/v2/"}
+	_, err := client.waitForStorageJob(42, 100*time.Millisecond)
+
+	assert.EqualError(t, err, "timed out after 100ms waiting for storage job 42 to finish")
+}
+
+func TestWaitForStorageJob_Cancelled(t *testing.T) {
+	server := newStorageJobServer("processing")
+	defer server.Close()
+
+	stopContext, stop := context.WithCancel(context.Background())
+	stop()
+
+	client := &KBCClient{StorageURL: server.URL + "/v2/", StopContext: stopContext}
+	err := client.pollJob("storage job 42", time.Minute, func() (bool, error) { return false, nil })
+
+	assert.EqualError(t, err, "cancelled while waiting for storage job 42 to finish")
+}
+
+func TestStorageJobResults_IgnoresNonObjectResults(t *testing.T) {
+	var results StorageJobResults
+
+	assert.NoError(t, results.UnmarshalJSON([]byte("[]")))
+	assert.NoError(t, results.UnmarshalJSON([]byte("null")))
+	assert.NoError(t, results.UnmarshalJSON([]byte(`{"id":"in.c-test.test"}`)))
+	assert.Equal(t, "in.c-test.test", string(results.ID))
+	assert.NoError(t, results.UnmarshalJSON([]byte(`{"id":1234}`)))
+	assert.Equal(t, "1234", string(results.ID))
+}
@@ -0,0 +1,183 @@
+package keboola
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/url"
+	"sort"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/paybyphone/terraform-provider-keboola/plugin/providers/keboola/buffer"
+)
+
+//region Keboola API Contracts
+
+//StorageMetadata is a single metadata entry (such as KBC.description) of a bucket,
+//table or column within the Keboola Storage API.
+type StorageMetadata struct {
+	ID       KBCNumberString `json:"id"`
+	Key      string          `json:"key"`
+	Value    string          `json:"value"`
+	Provider string          `json:"provider"`
+}
+
+//StorageColumnMetadata holds the metadata of the columns of a table, by column name.
+type StorageColumnMetadata map[string][]StorageMetadata
+
+//UnmarshalJSON handles unmarshaling StorageColumnMetadata from JSON. The Storage API
+//returns an empty array, rather than an empty object, for tables without column metadata.
+func (scm *StorageColumnMetadata) UnmarshalJSON(data []byte) error {
+	var columns map[string][]StorageMetadata
+
+	if err := json.Unmarshal(data, &columns); err != nil {
+		var empty []interface{}
+
+		if json.Unmarshal(data, &empty) != nil || len(empty) > 0 {
+			return err
+		}
+	}
+
+	*scm = columns
+	return nil
+}
+
+//endregion
+
+//defaultMetadataProvider is the provider name under which metadata is managed, unless
+//the provider's metadata_provider setting says otherwise.
+const defaultMetadataProvider = "terraform"
+
+//metadataSchema is the schema of the metadata attribute of buckets and tables (and of their columns),
+//which holds the metadata entries set by the provider's metadata_provider, by key.
+var metadataSchema = schema.Schema{
+	Type:        schema.TypeMap,
+	Optional:    true,
+	Elem:        &schema.Schema{Type: schema.TypeString},
+	Description: "The metadata entries (e.g. KBC.description) set under the provider's metadata_provider, by key.",
+}
+
+//storageMetadataValues returns the values of the metadata entries set by the given provider, by key.
+func storageMetadataValues(metadata []StorageMetadata, provider string) map[string]string {
+	values := make(map[string]string)
+
+	for _, entry := range metadata {
+		if entry.Provider == provider {
+			values[entry.Key] = entry.Value
+		}
+	}
+
+	return values
+}
+
+//diffStorageMetadata compares the metadata entries set by the given provider with the desired values, returning
+//the values which have to be set (because they are new or have changed) and the entries which have to be deleted.
+func diffStorageMetadata(metadata []StorageMetadata, provider string, desired map[string]interface{}) (map[string]string, []StorageMetadata) {
+	current := make(map[string]StorageMetadata)
+	changed := make(map[string]string)
+	var removed []StorageMetadata
+
+	for _, entry := range metadata {
+		if entry.Provider != provider {
+			continue
+		}
+
+		current[entry.Key] = entry
+
+		if _, ok := desired[entry.Key]; !ok {
+			removed = append(removed, entry)
+		}
+	}
+
+	for key, value := range desired {
+		if entry, ok := current[key]; !ok || entry.Value != value.(string) {
+			changed[key] = value.(string)
+		}
+	}
+
+	return changed, removed
+}
+
+//addStorageMetadataForm adds metadata values to a form as prefix[0][key], prefix[0][value], prefix[1][key]...
+//The values are added ordered by key, so that requests are the same from one run to the next.
+func addStorageMetadataForm(form url.Values, prefix string, values map[string]string) {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for index, key := range keys {
+		form.Add(fmt.Sprintf("%s[%d][key]", prefix, index), key)
+		form.Add(fmt.Sprintf("%s[%d][value]", prefix, index), values[key])
+	}
+}
+
+//postStorageMetadata sets metadata of a bucket or table (e.g. storage/buckets/{id}), from a form built using addStorageMetadataForm.
+func postStorageMetadata(client *KBCClient, endpoint string, metadataForm url.Values) error {
+	metadataForm.Set("provider", client.MetadataProvider)
+
+	postResponse, err := client.PostToStorage(fmt.Sprintf("%s/metadata", endpoint), buffer.FromForm(metadataForm))
+
+	if hasErrors(err, postResponse) {
+		return extractError(err, postResponse)
+	}
+
+	return nil
+}
+
+//deleteStorageMetadata deletes metadata entries of a bucket, table or column (e.g. storage/columns/{id}).
+//Entries which no longer exist are ignored.
+func deleteStorageMetadata(client *KBCClient, endpoint string, metadata []StorageMetadata) error {
+	for _, entry := range metadata {
+		log.Printf("[INFO] Deleting metadata %s of %s", entry.Key, endpoint)
+
+		deleteResponse, err := client.DeleteFromStorage(fmt.Sprintf("%s/metadata/%s", endpoint, entry.ID))
+
+		if hasErrors(err, deleteResponse) {
+			if err = extractError(err, deleteResponse); !isNotFoundError(err) {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+//storageMetadataDataSourceSchema is the schema of the metadata attribute of the storage data sources,
+//which holds all of the metadata entries of a bucket or table, whichever provider they were set by.
+var storageMetadataDataSourceSchema = schema.Schema{
+	Type:     schema.TypeList,
+	Computed: true,
+	Elem: &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"key": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"value": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"provider": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	},
+}
+
+//flattenStorageMetadata converts metadata entries to the metadata attribute of the storage data sources.
+func flattenStorageMetadata(metadata []StorageMetadata) []map[string]interface{} {
+	entries := make([]map[string]interface{}, 0, len(metadata))
+
+	for _, entry := range metadata {
+		entries = append(entries, map[string]interface{}{
+			"key":      entry.Key,
+			"value":    entry.Value,
+			"provider": entry.Provider,
+		})
+	}
+
+	return entries
+}
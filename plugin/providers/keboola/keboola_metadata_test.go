@@ -0,0 +1,45 @@
+package keboola
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStorageColumnMetadata_UnmarshalJSON(t *testing.T) {
+	var table StorageTable
+
+	err := json.Unmarshal([]byte(`{"columnMetadata":{"name":[{"id":"42","key":"KBC.description","value":"Full name","provider":"terraform"}]}}`), &table)
+
+	assert.NoError(t, err)
+	assert.Equal(t, KBCNumberString("42"), table.ColumnMetadata["name"][0].ID)
+	assert.Equal(t, "Full name", table.ColumnMetadata["name"][0].Value)
+
+	err = json.Unmarshal([]byte(`{"columnMetadata":[]}`), &table)
+
+	assert.NoError(t, err)
+	assert.Empty(t, table.ColumnMetadata)
+
+	err = json.Unmarshal([]byte(`{"columnMetadata":["name"]}`), &table)
+
+	assert.Error(t, err)
+}
+
+func TestDiffStorageMetadata(t *testing.T) {
+	metadata := []StorageMetadata{
+		{ID: "1", Key: "KBC.description", Value: "Old description", Provider: "terraform"},
+		{ID: "2", Key: "owner", Value: "data-team", Provider: "terraform"},
+		{ID: "3", Key: "unchanged", Value: "value", Provider: "terraform"},
+		{ID: "4", Key: "owner", Value: "someone else", Provider: "user"},
+	}
+
+	changed, removed := diffStorageMetadata(metadata, "terraform", map[string]interface{}{
+		"KBC.description": "New description",
+		"unchanged":       "value",
+		"added":           "value",
+	})
+
+	assert.Equal(t, map[string]string{"KBC.description": "New description", "added": "value"}, changed)
+	assert.Equal(t, []StorageMetadata{metadata[1]}, removed)
+}
@@ -0,0 +1,23 @@
+package keboola
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKBCNumberString_UnmarshalsNumbersAndStrings(t *testing.T) {
+	var values struct {
+		Number KBCNumberString `json:"number"`
+		String KBCNumberString `json:"string"`
+		Phase  KBCNumberString `json:"phase"`
+	}
+
+	err := json.Unmarshal([]byte(`{"number": 1234, "string": "in.c-bucket", "phase": "1"}`), &values)
+
+	assert.NoError(t, err)
+	assert.Equal(t, KBCNumberString("1234"), values.Number)
+	assert.Equal(t, KBCNumberString("in.c-bucket"), values.String, "A string value should not keep its JSON quotes")
+	assert.Equal(t, KBCNumberString("1"), values.Phase, "A quoted number should not keep its JSON quotes")
+}
@@ -0,0 +1,3010 @@
+package keboola
+
+import (
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+const mockAPIKey = "mock-storage-api-token"
+
+//mockKeboolaAPI is an in-process fake of the Keboola Storage, Syrup and File Import APIs, used by the
+//TestUnit* tests to exercise every resource without a Keboola project. It keeps its state in memory,
+//serves Storage under /v2/storage/ and advertises itself as the Syrup and File Import services.
+type mockKeboolaAPI struct {
+	*httptest.Server
+
+	mutex  sync.Mutex
+	routes []mockRoute
+	lastID int
+
+	components         map[string]map[string]*mockConfiguration
+	branches           map[string]*mockBranch
+	buckets            map[string]*mockBucket
+	tables             map[string]*mockTable
+	tokens             map[string]*mockToken
+	files              map[int]*mockFile
+	workspaces         map[string]*mockWorkspace
+	snapshots          map[string]*mockSnapshot
+	storageJobs        map[int]*mockStorageJob
+	syrupJobs          map[int]*mockSyrupJob
+	orchestrations     map[string]map[string]interface{}
+	orchestrationTasks map[string][]map[string]interface{}
+	goodDataTables     map[string]map[string]interface{}
+
+	//fileStorageProvider is the provider (aws, azure or gcp) of the file storage prepared files are uploaded to.
+	fileStorageProvider string
+
+	//synchronousStorageJobs makes creating typed tables and linking buckets respond with the created
+	//resource straight away, rather than with a job, as the Storage API may do.
+	synchronousStorageJobs bool
+}
+
+type mockRoute struct {
+	method  string
+	pattern *regexp.Regexp
+	handle  func(w http.ResponseWriter, r *http.Request, params []string)
+}
+
+type mockConfiguration struct {
+	ID                string               `json:"id"`
+	Name              string               `json:"name"`
+	Description       string               `json:"description"`
+	ChangeDescription string               `json:"changeDescription"`
+	IsDisabled        bool                 `json:"isDisabled"`
+	Version           int                  `json:"version"`
+	Configuration     interface{}          `json:"configuration"`
+	Rows              []*mockConfiguration `json:"rows,omitempty"`
+	RowsSortOrder     []string             `json:"rowsSortOrder,omitempty"`
+}
+
+type mockBranch struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	IsDefault   bool   `json:"isDefault"`
+
+	components map[string]map[string]*mockConfiguration
+}
+
+type mockBucket struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Stage       string `json:"stage"`
+	Description string `json:"description"`
+	Backend     string `json:"backend"`
+
+	RowsCount     int `json:"rowsCount"`
+	DataSizeBytes int `json:"dataSizeBytes"`
+
+	Metadata []*mockMetadata `json:"metadata"`
+
+	Sharing           string `json:"sharing"`
+	SharingParameters struct {
+		Projects []map[string]interface{} `json:"projects"`
+		Users    []map[string]interface{} `json:"users"`
+	} `json:"sharingParameters"`
+	LinkedBy     []*mockBucketLink `json:"linkedBy"`
+	SourceBucket *mockBucketLink   `json:"sourceBucket,omitempty"`
+}
+
+//mockBucketLink refers to a bucket of a project, either a bucket linking a shared bucket, or the shared bucket itself.
+type mockBucketLink struct {
+	ID      string `json:"id"`
+	Project struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	} `json:"project"`
+}
+
+type mockTable struct {
+	ID             string   `json:"id"`
+	Name           string   `json:"name"`
+	Transactional  bool     `json:"transactional"`
+	Columns        []string `json:"columns"`
+	PrimaryKey     []string `json:"primaryKey"`
+	IndexedColumns []string `json:"indexedColumns"`
+	RowsCount      int      `json:"rowsCount"`
+	DataSizeBytes  int      `json:"dataSizeBytes"`
+	LastImportDate string   `json:"lastImportDate,omitempty"`
+	Bucket         struct {
+		ID string `json:"id"`
+	} `json:"bucket"`
+
+	Metadata       []*mockMetadata    `json:"metadata"`
+	ColumnMetadata mockColumnMetadata `json:"columnMetadata"`
+
+	IsAlias     bool `json:"isAlias"`
+	SourceTable *struct {
+		ID string `json:"id"`
+	} `json:"sourceTable,omitempty"`
+	AliasFilter          *mockAliasFilter `json:"aliasFilter,omitempty"`
+	AliasColumnsAutoSync bool             `json:"aliasColumnsAutoSync"`
+
+	IsTyped    bool                 `json:"isTyped"`
+	Definition *mockTableDefinition `json:"definition,omitempty"`
+
+	rows [][]string
+}
+
+type mockTableDefinition struct {
+	PrimaryKeysNames []string                `json:"primaryKeysNames"`
+	Columns          []*mockColumnDefinition `json:"columns"`
+}
+
+type mockColumnDefinition struct {
+	Name       string         `json:"name"`
+	Definition mockColumnType `json:"definition"`
+}
+
+type mockColumnType struct {
+	Type     string  `json:"type"`
+	Length   *string `json:"length"`
+	Nullable bool    `json:"nullable"`
+	Default  *string `json:"default"`
+}
+
+//normalized returns the type of a column as Snowflake reports it: in upper case, with integer types
+//reported as NUMBER(38,0), aliases reported as the type they stand for, and NUMBER and VARCHAR given
+//their default length.
+func (ct mockColumnType) normalized() mockColumnType {
+	ct.Type = strings.ToUpper(ct.Type)
+
+	switch ct.Type {
+	case "INT", "INTEGER", "BIGINT", "SMALLINT":
+		ct.Type = "NUMBER"
+		ct.Length = mockOptionalString("38,0")
+	case "DECIMAL", "NUMERIC":
+		ct.Type = "NUMBER"
+	case "STRING", "TEXT":
+		ct.Type = "VARCHAR"
+	case "DOUBLE", "REAL":
+		ct.Type = "FLOAT"
+	}
+
+	if ct.Length == nil {
+		switch ct.Type {
+		case "NUMBER":
+			ct.Length = mockOptionalString("38,0")
+		case "VARCHAR":
+			ct.Length = mockOptionalString("16777216")
+		}
+	}
+
+	return ct
+}
+
+//column returns the definition of a column of a typed table, or nil if there is no such column.
+func (td *mockTableDefinition) column(name string) *mockColumnDefinition {
+	for _, column := range td.Columns {
+		if column.Name == name {
+			return column
+		}
+	}
+
+	return nil
+}
+
+type mockAliasFilter struct {
+	Column   string   `json:"column"`
+	Operator string   `json:"operator"`
+	Values   []string `json:"values"`
+}
+
+type mockMetadata struct {
+	ID       string `json:"id"`
+	Key      string `json:"key"`
+	Value    string `json:"value"`
+	Provider string `json:"provider"`
+}
+
+//mockColumnMetadata is the metadata of the columns of a table, which (like the real
+//Storage API) is returned as an empty array when no column has any metadata.
+type mockColumnMetadata map[string][]*mockMetadata
+
+func (cm mockColumnMetadata) MarshalJSON() ([]byte, error) {
+	if len(cm) == 0 {
+		return []byte("[]"), nil
+	}
+
+	return json.Marshal(map[string][]*mockMetadata(cm))
+}
+
+type mockToken struct {
+	ID                    string            `json:"id"`
+	Token                 string            `json:"token"`
+	Description           string            `json:"description"`
+	Created               string            `json:"created"`
+	Expires               *string           `json:"expires"`
+	IsMasterToken         bool              `json:"isMasterToken"`
+	CanManageBuckets      bool              `json:"canManageBuckets"`
+	CanManageTokens       bool              `json:"canManageTokens"`
+	CanReadAllFileUploads bool              `json:"canReadAllFileUploads"`
+	ComponentAccess       []string          `json:"componentAccess"`
+	BucketPermissions     map[string]string `json:"bucketPermissions"`
+}
+
+type mockFile struct {
+	ID          int      `json:"id"`
+	Name        string   `json:"name"`
+	SizeBytes   int      `json:"sizeBytes"`
+	IsPublic    bool     `json:"isPublic"`
+	IsPermanent bool     `json:"isPermanent"`
+	Tags        []string `json:"tags"`
+	Created     string   `json:"created"`
+	Provider    string   `json:"provider"`
+	Content     string   `json:"-"`
+}
+
+type mockSnapshot struct {
+	ID          int    `json:"id"`
+	Description string `json:"description"`
+	CreatedTime string `json:"createdTime"`
+	Table       struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"table"`
+
+	table mockTable
+}
+
+type mockWorkspace struct {
+	ID         int `json:"id"`
+	Connection struct {
+		Backend   string `json:"backend"`
+		Host      string `json:"host"`
+		Database  string `json:"database"`
+		Schema    string `json:"schema"`
+		Warehouse string `json:"warehouse,omitempty"`
+		User      string `json:"user"`
+	} `json:"connection"`
+
+	password string
+	tables   map[string]string
+}
+
+type mockStorageJob struct {
+	ID        int         `json:"id"`
+	URL       string      `json:"url"`
+	Status    string      `json:"status"`
+	Operation string      `json:"operationName"`
+	Results   interface{} `json:"results"`
+	Error     *struct {
+		Code        string `json:"code"`
+		Message     string `json:"message"`
+		ExceptionID string `json:"exceptionId"`
+	} `json:"error,omitempty"`
+}
+
+type mockSyrupJob struct {
+	ID     int    `json:"id"`
+	URL    string `json:"url"`
+	Status string `json:"status"`
+	Result struct {
+		Message string `json:"message"`
+	} `json:"result"`
+}
+
+//newMockKeboolaAPI starts a new, empty, mock Keboola API. Call Close once the test has finished.
+func newMockKeboolaAPI() *mockKeboolaAPI {
+	m := &mockKeboolaAPI{
+		lastID:             1000,
+		components:         make(map[string]map[string]*mockConfiguration),
+		branches:           make(map[string]*mockBranch),
+		buckets:            make(map[string]*mockBucket),
+		tables:             make(map[string]*mockTable),
+		tokens:             make(map[string]*mockToken),
+		files:              make(map[int]*mockFile),
+		workspaces:         make(map[string]*mockWorkspace),
+		snapshots:          make(map[string]*mockSnapshot),
+		storageJobs:        make(map[int]*mockStorageJob),
+		syrupJobs:          make(map[int]*mockSyrupJob),
+		orchestrations:     make(map[string]map[string]interface{}),
+		orchestrationTasks: make(map[string][]map[string]interface{}),
+		goodDataTables:     make(map[string]map[string]interface{}),
+
+		fileStorageProvider: "aws",
+	}
+
+	m.route("GET", `/v2/storage`, m.getIndex)
+	m.route("GET", `/v2/storage/tokens/verify`, m.verifyToken)
+	m.route("POST", `/v2/storage/tokens`, m.createToken)
+	m.route("GET", `/v2/storage/tokens/([^/]+)`, m.getToken)
+	m.route("PUT", `/v2/storage/tokens/([^/]+)`, m.updateToken)
+	m.route("DELETE", `/v2/storage/tokens/([^/]+)`, m.deleteToken)
+	m.route("POST", `/v2/storage/components/([^/]+)/configs`, m.createConfiguration)
+	m.route("GET", `/v2/storage/components/([^/]+)/configs/([^/]+)`, m.getConfiguration)
+	m.route("PUT", `/v2/storage/components/([^/]+)/configs/([^/]+)`, m.updateConfiguration)
+	m.route("DELETE", `/v2/storage/components/([^/]+)/configs/([^/]+)`, m.deleteConfiguration)
+	m.route("GET", `/v2/storage/components/([^/]+)/configs/([^/]+)/rows`, m.listRows)
+	m.route("POST", `/v2/storage/components/([^/]+)/configs/([^/]+)/rows`, m.createRow)
+	m.route("GET", `/v2/storage/components/([^/]+)/configs/([^/]+)/rows/([^/]+)`, m.getRow)
+	m.route("PUT", `/v2/storage/components/([^/]+)/configs/([^/]+)/rows/([^/]+)`, m.updateRow)
+	m.route("DELETE", `/v2/storage/components/([^/]+)/configs/([^/]+)/rows/([^/]+)`, m.deleteRow)
+	m.route("POST", `/v2/storage/dev-branches`, m.createBranch)
+	m.route("GET", `/v2/storage/dev-branches/(\d+)`, m.getBranch)
+	m.route("PUT", `/v2/storage/dev-branches/(\d+)`, m.updateBranch)
+	m.route("DELETE", `/v2/storage/dev-branches/(\d+)`, m.deleteBranch)
+	m.route("GET", `/v2/storage/buckets`, m.listBuckets)
+	m.route("POST", `/v2/storage/buckets`, m.createBucket)
+	m.route("POST", `/v2/storage/buckets/link`, m.linkBucket)
+	m.route("GET", `/v2/storage/buckets/([^/]+)`, m.getBucket)
+	m.route("DELETE", `/v2/storage/buckets/([^/]+)`, m.deleteBucket)
+	m.route("POST", `/v2/storage/buckets/([^/]+)/share-organization`, m.shareBucket("organization"))
+	m.route("PUT", `/v2/storage/buckets/([^/]+)/share-organization`, m.shareBucket("organization"))
+	m.route("POST", `/v2/storage/buckets/([^/]+)/share-organization-project`, m.shareBucket("organization-project"))
+	m.route("PUT", `/v2/storage/buckets/([^/]+)/share-organization-project`, m.shareBucket("organization-project"))
+	m.route("POST", `/v2/storage/buckets/([^/]+)/share-to-projects`, m.shareBucket("specific-projects"))
+	m.route("PUT", `/v2/storage/buckets/([^/]+)/share-to-projects`, m.shareBucket("specific-projects"))
+	m.route("POST", `/v2/storage/buckets/([^/]+)/share-to-users`, m.shareBucket("specific-users"))
+	m.route("PUT", `/v2/storage/buckets/([^/]+)/share-to-users`, m.shareBucket("specific-users"))
+	m.route("DELETE", `/v2/storage/buckets/([^/]+)/share`, m.unshareBucket)
+	m.route("POST", `/v2/storage/buckets/([^/]+)/metadata`, m.setBucketMetadata)
+	m.route("DELETE", `/v2/storage/buckets/([^/]+)/metadata/([^/]+)`, m.deleteBucketMetadata)
+	m.route("POST", `/v2/storage/buckets/([^/]+)/tables-async`, m.createTableAsync)
+	m.route("POST", `/v2/storage/buckets/([^/]+)/tables-definition`, m.createTableDefinition)
+	m.route("POST", `/v2/storage/buckets/([^/]+)/table-aliases`, m.createTableAlias)
+	m.route("GET", `/v2/storage/buckets/([^/]+)/tables`, m.listBucketTables)
+	m.route("GET", `/v2/storage/tables`, m.listTables)
+	m.route("GET", `/v2/storage/tables/([^/]+)`, m.getTable)
+	m.route("DELETE", `/v2/storage/tables/([^/]+)`, m.deleteTable)
+	m.route("POST", `/v2/storage/tables/([^/]+)/columns`, m.addTableColumn)
+	m.route("DELETE", `/v2/storage/tables/([^/]+)/columns/([^/]+)`, m.deleteTableColumn)
+	m.route("PUT", `/v2/storage/tables/([^/]+)/columns/([^/]+)/definition`, m.updateTableColumnDefinition)
+	m.route("POST", `/v2/storage/tables/([^/]+)/import-async`, m.importTableAsync)
+	m.route("POST", `/v2/storage/tables/([^/]+)/primary-key`, m.createTablePrimaryKey)
+	m.route("POST", `/v2/storage/tables/([^/]+)/snapshots`, m.createTableSnapshot)
+	m.route("DELETE", `/v2/storage/tables/([^/]+)/primary-key`, m.deleteTablePrimaryKey)
+	m.route("POST", `/v2/storage/tables/([^/]+)/alias-filter`, m.setAliasFilter)
+	m.route("DELETE", `/v2/storage/tables/([^/]+)/alias-filter`, m.removeAliasFilter)
+	m.route("POST", `/v2/storage/tables/([^/]+)/alias-disable-auto-sync`, m.disableAliasAutoSync)
+	m.route("POST", `/v2/storage/tables/([^/]+)/metadata`, m.setTableMetadata)
+	m.route("DELETE", `/v2/storage/tables/([^/]+)/metadata/([^/]+)`, m.deleteTableMetadata)
+	m.route("DELETE", `/v2/storage/columns/([^/]+)\.([^/.]+)/metadata/([^/]+)`, m.deleteColumnMetadata)
+	m.route("GET", `/v2/storage/snapshots/(\d+)`, m.getSnapshot)
+	m.route("DELETE", `/v2/storage/snapshots/(\d+)`, m.deleteSnapshot)
+	m.route("POST", `/v2/storage/files/prepare`, m.prepareFile)
+	m.route("GET", `/v2/storage/files/(\d+)`, m.getFile)
+	m.route("DELETE", `/v2/storage/files/(\d+)`, m.deleteFile)
+	m.route("POST", `/v2/storage/files/(\d+)/tags`, m.addFileTag)
+	m.route("DELETE", `/v2/storage/files/(\d+)/tags/([^/]+)`, m.deleteFileTag)
+	m.route("POST", `/v2/storage/workspaces`, m.createWorkspace)
+	m.route("GET", `/v2/storage/workspaces/(\d+)`, m.getWorkspace)
+	m.route("DELETE", `/v2/storage/workspaces/(\d+)`, m.deleteWorkspace)
+	m.route("POST", `/v2/storage/workspaces/(\d+)/password`, m.resetWorkspacePassword)
+	m.route("POST", `/v2/storage/workspaces/(\d+)/load`, m.loadWorkspace)
+	m.route("GET", `/v2/storage/jobs/(\d+)`, m.getStorageJob)
+
+	m.route("POST", `/upload-file`, m.uploadFile)
+
+	m.route("POST", `/encrypt`, m.encrypt)
+
+	m.route("GET", `/queue/job/(\d+)`, m.getSyrupJob)
+	m.route("POST", `/provisioning/snowflake`, m.provisionSnowflake)
+	m.route("POST", `/gooddata-writer/v2`, m.createGoodDataWriter)
+	m.route("DELETE", `/gooddata-writer/configs/([^/]+)`, m.deleteGoodDataWriter)
+	m.route("POST", `/gooddata-writer/v2/([^/]+)/tables/([^/]+)`, m.createGoodDataTable)
+	m.route("GET", `/gooddata-writer/v2/([^/]+)/tables/([^/]+)`, m.getGoodDataTable)
+	m.route("PATCH", `/gooddata-writer/v2/([^/]+)/tables/([^/]+)`, m.updateGoodDataTable)
+	m.route("DELETE", `/gooddata-writer/v2/([^/]+)/tables/([^/]+)`, m.deleteGoodDataTable)
+	m.route("POST", `/orchestrator/orchestrations`, m.createOrchestration)
+	m.route("GET", `/orchestrator/orchestrations/(\d+)`, m.getOrchestration)
+	m.route("PUT", `/orchestrator/orchestrations/(\d+)`, m.updateOrchestration)
+	m.route("DELETE", `/orchestrator/orchestrations/(\d+)`, m.deleteOrchestration)
+	m.route("GET", `/orchestrator/orchestrations/(\d+)/tasks`, m.getOrchestrationTasks)
+	m.route("PUT", `/orchestrator/orchestrations/(\d+)/tasks`, m.updateOrchestrationTasks)
+
+	m.Server = httptest.NewServer(m)
+
+	return m
+}
+
+func (m *mockKeboolaAPI) route(method string, pattern string, handle func(w http.ResponseWriter, r *http.Request, params []string)) {
+	m.routes = append(m.routes, mockRoute{method, regexp.MustCompile("^" + pattern + "$"), handle})
+}
+
+var mockBranchPath = regexp.MustCompile(`^/v2/storage/branch/([^/]+)(/components/.+)$`)
+
+//mockFileStoragePath is where prepared Storage files are uploaded to, standing in for the stack's S3 bucket,
+//Azure Blob Storage container (under /abs) or Google Cloud Storage bucket (under /gcs).
+const mockFileStoragePath = "/file-storage"
+
+func (m *mockKeboolaAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	//The file storage is not part of Keboola, and must never be sent the Storage API token.
+	if strings.HasPrefix(r.URL.Path, mockFileStoragePath) {
+		m.uploadToFileStorage(w, r)
+		return
+	}
+
+	if r.Header.Get("X-StorageApi-Token") != mockAPIKey {
+		writeMockError(w, http.StatusUnauthorized, "storage.tokenInvalid", "Invalid access token")
+		return
+	}
+
+	path := strings.TrimRight(r.URL.Path, "/")
+	pathMatched := false
+
+	//Requests for the configurations of a development branch are served from that branch's own copy.
+	if branchPath := mockBranchPath.FindStringSubmatch(path); branchPath != nil {
+		branch, ok := m.branches[branchPath[1]]
+
+		if !ok {
+			writeMockError(w, http.StatusNotFound, "storage.devBranches.notFound", fmt.Sprintf("Branch %s not found", branchPath[1]))
+			return
+		}
+
+		defaultComponents := m.components
+		m.components = branch.components
+		defer func() { m.components = defaultComponents }()
+
+		path = "/v2/storage" + branchPath[2]
+	}
+
+	for _, route := range m.routes {
+		params := route.pattern.FindStringSubmatch(path)
+
+		if params == nil {
+			continue
+		}
+
+		pathMatched = true
+
+		if route.method == r.Method {
+			route.handle(w, r, params)
+			return
+		}
+	}
+
+	if pathMatched {
+		writeMockError(w, http.StatusMethodNotAllowed, "methodNotAllowed", fmt.Sprintf("Method %s is not allowed on %s", r.Method, path))
+		return
+	}
+
+	writeMockError(w, http.StatusNotFound, "notFound", fmt.Sprintf("Unknown endpoint %s", path))
+}
+
+func (m *mockKeboolaAPI) nextID() int {
+	m.lastID++
+	return m.lastID
+}
+
+func writeMockJSON(w http.ResponseWriter, status int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(value)
+}
+
+func writeMockError(w http.ResponseWriter, status int, code string, message string) {
+	writeMockJSON(w, status, map[string]interface{}{
+		"error":       message,
+		"code":        code,
+		"status":      "error",
+		"exceptionId": fmt.Sprintf("mock-%d", status),
+	})
+}
+
+func parseMockBool(value string) bool {
+	return value == "1" || value == "true"
+}
+
+func parseMockList(value string) []string {
+	list := []string{}
+
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+
+	return list
+}
+
+//region Storage API
+
+func (m *mockKeboolaAPI) getIndex(w http.ResponseWriter, r *http.Request, params []string) {
+	writeMockJSON(w, http.StatusOK, map[string]interface{}{
+		"services": []map[string]string{
+			{"id": "syrup", "url": m.URL},
+			{"id": "import", "url": m.URL},
+			{"id": "encryption", "url": m.URL},
+		},
+	})
+}
+
+func (m *mockKeboolaAPI) verifyToken(w http.ResponseWriter, r *http.Request, params []string) {
+	writeMockJSON(w, http.StatusOK, map[string]interface{}{
+		"id":               "1",
+		"description":      "Mock master token",
+		"isMasterToken":    true,
+		"canManageBuckets": true,
+		"canManageTokens":  true,
+		"owner": map[string]interface{}{
+			"id":             1234,
+			"name":           "Mock Project",
+			"defaultBackend": "snowflake",
+		},
+	})
+}
+
+var mockIndexedFormKey = regexp.MustCompile(`^(\w+)\[([^\]]*)\]$`)
+
+//applyTokenForm updates a token from the form values (or query string) of a create or update request.
+func applyTokenForm(token *mockToken, form url.Values) {
+	if _, ok := form["description"]; ok {
+		token.Description = form.Get("description")
+	}
+	if _, ok := form["canManageBuckets"]; ok {
+		token.CanManageBuckets = parseMockBool(form.Get("canManageBuckets"))
+	}
+	if _, ok := form["canManageTokens"]; ok {
+		token.CanManageTokens = parseMockBool(form.Get("canManageTokens"))
+	}
+	if _, ok := form["canReadAllFileUploads"]; ok {
+		token.CanReadAllFileUploads = parseMockBool(form.Get("canReadAllFileUploads"))
+	}
+
+	componentAccess := map[string]string{}
+	bucketPermissions := map[string]string{}
+
+	for key, values := range form {
+		match := mockIndexedFormKey.FindStringSubmatch(key)
+
+		if match == nil {
+			continue
+		}
+
+		switch match[1] {
+		case "componentAccess":
+			componentAccess[match[2]] = values[0]
+		case "bucketPermissions":
+			bucketPermissions[match[2]] = values[0]
+		}
+	}
+
+	if len(componentAccess) > 0 {
+		keys := make([]string, 0, len(componentAccess))
+		for key := range componentAccess {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		token.ComponentAccess = []string{}
+		for _, key := range keys {
+			token.ComponentAccess = append(token.ComponentAccess, componentAccess[key])
+		}
+	}
+
+	if len(bucketPermissions) > 0 {
+		token.BucketPermissions = bucketPermissions
+	}
+}
+
+func (m *mockKeboolaAPI) createTokenWithDescription(description string) *mockToken {
+	token := &mockToken{
+		ID:                strconv.Itoa(m.nextID()),
+		Description:       description,
+		Created:           time.Now().UTC().Format("2006-01-02T15:04:05-0700"),
+		ComponentAccess:   []string{},
+		BucketPermissions: map[string]string{},
+	}
+
+	token.Token = fmt.Sprintf("mock-%s-token", token.ID)
+	m.tokens[token.ID] = token
+
+	return token
+}
+
+func (m *mockKeboolaAPI) createToken(w http.ResponseWriter, r *http.Request, params []string) {
+	r.ParseForm()
+
+	token := m.createTokenWithDescription("")
+	applyTokenForm(token, r.Form)
+
+	if expiresIn, _ := strconv.Atoi(r.Form.Get("expiresIn")); expiresIn > 0 {
+		created, _ := time.Parse("2006-01-02T15:04:05-0700", token.Created)
+		expires := created.Add(time.Duration(expiresIn) * time.Second).Format("2006-01-02T15:04:05-0700")
+		token.Expires = &expires
+	}
+
+	writeMockJSON(w, http.StatusCreated, token)
+}
+
+func (m *mockKeboolaAPI) getToken(w http.ResponseWriter, r *http.Request, params []string) {
+	token, ok := m.tokens[params[1]]
+
+	if !ok {
+		writeMockError(w, http.StatusNotFound, "storage.tokens.notFound", fmt.Sprintf("Token %s not found", params[1]))
+		return
+	}
+
+	writeMockJSON(w, http.StatusOK, token)
+}
+
+func (m *mockKeboolaAPI) updateToken(w http.ResponseWriter, r *http.Request, params []string) {
+	token, ok := m.tokens[params[1]]
+
+	if !ok {
+		writeMockError(w, http.StatusNotFound, "storage.tokens.notFound", fmt.Sprintf("Token %s not found", params[1]))
+		return
+	}
+
+	r.ParseForm()
+	applyTokenForm(token, r.Form)
+
+	writeMockJSON(w, http.StatusOK, token)
+}
+
+func (m *mockKeboolaAPI) deleteToken(w http.ResponseWriter, r *http.Request, params []string) {
+	if _, ok := m.tokens[params[1]]; !ok {
+		writeMockError(w, http.StatusNotFound, "storage.tokens.notFound", fmt.Sprintf("Token %s not found", params[1]))
+		return
+	}
+
+	delete(m.tokens, params[1])
+	w.WriteHeader(http.StatusNoContent)
+}
+
+//parseMockConfiguration parses the JSON configuration of a component configuration or row,
+//which is stored as an object, as it is by the Storage API.
+func parseMockConfiguration(value string) (interface{}, error) {
+	if value == "" {
+		return map[string]interface{}{}, nil
+	}
+
+	var configuration interface{}
+	err := json.Unmarshal([]byte(value), &configuration)
+
+	return encryptMockSecrets(configuration), err
+}
+
+//encryptMockSecrets "encrypts" the non-empty plain text values of the #-prefixed keys of a configuration,
+//as the Storage API does when a configuration is saved.
+func encryptMockSecrets(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for key, nested := range value {
+			if secret, ok := nested.(string); ok && secret != "" && strings.HasPrefix(key, "#") && !strings.HasPrefix(secret, "KBC::") {
+				value[key] = "KBC::ProjectSecure::" + base64.StdEncoding.EncodeToString([]byte(secret))
+			} else {
+				value[key] = encryptMockSecrets(nested)
+			}
+		}
+	case []interface{}:
+		for index, nested := range value {
+			value[index] = encryptMockSecrets(nested)
+		}
+	}
+
+	return value
+}
+
+//applyConfigurationForm updates a configuration or row with the fields present in a create or update request.
+func applyConfigurationForm(configuration *mockConfiguration, form url.Values) error {
+	if _, ok := form["name"]; ok {
+		configuration.Name = form.Get("name")
+	}
+	if _, ok := form["description"]; ok {
+		configuration.Description = form.Get("description")
+	}
+	if _, ok := form["changeDescription"]; ok {
+		configuration.ChangeDescription = form.Get("changeDescription")
+	}
+	if _, ok := form["isDisabled"]; ok {
+		configuration.IsDisabled = parseMockBool(form.Get("isDisabled"))
+	}
+	if _, ok := form["configuration"]; ok {
+		parsed, err := parseMockConfiguration(form.Get("configuration"))
+
+		if err != nil {
+			return err
+		}
+
+		configuration.Configuration = parsed
+	}
+	if _, ok := form["rowsSortOrder"]; ok {
+		configuration.RowsSortOrder = nil
+	}
+	if rowIDs, ok := form["rowsSortOrder[]"]; ok {
+		for _, rowID := range rowIDs {
+			if findMockRowIndex(configuration, rowID) < 0 {
+				return fmt.Errorf("Row %s not found", rowID)
+			}
+		}
+
+		configuration.RowsSortOrder = rowIDs
+	}
+
+	configuration.Version++
+
+	return nil
+}
+
+func (m *mockKeboolaAPI) findConfiguration(w http.ResponseWriter, componentID string, configID string) *mockConfiguration {
+	configuration, ok := m.components[componentID][configID]
+
+	if !ok {
+		writeMockError(w, http.StatusNotFound, "notFound", fmt.Sprintf("Configuration %s not found", configID))
+		return nil
+	}
+
+	return configuration
+}
+
+func (m *mockKeboolaAPI) createConfiguration(w http.ResponseWriter, r *http.Request, params []string) {
+	r.ParseForm()
+
+	componentID := params[1]
+	configID := r.Form.Get("configurationId")
+
+	if configID == "" {
+		configID = strconv.Itoa(m.nextID())
+	}
+
+	if _, ok := m.components[componentID]; !ok {
+		m.components[componentID] = make(map[string]*mockConfiguration)
+	}
+
+	if _, ok := m.components[componentID][configID]; ok {
+		writeMockError(w, http.StatusBadRequest, "configurationAlreadyExists", fmt.Sprintf("Configuration %s already exists", configID))
+		return
+	}
+
+	configuration := &mockConfiguration{ID: configID, Configuration: map[string]interface{}{}}
+
+	if err := applyConfigurationForm(configuration, r.Form); err != nil {
+		writeMockError(w, http.StatusBadRequest, "invalidConfiguration", err.Error())
+		return
+	}
+
+	m.components[componentID][configID] = configuration
+
+	writeMockJSON(w, http.StatusCreated, configuration)
+}
+
+func (m *mockKeboolaAPI) getConfiguration(w http.ResponseWriter, r *http.Request, params []string) {
+	if configuration := m.findConfiguration(w, params[1], params[2]); configuration != nil {
+		writeMockJSON(w, http.StatusOK, configuration)
+	}
+}
+
+func (m *mockKeboolaAPI) updateConfiguration(w http.ResponseWriter, r *http.Request, params []string) {
+	configuration := m.findConfiguration(w, params[1], params[2])
+
+	if configuration == nil {
+		return
+	}
+
+	r.ParseForm()
+
+	if err := applyConfigurationForm(configuration, r.Form); err != nil {
+		writeMockError(w, http.StatusBadRequest, "invalidConfiguration", err.Error())
+		return
+	}
+
+	writeMockJSON(w, http.StatusOK, configuration)
+}
+
+func (m *mockKeboolaAPI) deleteConfiguration(w http.ResponseWriter, r *http.Request, params []string) {
+	if configuration := m.findConfiguration(w, params[1], params[2]); configuration != nil {
+		delete(m.components[params[1]], params[2])
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func findMockRowIndex(configuration *mockConfiguration, rowID string) int {
+	for index, row := range configuration.Rows {
+		if row.ID == rowID {
+			return index
+		}
+	}
+
+	return -1
+}
+
+func (m *mockKeboolaAPI) findRow(w http.ResponseWriter, configuration *mockConfiguration, rowID string) (int, *mockConfiguration) {
+	if index := findMockRowIndex(configuration, rowID); index >= 0 {
+		return index, configuration.Rows[index]
+	}
+
+	writeMockError(w, http.StatusNotFound, "notFound", fmt.Sprintf("Row %s not found", rowID))
+	return -1, nil
+}
+
+func (m *mockKeboolaAPI) listRows(w http.ResponseWriter, r *http.Request, params []string) {
+	if configuration := m.findConfiguration(w, params[1], params[2]); configuration != nil {
+		rows := configuration.Rows
+		if rows == nil {
+			rows = []*mockConfiguration{}
+		}
+
+		writeMockJSON(w, http.StatusOK, rows)
+	}
+}
+
+func (m *mockKeboolaAPI) createRow(w http.ResponseWriter, r *http.Request, params []string) {
+	configuration := m.findConfiguration(w, params[1], params[2])
+
+	if configuration == nil {
+		return
+	}
+
+	r.ParseForm()
+
+	rowID := r.Form.Get("rowId")
+	if rowID == "" {
+		rowID = strconv.Itoa(m.nextID())
+	}
+
+	row := &mockConfiguration{ID: rowID, Configuration: map[string]interface{}{}}
+
+	if err := applyConfigurationForm(row, r.Form); err != nil {
+		writeMockError(w, http.StatusBadRequest, "invalidConfiguration", err.Error())
+		return
+	}
+
+	//Legacy transformations identify themselves by an id within their own configuration.
+	if rowConfiguration, ok := row.Configuration.(map[string]interface{}); ok && params[1] == "transformation" {
+		rowConfiguration["id"] = rowID
+	}
+
+	configuration.Rows = append(configuration.Rows, row)
+	configuration.Version++
+
+	writeMockJSON(w, http.StatusCreated, row)
+}
+
+func (m *mockKeboolaAPI) getRow(w http.ResponseWriter, r *http.Request, params []string) {
+	if configuration := m.findConfiguration(w, params[1], params[2]); configuration != nil {
+		if _, row := m.findRow(w, configuration, params[3]); row != nil {
+			writeMockJSON(w, http.StatusOK, row)
+		}
+	}
+}
+
+func (m *mockKeboolaAPI) updateRow(w http.ResponseWriter, r *http.Request, params []string) {
+	configuration := m.findConfiguration(w, params[1], params[2])
+
+	if configuration == nil {
+		return
+	}
+
+	_, row := m.findRow(w, configuration, params[3])
+
+	if row == nil {
+		return
+	}
+
+	r.ParseForm()
+
+	if err := applyConfigurationForm(row, r.Form); err != nil {
+		writeMockError(w, http.StatusBadRequest, "invalidConfiguration", err.Error())
+		return
+	}
+
+	if rowConfiguration, ok := row.Configuration.(map[string]interface{}); ok && params[1] == "transformation" {
+		rowConfiguration["id"] = row.ID
+	}
+
+	configuration.Version++
+
+	writeMockJSON(w, http.StatusOK, row)
+}
+
+func (m *mockKeboolaAPI) deleteRow(w http.ResponseWriter, r *http.Request, params []string) {
+	configuration := m.findConfiguration(w, params[1], params[2])
+
+	if configuration == nil {
+		return
+	}
+
+	if index, row := m.findRow(w, configuration, params[3]); row != nil {
+		configuration.Rows = append(configuration.Rows[:index], configuration.Rows[index+1:]...)
+
+		for position, rowID := range configuration.RowsSortOrder {
+			if rowID == row.ID {
+				configuration.RowsSortOrder = append(configuration.RowsSortOrder[:position], configuration.RowsSortOrder[position+1:]...)
+				break
+			}
+		}
+
+		configuration.Version++
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+//addBranch creates a development branch, with a copy of every configuration in the default branch.
+func (m *mockKeboolaAPI) addBranch(name string, description string) *mockBranch {
+	branch := &mockBranch{
+		ID:          m.nextID(),
+		Name:        name,
+		Description: description,
+		components:  make(map[string]map[string]*mockConfiguration),
+	}
+
+	for componentID, configurations := range m.components {
+		branch.components[componentID] = make(map[string]*mockConfiguration)
+
+		for configID, configuration := range configurations {
+			var copied mockConfiguration
+
+			content, _ := json.Marshal(configuration)
+			json.Unmarshal(content, &copied)
+
+			branch.components[componentID][configID] = &copied
+		}
+	}
+
+	m.branches[strconv.Itoa(branch.ID)] = branch
+
+	return branch
+}
+
+func (m *mockKeboolaAPI) findBranch(w http.ResponseWriter, branchID string) *mockBranch {
+	branch, ok := m.branches[branchID]
+
+	if !ok {
+		writeMockError(w, http.StatusNotFound, "storage.devBranches.notFound", fmt.Sprintf("Branch %s not found", branchID))
+		return nil
+	}
+
+	return branch
+}
+
+func (m *mockKeboolaAPI) createBranch(w http.ResponseWriter, r *http.Request, params []string) {
+	r.ParseForm()
+
+	if r.Form.Get("name") == "" {
+		writeMockError(w, http.StatusBadRequest, "storage.devBranches.validation", "Branch name is required")
+		return
+	}
+
+	m.startStorageJob(w, "devBranchCreate", m.addBranch(r.Form.Get("name"), r.Form.Get("description")), nil)
+}
+
+func (m *mockKeboolaAPI) getBranch(w http.ResponseWriter, r *http.Request, params []string) {
+	if branch := m.findBranch(w, params[1]); branch != nil {
+		writeMockJSON(w, http.StatusOK, branch)
+	}
+}
+
+func (m *mockKeboolaAPI) updateBranch(w http.ResponseWriter, r *http.Request, params []string) {
+	branch := m.findBranch(w, params[1])
+
+	if branch == nil {
+		return
+	}
+
+	r.ParseForm()
+
+	if _, ok := r.Form["name"]; ok {
+		branch.Name = r.Form.Get("name")
+	}
+	if _, ok := r.Form["description"]; ok {
+		branch.Description = r.Form.Get("description")
+	}
+
+	writeMockJSON(w, http.StatusOK, branch)
+}
+
+func (m *mockKeboolaAPI) deleteBranch(w http.ResponseWriter, r *http.Request, params []string) {
+	if branch := m.findBranch(w, params[1]); branch != nil {
+		delete(m.branches, params[1])
+		m.startStorageJob(w, "devBranchDelete", nil, nil)
+	}
+}
+
+func (m *mockKeboolaAPI) findBucket(w http.ResponseWriter, bucketID string) *mockBucket {
+	bucket, ok := m.buckets[bucketID]
+
+	if !ok {
+		writeMockError(w, http.StatusNotFound, "storage.buckets.notFound", fmt.Sprintf("Bucket %s not found", bucketID))
+		return nil
+	}
+
+	return bucket
+}
+
+//updateBucketStats sums the rows and data sizes of the tables of a bucket, as reported by the Storage API.
+func (m *mockKeboolaAPI) updateBucketStats(bucket *mockBucket) *mockBucket {
+	bucket.RowsCount, bucket.DataSizeBytes = 0, 0
+
+	for _, table := range m.tables {
+		if table.Bucket.ID == bucket.ID && !table.IsAlias {
+			bucket.RowsCount += table.RowsCount
+			bucket.DataSizeBytes += table.DataSizeBytes
+		}
+	}
+
+	return bucket
+}
+
+func (m *mockKeboolaAPI) listBuckets(w http.ResponseWriter, r *http.Request, params []string) {
+	bucketIDs := make([]string, 0, len(m.buckets))
+	for bucketID := range m.buckets {
+		bucketIDs = append(bucketIDs, bucketID)
+	}
+	sort.Strings(bucketIDs)
+
+	buckets := make([]*mockBucket, 0, len(bucketIDs))
+	for _, bucketID := range bucketIDs {
+		buckets = append(buckets, m.updateBucketStats(m.buckets[bucketID]))
+	}
+
+	writeMockJSON(w, http.StatusOK, buckets)
+}
+
+func (m *mockKeboolaAPI) createBucket(w http.ResponseWriter, r *http.Request, params []string) {
+	r.ParseForm()
+
+	bucket := &mockBucket{
+		Name:        r.Form.Get("name"),
+		Stage:       r.Form.Get("stage"),
+		Description: r.Form.Get("description"),
+		Backend:     r.Form.Get("backend"),
+	}
+
+	if bucket.Name == "" || (bucket.Stage != "in" && bucket.Stage != "out") {
+		writeMockError(w, http.StatusBadRequest, "storage.buckets.validation", "A bucket needs a name, and a stage of in or out")
+		return
+	}
+
+	if !strings.HasPrefix(bucket.Name, "c-") {
+		bucket.Name = "c-" + bucket.Name
+	}
+
+	if bucket.Backend == "" {
+		bucket.Backend = "snowflake"
+	}
+
+	bucket.ID = fmt.Sprintf("%s.%s", bucket.Stage, bucket.Name)
+
+	if _, ok := m.buckets[bucket.ID]; ok {
+		writeMockError(w, http.StatusBadRequest, "storage.buckets.alreadyExists", fmt.Sprintf("Bucket %s already exists", bucket.ID))
+		return
+	}
+
+	m.buckets[bucket.ID] = bucket
+
+	writeMockJSON(w, http.StatusCreated, bucket)
+}
+
+func (m *mockKeboolaAPI) getBucket(w http.ResponseWriter, r *http.Request, params []string) {
+	if bucket := m.findBucket(w, params[1]); bucket != nil {
+		writeMockJSON(w, http.StatusOK, m.updateBucketStats(bucket))
+	}
+}
+
+func (m *mockKeboolaAPI) deleteBucket(w http.ResponseWriter, r *http.Request, params []string) {
+	bucket := m.findBucket(w, params[1])
+
+	if bucket == nil {
+		return
+	}
+
+	if len(bucket.LinkedBy) > 0 {
+		writeMockError(w, http.StatusBadRequest, "storage.buckets.alreadyLinked", fmt.Sprintf("Bucket %s is linked by other projects", bucket.ID))
+		return
+	}
+
+	force := parseMockBool(r.URL.Query().Get("force"))
+
+	for tableID, table := range m.tables {
+		if table.Bucket.ID != bucket.ID {
+			continue
+		}
+
+		if !force {
+			writeMockError(w, http.StatusBadRequest, "storage.buckets.notEmpty", fmt.Sprintf("Bucket %s is not empty", bucket.ID))
+			return
+		}
+
+		delete(m.tables, tableID)
+	}
+
+	if bucket.SourceBucket != nil {
+		if source, ok := m.buckets[bucket.SourceBucket.ID]; ok {
+			for index, link := range source.LinkedBy {
+				if link.ID == bucket.ID {
+					source.LinkedBy = append(source.LinkedBy[:index], source.LinkedBy[index+1:]...)
+					break
+				}
+			}
+		}
+	}
+
+	delete(m.buckets, bucket.ID)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+//shareBucket returns a handler sharing a bucket in the given mode. POST shares a bucket which
+//is not shared yet, while PUT changes how an already shared bucket is shared.
+func (m *mockKeboolaAPI) shareBucket(sharing string) func(w http.ResponseWriter, r *http.Request, params []string) {
+	return func(w http.ResponseWriter, r *http.Request, params []string) {
+		bucket := m.findBucket(w, params[1])
+
+		if bucket == nil {
+			return
+		}
+
+		if bucket.SourceBucket != nil {
+			writeMockError(w, http.StatusBadRequest, "storage.buckets.cannotShareLinkedBucket", fmt.Sprintf("Linked bucket %s cannot be shared", bucket.ID))
+			return
+		}
+
+		if r.Method == "POST" && bucket.Sharing != "" {
+			writeMockError(w, http.StatusBadRequest, "storage.buckets.alreadyShared", fmt.Sprintf("Bucket %s is already shared", bucket.ID))
+			return
+		}
+
+		if r.Method == "PUT" && bucket.Sharing == "" {
+			writeMockError(w, http.StatusBadRequest, "storage.buckets.notShared", fmt.Sprintf("Bucket %s is not shared", bucket.ID))
+			return
+		}
+
+		r.ParseForm()
+
+		projects := []map[string]interface{}{}
+		for _, projectID := range r.Form["targetProjectIds[]"] {
+			id, _ := strconv.Atoi(projectID)
+			projects = append(projects, map[string]interface{}{"id": id, "name": fmt.Sprintf("Project %d", id)})
+		}
+
+		users := []map[string]interface{}{}
+		for _, user := range r.Form["targetUsers[]"] {
+			users = append(users, map[string]interface{}{"id": m.nextID(), "email": user})
+		}
+
+		if (sharing == "specific-projects" && len(projects) == 0) || (sharing == "specific-users" && len(users) == 0) {
+			writeMockError(w, http.StatusBadRequest, "storage.buckets.validation", fmt.Sprintf("Sharing a bucket with %s requires targets", sharing))
+			return
+		}
+
+		bucket.Sharing = sharing
+		bucket.SharingParameters.Projects = projects
+		bucket.SharingParameters.Users = users
+
+		m.startStorageJob(w, "bucketShare", bucket, nil)
+	}
+}
+
+func (m *mockKeboolaAPI) unshareBucket(w http.ResponseWriter, r *http.Request, params []string) {
+	bucket := m.findBucket(w, params[1])
+
+	if bucket == nil {
+		return
+	}
+
+	if bucket.Sharing == "" {
+		writeMockError(w, http.StatusBadRequest, "storage.buckets.notShared", fmt.Sprintf("Bucket %s is not shared", bucket.ID))
+		return
+	}
+
+	if len(bucket.LinkedBy) > 0 {
+		writeMockError(w, http.StatusBadRequest, "storage.buckets.alreadyLinked", fmt.Sprintf("Bucket %s is linked by other projects", bucket.ID))
+		return
+	}
+
+	bucket.Sharing = ""
+	bucket.SharingParameters.Projects = nil
+	bucket.SharingParameters.Users = nil
+
+	m.startStorageJob(w, "bucketUnshare", nil, nil)
+}
+
+//linkBucket links a shared bucket. The mock only has a single project (1234), which is
+//allowed to link its own shared buckets, unlike the real Storage API.
+func (m *mockKeboolaAPI) linkBucket(w http.ResponseWriter, r *http.Request, params []string) {
+	r.ParseForm()
+
+	if r.Form.Get("sourceProjectId") != "1234" {
+		writeMockError(w, http.StatusNotFound, "storage.projects.notFound", fmt.Sprintf("Project %s not found", r.Form.Get("sourceProjectId")))
+		return
+	}
+
+	source, ok := m.buckets[r.Form.Get("sourceBucketId")]
+
+	if !ok || source.Sharing == "" {
+		writeMockError(w, http.StatusNotFound, "storage.buckets.notFound", fmt.Sprintf("Shared bucket %s not found", r.Form.Get("sourceBucketId")))
+		return
+	}
+
+	bucket := &mockBucket{
+		Name:    r.Form.Get("name"),
+		Stage:   r.Form.Get("stage"),
+		Backend: source.Backend,
+	}
+
+	if !strings.HasPrefix(bucket.Name, "c-") {
+		bucket.Name = "c-" + bucket.Name
+	}
+
+	bucket.ID = fmt.Sprintf("%s.%s", bucket.Stage, bucket.Name)
+
+	if _, ok := m.buckets[bucket.ID]; ok {
+		writeMockError(w, http.StatusBadRequest, "storage.buckets.alreadyExists", fmt.Sprintf("Bucket %s already exists", bucket.ID))
+		return
+	}
+
+	bucket.SourceBucket = &mockBucketLink{ID: source.ID}
+	bucket.SourceBucket.Project.ID = 1234
+	bucket.SourceBucket.Project.Name = "Mock Project"
+
+	link := &mockBucketLink{ID: bucket.ID}
+	link.Project.ID = 1234
+	link.Project.Name = "Mock Project"
+	source.LinkedBy = append(source.LinkedBy, link)
+
+	m.buckets[bucket.ID] = bucket
+
+	if m.synchronousStorageJobs {
+		writeMockJSON(w, http.StatusCreated, bucket)
+		return
+	}
+
+	m.startStorageJob(w, "bucketLink", map[string]string{"id": bucket.ID}, nil)
+}
+
+func (m *mockKeboolaAPI) findTable(w http.ResponseWriter, tableID string) *mockTable {
+	table, ok := m.tables[tableID]
+
+	if !ok {
+		writeMockError(w, http.StatusNotFound, "storage.tables.notFound", fmt.Sprintf("Table %s not found", tableID))
+		return nil
+	}
+
+	return table
+}
+
+//startStorageJob records a Storage API job, which has already finished by the time it is first polled.
+func (m *mockKeboolaAPI) startStorageJob(w http.ResponseWriter, operation string, results interface{}, failure error) {
+	job := &mockStorageJob{
+		ID:        m.nextID(),
+		Status:    "success",
+		Operation: operation,
+		Results:   results,
+	}
+
+	job.URL = fmt.Sprintf("%s/v2/storage/jobs/%d", m.URL, job.ID)
+
+	if failure != nil {
+		job.Status = "error"
+		job.Results = nil
+		job.Error = &struct {
+			Code        string `json:"code"`
+			Message     string `json:"message"`
+			ExceptionID string `json:"exceptionId"`
+		}{"storage.jobFailed", failure.Error(), fmt.Sprintf("mock-job-%d", job.ID)}
+	}
+
+	m.storageJobs[job.ID] = job
+
+	writeMockJSON(w, http.StatusAccepted, map[string]interface{}{
+		"id":            job.ID,
+		"url":           job.URL,
+		"status":        "waiting",
+		"operationName": operation,
+	})
+}
+
+func (m *mockKeboolaAPI) getStorageJob(w http.ResponseWriter, r *http.Request, params []string) {
+	jobID, _ := strconv.Atoi(params[1])
+	job, ok := m.storageJobs[jobID]
+
+	if !ok {
+		writeMockError(w, http.StatusNotFound, "storage.jobs.notFound", fmt.Sprintf("Job %d not found", jobID))
+		return
+	}
+
+	writeMockJSON(w, http.StatusOK, job)
+}
+
+func (m *mockKeboolaAPI) createTableAsync(w http.ResponseWriter, r *http.Request, params []string) {
+	bucket := m.findBucket(w, params[1])
+
+	if bucket == nil {
+		return
+	}
+
+	r.ParseForm()
+
+	if snapshotID := r.Form.Get("snapshotId"); snapshotID != "" {
+		m.restoreTableFromSnapshot(w, bucket, r.Form.Get("name"), snapshotID)
+		return
+	}
+
+	fileID, _ := strconv.Atoi(r.Form.Get("dataFileId"))
+	file, ok := m.files[fileID]
+
+	if !ok {
+		writeMockError(w, http.StatusBadRequest, "storage.files.notFound", fmt.Sprintf("File %d not found", fileID))
+		return
+	}
+
+	delimiter := r.Form.Get("delimiter")
+	if delimiter == "" {
+		delimiter = ","
+	}
+
+	reader := csv.NewReader(strings.NewReader(file.Content))
+	reader.Comma = []rune(delimiter)[0]
+	header, err := reader.Read()
+
+	if err != nil {
+		m.startStorageJob(w, "tableCreate", nil, fmt.Errorf("Unable to read the CSV header: %s", err))
+		return
+	}
+
+	table := &mockTable{
+		Name:           r.Form.Get("name"),
+		Columns:        header,
+		PrimaryKey:     parseMockList(r.Form.Get("primaryKey")),
+		IndexedColumns: []string{},
+	}
+
+	table.ID = fmt.Sprintf("%s.%s", bucket.ID, table.Name)
+	table.Bucket.ID = bucket.ID
+
+	if _, ok := m.tables[table.ID]; ok {
+		m.startStorageJob(w, "tableCreate", nil, fmt.Errorf("Table %s already exists", table.ID))
+		return
+	}
+
+	for _, primaryKeyColumn := range table.PrimaryKey {
+		if !containsString(table.Columns, primaryKeyColumn) {
+			m.startStorageJob(w, "tableCreate", nil, fmt.Errorf("Primary key column %s is not a column of the table", primaryKeyColumn))
+			return
+		}
+	}
+
+	m.tables[table.ID] = table
+	m.startStorageJob(w, "tableCreate", map[string]string{"id": table.ID, "name": table.Name}, nil)
+}
+
+//copyMockTable copies the columns, primary key, definition and rows of a table, so that a snapshot
+//(or a table restored from one) is not changed along with the table it was copied from.
+func copyMockTable(table mockTable) mockTable {
+	table.Columns = append([]string{}, table.Columns...)
+	table.PrimaryKey = append([]string{}, table.PrimaryKey...)
+	table.Metadata = nil
+	table.ColumnMetadata = nil
+
+	if table.Definition != nil {
+		definition := *table.Definition
+		definition.PrimaryKeysNames = append([]string{}, definition.PrimaryKeysNames...)
+		definition.Columns = make([]*mockColumnDefinition, 0, len(table.Definition.Columns))
+
+		for _, column := range table.Definition.Columns {
+			columnCopy := *column
+			definition.Columns = append(definition.Columns, &columnCopy)
+		}
+		table.Definition = &definition
+	}
+
+	rows := make([][]string, 0, len(table.rows))
+	for _, row := range table.rows {
+		rows = append(rows, append([]string{}, row...))
+	}
+
+	table.rows = rows
+
+	return table
+}
+
+func (m *mockKeboolaAPI) createTableSnapshot(w http.ResponseWriter, r *http.Request, params []string) {
+	table := m.findTable(w, params[1])
+
+	if table == nil {
+		return
+	}
+
+	if table.IsAlias {
+		m.startStorageJob(w, "tableSnapshotCreate", nil, fmt.Errorf("Snapshots of alias tables are not supported"))
+		return
+	}
+
+	r.ParseForm()
+
+	snapshot := &mockSnapshot{
+		ID:          m.nextID(),
+		Description: r.Form.Get("description"),
+		CreatedTime: "2020-01-01T00:00:00+0000",
+		table:       copyMockTable(*table),
+	}
+
+	snapshot.Table.ID = table.ID
+	snapshot.Table.Name = table.Name
+
+	m.snapshots[strconv.Itoa(snapshot.ID)] = snapshot
+	m.startStorageJob(w, "tableSnapshotCreate", map[string]interface{}{"id": snapshot.ID}, nil)
+}
+
+func (m *mockKeboolaAPI) getSnapshot(w http.ResponseWriter, r *http.Request, params []string) {
+	snapshot, ok := m.snapshots[params[1]]
+
+	if !ok {
+		writeMockError(w, http.StatusNotFound, "storage.snapshots.notFound", fmt.Sprintf("Snapshot %s not found", params[1]))
+		return
+	}
+
+	writeMockJSON(w, http.StatusOK, snapshot)
+}
+
+func (m *mockKeboolaAPI) deleteSnapshot(w http.ResponseWriter, r *http.Request, params []string) {
+	if _, ok := m.snapshots[params[1]]; !ok {
+		writeMockError(w, http.StatusNotFound, "storage.snapshots.notFound", fmt.Sprintf("Snapshot %s not found", params[1]))
+		return
+	}
+
+	delete(m.snapshots, params[1])
+	w.WriteHeader(http.StatusNoContent)
+}
+
+//restoreTableFromSnapshot creates a table in a bucket from a copy of the table a snapshot was taken of.
+func (m *mockKeboolaAPI) restoreTableFromSnapshot(w http.ResponseWriter, bucket *mockBucket, name string, snapshotID string) {
+	snapshot, ok := m.snapshots[snapshotID]
+
+	if !ok {
+		m.startStorageJob(w, "tableCreate", nil, fmt.Errorf("Snapshot %s not found", snapshotID))
+		return
+	}
+
+	table := copyMockTable(snapshot.table)
+	table.Name = name
+	table.ID = fmt.Sprintf("%s.%s", bucket.ID, name)
+	table.Bucket.ID = bucket.ID
+
+	if _, ok := m.tables[table.ID]; ok {
+		m.startStorageJob(w, "tableCreate", nil, fmt.Errorf("Table %s already exists", table.ID))
+		return
+	}
+
+	m.tables[table.ID] = &table
+	m.startStorageJob(w, "tableCreate", map[string]string{"id": table.ID, "name": table.Name}, nil)
+}
+
+//mockOptionalString returns nil for an empty string, as the Storage API returns null for unset lengths and defaults.
+func mockOptionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+
+	return &value
+}
+
+func (m *mockKeboolaAPI) createTableDefinition(w http.ResponseWriter, r *http.Request, params []string) {
+	bucket := m.findBucket(w, params[1])
+
+	if bucket == nil {
+		return
+	}
+
+	var request struct {
+		Name             string   `json:"name"`
+		PrimaryKeysNames []string `json:"primaryKeysNames"`
+		Columns          []struct {
+			Name       string `json:"name"`
+			Definition struct {
+				Type     string `json:"type"`
+				Length   string `json:"length"`
+				Nullable bool   `json:"nullable"`
+				Default  string `json:"default"`
+			} `json:"definition"`
+		} `json:"columns"`
+	}
+
+	if r.Header.Get("Content-Type") != "application/json" || json.NewDecoder(r.Body).Decode(&request) != nil {
+		writeMockError(w, http.StatusBadRequest, "storage.validation", "Invalid JSON table definition")
+		return
+	}
+
+	table := &mockTable{
+		Name:           request.Name,
+		Columns:        []string{},
+		PrimaryKey:     request.PrimaryKeysNames,
+		IndexedColumns: []string{},
+		IsTyped:        true,
+		Definition:     &mockTableDefinition{PrimaryKeysNames: request.PrimaryKeysNames},
+	}
+
+	if table.PrimaryKey == nil {
+		table.PrimaryKey = []string{}
+	}
+
+	table.ID = fmt.Sprintf("%s.%s", bucket.ID, table.Name)
+	table.Bucket.ID = bucket.ID
+
+	if _, ok := m.tables[table.ID]; ok {
+		m.startStorageJob(w, "tableDefinitionCreate", nil, fmt.Errorf("Table %s already exists", table.ID))
+		return
+	}
+
+	for _, column := range request.Columns {
+		if column.Definition.Type == "" {
+			writeMockError(w, http.StatusBadRequest, "storage.validation", fmt.Sprintf("Column %q has no type", column.Name))
+			return
+		}
+
+		table.Columns = append(table.Columns, column.Name)
+		table.Definition.Columns = append(table.Definition.Columns, &mockColumnDefinition{
+			Name: column.Name,
+			Definition: mockColumnType{
+				Type:     column.Definition.Type,
+				Length:   mockOptionalString(column.Definition.Length),
+				Nullable: column.Definition.Nullable,
+				Default:  mockOptionalString(column.Definition.Default),
+			}.normalized(),
+		})
+	}
+
+	for _, primaryKeyColumn := range table.PrimaryKey {
+		if !containsString(table.Columns, primaryKeyColumn) {
+			m.startStorageJob(w, "tableDefinitionCreate", nil, fmt.Errorf("Primary key column %s is not a column of the table", primaryKeyColumn))
+			return
+		}
+	}
+
+	m.tables[table.ID] = table
+
+	if m.synchronousStorageJobs {
+		writeMockJSON(w, http.StatusCreated, table)
+		return
+	}
+
+	m.startStorageJob(w, "tableDefinitionCreate", map[string]string{"id": table.ID, "name": table.Name}, nil)
+}
+
+//importTableAsync loads the rows of an uploaded CSV file into a table, replacing its rows, or (when incremental)
+//adding to them, with rows that have the same primary key as an existing row replacing that row.
+func (m *mockKeboolaAPI) importTableAsync(w http.ResponseWriter, r *http.Request, params []string) {
+	table := m.findTable(w, params[1])
+
+	if table == nil {
+		return
+	}
+
+	r.ParseForm()
+
+	fileID, _ := strconv.Atoi(r.Form.Get("dataFileId"))
+	file, ok := m.files[fileID]
+
+	if !ok {
+		writeMockError(w, http.StatusBadRequest, "storage.files.notFound", fmt.Sprintf("File %d not found", fileID))
+		return
+	}
+
+	reader := csv.NewReader(strings.NewReader(file.Content))
+	reader.Comma = []rune(r.Form.Get("delimiter"))[0]
+	records, err := reader.ReadAll()
+
+	if err != nil || len(records) == 0 {
+		m.startStorageJob(w, "tableImport", nil, fmt.Errorf("Unable to read the CSV file: %v", err))
+		return
+	}
+
+	header := records[0]
+
+	for _, column := range header {
+		if !containsString(table.Columns, column) {
+			m.startStorageJob(w, "tableImport", nil, fmt.Errorf("Column %s is not a column of table %s", column, table.ID))
+			return
+		}
+	}
+
+	rows := [][]string{}
+	if parseMockBool(r.Form.Get("incremental")) {
+		rows = table.rows
+	}
+
+	primaryKey := func(row []string) string {
+		var values []string
+		for index, column := range table.Columns {
+			if containsString(table.PrimaryKey, column) {
+				values = append(values, row[index])
+			}
+		}
+
+		return strings.Join(values, "\x00")
+	}
+
+	for _, record := range records[1:] {
+		row := make([]string, len(table.Columns))
+
+		for index, column := range table.Columns {
+			for headerIndex, headerColumn := range header {
+				if headerColumn == column {
+					row[index] = record[headerIndex]
+				}
+			}
+		}
+
+		replaced := false
+
+		if len(table.PrimaryKey) > 0 {
+			for index, existing := range rows {
+				if primaryKey(existing) == primaryKey(row) {
+					rows[index] = row
+					replaced = true
+				}
+			}
+		}
+
+		if !replaced {
+			rows = append(rows, row)
+		}
+	}
+
+	table.rows = rows
+	table.RowsCount = len(rows)
+	table.DataSizeBytes = len(file.Content)
+	table.LastImportDate = time.Now().Format("2006-01-02T15:04:05-0700")
+
+	m.startStorageJob(w, "tableImport", map[string]interface{}{"importedColumns": header, "totalRowsCount": len(rows)}, nil)
+}
+
+//sortedTables returns the tables of a bucket (or of all buckets, if bucketID is empty), ordered by ID.
+func (m *mockKeboolaAPI) sortedTables(bucketID string) []*mockTable {
+	tables := []*mockTable{}
+
+	for _, table := range m.tables {
+		if bucketID == "" || table.Bucket.ID == bucketID {
+			tables = append(tables, table)
+		}
+	}
+
+	sort.Slice(tables, func(i, j int) bool {
+		return tables[i].ID < tables[j].ID
+	})
+
+	return tables
+}
+
+func (m *mockKeboolaAPI) listTables(w http.ResponseWriter, r *http.Request, params []string) {
+	writeMockJSON(w, http.StatusOK, m.sortedTables(""))
+}
+
+func (m *mockKeboolaAPI) listBucketTables(w http.ResponseWriter, r *http.Request, params []string) {
+	if bucket := m.findBucket(w, params[1]); bucket != nil {
+		writeMockJSON(w, http.StatusOK, m.sortedTables(bucket.ID))
+	}
+}
+
+func (m *mockKeboolaAPI) getTable(w http.ResponseWriter, r *http.Request, params []string) {
+	if table := m.findTable(w, params[1]); table != nil {
+		writeMockJSON(w, http.StatusOK, table)
+	}
+}
+
+func (m *mockKeboolaAPI) deleteTable(w http.ResponseWriter, r *http.Request, params []string) {
+	if table := m.findTable(w, params[1]); table != nil {
+		for _, alias := range m.tables {
+			if alias.SourceTable != nil && alias.SourceTable.ID == table.ID {
+				writeMockError(w, http.StatusBadRequest, "storage.tables.cannotDeleteTableWithAliases", fmt.Sprintf("Table %s has aliases, which have to be deleted first", table.ID))
+				return
+			}
+		}
+
+		delete(m.tables, table.ID)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+//parseMockAliasFilter parses an aliasFilter[column], aliasFilter[operator] and aliasFilter[values][]
+//form, checking that the column is one of the given columns. It returns nil if there is no filter.
+func parseMockAliasFilter(form url.Values, columns []string) (*mockAliasFilter, error) {
+	if form.Get("aliasFilter[column]") == "" {
+		return nil, nil
+	}
+
+	filter := &mockAliasFilter{
+		Column:   form.Get("aliasFilter[column]"),
+		Operator: form.Get("aliasFilter[operator]"),
+		Values:   form["aliasFilter[values][]"],
+	}
+
+	if filter.Operator == "" {
+		filter.Operator = "eq"
+	}
+
+	if filter.Operator != "eq" && filter.Operator != "ne" {
+		return nil, fmt.Errorf("Invalid filter operator %s", filter.Operator)
+	}
+
+	if !containsString(columns, filter.Column) {
+		return nil, fmt.Errorf("Filter column %s is not a column of the table", filter.Column)
+	}
+
+	return filter, nil
+}
+
+func (m *mockKeboolaAPI) createTableAlias(w http.ResponseWriter, r *http.Request, params []string) {
+	bucket := m.findBucket(w, params[1])
+
+	if bucket == nil {
+		return
+	}
+
+	r.ParseForm()
+
+	source, ok := m.tables[r.Form.Get("sourceTable")]
+
+	if !ok {
+		writeMockError(w, http.StatusBadRequest, "storage.tables.notFound", fmt.Sprintf("Source table %s not found", r.Form.Get("sourceTable")))
+		return
+	}
+
+	if source.IsAlias {
+		writeMockError(w, http.StatusBadRequest, "storage.tables.cannotCreateAliasFromAlias", "An alias cannot be created from another alias")
+		return
+	}
+
+	alias := &mockTable{
+		Name:                 r.Form.Get("name"),
+		Columns:              append([]string{}, source.Columns...),
+		PrimaryKey:           source.PrimaryKey,
+		IndexedColumns:       []string{},
+		IsAlias:              true,
+		AliasColumnsAutoSync: true,
+	}
+
+	alias.ID = fmt.Sprintf("%s.%s", bucket.ID, alias.Name)
+	alias.Bucket.ID = bucket.ID
+	alias.SourceTable = &struct {
+		ID string `json:"id"`
+	}{source.ID}
+
+	if _, ok := m.tables[alias.ID]; ok {
+		writeMockError(w, http.StatusBadRequest, "storage.tables.alreadyExists", fmt.Sprintf("Table %s already exists", alias.ID))
+		return
+	}
+
+	if aliasColumns := r.Form["aliasColumns[]"]; len(aliasColumns) > 0 {
+		for _, column := range aliasColumns {
+			if !containsString(source.Columns, column) {
+				writeMockError(w, http.StatusBadRequest, "storage.tables.columnNotFound", fmt.Sprintf("Column %q not found in table %s", column, source.ID))
+				return
+			}
+		}
+
+		alias.Columns = aliasColumns
+		alias.AliasColumnsAutoSync = false
+	}
+
+	filter, err := parseMockAliasFilter(r.Form, source.Columns)
+
+	if err != nil {
+		writeMockError(w, http.StatusBadRequest, "storage.tables.validation", err.Error())
+		return
+	}
+
+	alias.AliasFilter = filter
+
+	m.tables[alias.ID] = alias
+
+	writeMockJSON(w, http.StatusCreated, alias)
+}
+
+//syncAliasColumns copies the columns of a table to its aliases which synchronise their columns automatically.
+func (m *mockKeboolaAPI) syncAliasColumns(source *mockTable) {
+	for _, alias := range m.tables {
+		if alias.SourceTable != nil && alias.SourceTable.ID == source.ID && alias.AliasColumnsAutoSync {
+			alias.Columns = append([]string{}, source.Columns...)
+		}
+	}
+}
+
+func (m *mockKeboolaAPI) findAlias(w http.ResponseWriter, tableID string) *mockTable {
+	alias := m.findTable(w, tableID)
+
+	if alias != nil && !alias.IsAlias {
+		writeMockError(w, http.StatusBadRequest, "storage.tables.notAlias", fmt.Sprintf("Table %s is not an alias", tableID))
+		return nil
+	}
+
+	return alias
+}
+
+func (m *mockKeboolaAPI) setAliasFilter(w http.ResponseWriter, r *http.Request, params []string) {
+	alias := m.findAlias(w, params[1])
+
+	if alias == nil {
+		return
+	}
+
+	r.ParseForm()
+
+	filter, err := parseMockAliasFilter(r.Form, m.tables[alias.SourceTable.ID].Columns)
+
+	if err == nil && filter == nil {
+		err = fmt.Errorf("A filter column is required")
+	}
+
+	if err != nil {
+		writeMockError(w, http.StatusBadRequest, "storage.tables.validation", err.Error())
+		return
+	}
+
+	alias.AliasFilter = filter
+
+	writeMockJSON(w, http.StatusOK, alias)
+}
+
+func (m *mockKeboolaAPI) removeAliasFilter(w http.ResponseWriter, r *http.Request, params []string) {
+	if alias := m.findAlias(w, params[1]); alias != nil {
+		alias.AliasFilter = nil
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (m *mockKeboolaAPI) disableAliasAutoSync(w http.ResponseWriter, r *http.Request, params []string) {
+	if alias := m.findAlias(w, params[1]); alias != nil {
+		alias.AliasColumnsAutoSync = false
+		writeMockJSON(w, http.StatusOK, alias)
+	}
+}
+
+func (m *mockKeboolaAPI) addTableColumn(w http.ResponseWriter, r *http.Request, params []string) {
+	table := m.findTable(w, params[1])
+
+	if table == nil {
+		return
+	}
+
+	r.ParseForm()
+
+	column := r.Form.Get("name")
+
+	if column == "" || containsString(table.Columns, column) {
+		writeMockError(w, http.StatusBadRequest, "storage.tables.columnAlreadyExists", fmt.Sprintf("Column %q already exists in table %s", column, table.ID))
+		return
+	}
+
+	if table.IsTyped {
+		if r.Form.Get("definition[type]") == "" {
+			writeMockError(w, http.StatusBadRequest, "storage.validation", fmt.Sprintf("Column %q of typed table %s has no type", column, table.ID))
+			return
+		}
+
+		table.Definition.Columns = append(table.Definition.Columns, &mockColumnDefinition{
+			Name: column,
+			Definition: mockColumnType{
+				Type:     r.Form.Get("definition[type]"),
+				Length:   mockOptionalString(r.Form.Get("definition[length]")),
+				Nullable: parseMockBool(r.Form.Get("definition[nullable]")),
+				Default:  mockOptionalString(r.Form.Get("definition[default]")),
+			}.normalized(),
+		})
+	}
+
+	table.Columns = append(table.Columns, column)
+
+	for index := range table.rows {
+		table.rows[index] = append(table.rows[index], "")
+	}
+
+	m.syncAliasColumns(table)
+
+	m.startStorageJob(w, "tableColumnAdd", table, nil)
+}
+
+func (m *mockKeboolaAPI) deleteTableColumn(w http.ResponseWriter, r *http.Request, params []string) {
+	table := m.findTable(w, params[1])
+
+	if table == nil {
+		return
+	}
+
+	column := params[2]
+
+	if !containsString(table.Columns, column) {
+		writeMockError(w, http.StatusNotFound, "storage.tables.columnNotFound", fmt.Sprintf("Column %q not found in table %s", column, table.ID))
+		return
+	}
+
+	if containsString(table.PrimaryKey, column) {
+		writeMockError(w, http.StatusBadRequest, "storage.tables.cannotDeletePrimaryKeyColumn", fmt.Sprintf("Column %q is part of the primary key of table %s", column, table.ID))
+		return
+	}
+
+	columns := []string{}
+	keptIndexes := []int{}
+
+	for index, existing := range table.Columns {
+		if existing != column {
+			columns = append(columns, existing)
+			keptIndexes = append(keptIndexes, index)
+		}
+	}
+
+	for rowIndex, row := range table.rows {
+		keptValues := make([]string, 0, len(keptIndexes))
+		for _, index := range keptIndexes {
+			keptValues = append(keptValues, row[index])
+		}
+
+		table.rows[rowIndex] = keptValues
+	}
+
+	table.Columns = columns
+	delete(table.ColumnMetadata, column)
+
+	if table.IsTyped {
+		var definitions []*mockColumnDefinition
+
+		for _, definition := range table.Definition.Columns {
+			if definition.Name != column {
+				definitions = append(definitions, definition)
+			}
+		}
+
+		table.Definition.Columns = definitions
+	}
+
+	m.syncAliasColumns(table)
+
+	m.startStorageJob(w, "tableColumnDelete", nil, nil)
+}
+
+func (m *mockKeboolaAPI) updateTableColumnDefinition(w http.ResponseWriter, r *http.Request, params []string) {
+	table := m.findTable(w, params[1])
+
+	if table == nil {
+		return
+	}
+
+	if !table.IsTyped {
+		writeMockError(w, http.StatusBadRequest, "storage.tables.notTyped", fmt.Sprintf("Table %s is not typed", table.ID))
+		return
+	}
+
+	definition := table.Definition.column(params[2])
+
+	if definition == nil {
+		writeMockError(w, http.StatusNotFound, "storage.tables.columnNotFound", fmt.Sprintf("Column %q not found in table %s", params[2], table.ID))
+		return
+	}
+
+	var request struct {
+		Type     string `json:"type"`
+		Length   string `json:"length"`
+		Nullable *bool  `json:"nullable"`
+		Default  string `json:"default"`
+	}
+
+	if r.Header.Get("Content-Type") != "application/json" || json.NewDecoder(r.Body).Decode(&request) != nil {
+		writeMockError(w, http.StatusBadRequest, "storage.validation", "Invalid JSON column definition")
+		return
+	}
+
+	if request.Type != "" {
+		writeMockError(w, http.StatusBadRequest, "storage.validation", "The type of a column cannot be changed")
+		return
+	}
+
+	definition.Definition.Length = mockOptionalString(request.Length)
+	definition.Definition.Default = mockOptionalString(request.Default)
+	definition.Definition = definition.Definition.normalized()
+
+	if request.Nullable != nil {
+		definition.Definition.Nullable = *request.Nullable
+	}
+
+	m.startStorageJob(w, "tableColumnDefinitionUpdate", table, nil)
+}
+
+func (m *mockKeboolaAPI) createTablePrimaryKey(w http.ResponseWriter, r *http.Request, params []string) {
+	table := m.findTable(w, params[1])
+
+	if table == nil {
+		return
+	}
+
+	if len(table.PrimaryKey) > 0 {
+		writeMockError(w, http.StatusBadRequest, "storage.tables.primaryKeyAlreadyExists", fmt.Sprintf("Table %s already has a primary key", table.ID))
+		return
+	}
+
+	r.ParseForm()
+
+	primaryKey := r.Form["columns[]"]
+	keyIndexes := []int{}
+
+	for _, column := range primaryKey {
+		index := -1
+		for columnIndex, existing := range table.Columns {
+			if existing == column {
+				index = columnIndex
+			}
+		}
+
+		if index < 0 {
+			writeMockError(w, http.StatusBadRequest, "storage.tables.columnNotFound", fmt.Sprintf("Column %q not found in table %s", column, table.ID))
+			return
+		}
+
+		keyIndexes = append(keyIndexes, index)
+	}
+
+	seen := make(map[string]bool)
+
+	for _, row := range table.rows {
+		key := make([]string, 0, len(keyIndexes))
+		for _, index := range keyIndexes {
+			key = append(key, row[index])
+		}
+
+		joined := strings.Join(key, "\x00")
+
+		if seen[joined] {
+			m.startStorageJob(w, "tablePrimaryKeyAdd", nil, fmt.Errorf("Primary key (%s) contains duplicate values", strings.Join(primaryKey, ", ")))
+			return
+		}
+
+		seen[joined] = true
+	}
+
+	table.PrimaryKey = primaryKey
+
+	m.startStorageJob(w, "tablePrimaryKeyAdd", table, nil)
+}
+
+func (m *mockKeboolaAPI) deleteTablePrimaryKey(w http.ResponseWriter, r *http.Request, params []string) {
+	if table := m.findTable(w, params[1]); table != nil {
+		table.PrimaryKey = []string{}
+		m.startStorageJob(w, "tablePrimaryKeyDelete", nil, nil)
+	}
+}
+
+var mockColumnsMetadataField = regexp.MustCompile(`^columnsMetadata\[(.+)\]\[0\]\[key\]$`)
+
+//setMockMetadata sets the metadata entries posted as prefix[0][key], prefix[0][value]... under the
+//given provider, replacing the values of entries which already exist.
+func (m *mockKeboolaAPI) setMockMetadata(metadata []*mockMetadata, provider string, form url.Values, prefix string) []*mockMetadata {
+	for index := 0; ; index++ {
+		keys, ok := form[fmt.Sprintf("%s[%d][key]", prefix, index)]
+
+		if !ok {
+			return metadata
+		}
+
+		value := form.Get(fmt.Sprintf("%s[%d][value]", prefix, index))
+		found := false
+
+		for _, entry := range metadata {
+			if entry.Provider == provider && entry.Key == keys[0] {
+				entry.Value = value
+				found = true
+			}
+		}
+
+		if !found {
+			metadata = append(metadata, &mockMetadata{strconv.Itoa(m.nextID()), keys[0], value, provider})
+		}
+	}
+}
+
+//deleteMockMetadata removes the metadata entry with the given ID, writing a 404 if there is no such entry.
+func deleteMockMetadata(w http.ResponseWriter, metadata []*mockMetadata, metadataID string) []*mockMetadata {
+	for index, entry := range metadata {
+		if entry.ID == metadataID {
+			w.WriteHeader(http.StatusNoContent)
+			return append(metadata[:index], metadata[index+1:]...)
+		}
+	}
+
+	writeMockError(w, http.StatusNotFound, "storage.metadata.notFound", fmt.Sprintf("Metadata %s not found", metadataID))
+	return metadata
+}
+
+func (m *mockKeboolaAPI) setBucketMetadata(w http.ResponseWriter, r *http.Request, params []string) {
+	bucket := m.findBucket(w, params[1])
+
+	if bucket == nil {
+		return
+	}
+
+	r.ParseForm()
+
+	provider := r.Form.Get("provider")
+
+	if provider == "" {
+		writeMockError(w, http.StatusBadRequest, "storage.metadata.invalidProvider", "A metadata provider is required")
+		return
+	}
+
+	bucket.Metadata = m.setMockMetadata(bucket.Metadata, provider, r.Form, "metadata")
+
+	writeMockJSON(w, http.StatusCreated, bucket.Metadata)
+}
+
+func (m *mockKeboolaAPI) deleteBucketMetadata(w http.ResponseWriter, r *http.Request, params []string) {
+	if bucket := m.findBucket(w, params[1]); bucket != nil {
+		bucket.Metadata = deleteMockMetadata(w, bucket.Metadata, params[2])
+	}
+}
+
+func (m *mockKeboolaAPI) setTableMetadata(w http.ResponseWriter, r *http.Request, params []string) {
+	table := m.findTable(w, params[1])
+
+	if table == nil {
+		return
+	}
+
+	r.ParseForm()
+
+	provider := r.Form.Get("provider")
+
+	if provider == "" {
+		writeMockError(w, http.StatusBadRequest, "storage.metadata.invalidProvider", "A metadata provider is required")
+		return
+	}
+
+	columns := []string{}
+
+	for field := range r.Form {
+		if columnField := mockColumnsMetadataField.FindStringSubmatch(field); columnField != nil {
+			if !containsString(table.Columns, columnField[1]) {
+				writeMockError(w, http.StatusBadRequest, "storage.tables.columnNotFound", fmt.Sprintf("Column %q not found in table %s", columnField[1], table.ID))
+				return
+			}
+
+			columns = append(columns, columnField[1])
+		}
+	}
+
+	table.Metadata = m.setMockMetadata(table.Metadata, provider, r.Form, "metadata")
+
+	if table.ColumnMetadata == nil {
+		table.ColumnMetadata = make(mockColumnMetadata)
+	}
+
+	for _, column := range columns {
+		table.ColumnMetadata[column] = m.setMockMetadata(table.ColumnMetadata[column], provider, r.Form, fmt.Sprintf("columnsMetadata[%s]", column))
+	}
+
+	writeMockJSON(w, http.StatusCreated, map[string]interface{}{
+		"metadata":        table.Metadata,
+		"columnsMetadata": table.ColumnMetadata,
+	})
+}
+
+func (m *mockKeboolaAPI) deleteTableMetadata(w http.ResponseWriter, r *http.Request, params []string) {
+	if table := m.findTable(w, params[1]); table != nil {
+		table.Metadata = deleteMockMetadata(w, table.Metadata, params[2])
+	}
+}
+
+func (m *mockKeboolaAPI) deleteColumnMetadata(w http.ResponseWriter, r *http.Request, params []string) {
+	table := m.findTable(w, params[1])
+
+	if table == nil {
+		return
+	}
+
+	column := params[2]
+
+	if !containsString(table.Columns, column) {
+		writeMockError(w, http.StatusNotFound, "storage.tables.columnNotFound", fmt.Sprintf("Column %q not found in table %s", column, table.ID))
+		return
+	}
+
+	table.ColumnMetadata[column] = deleteMockMetadata(w, table.ColumnMetadata[column], params[3])
+
+	if len(table.ColumnMetadata[column]) == 0 {
+		delete(table.ColumnMetadata, column)
+	}
+}
+
+func (m *mockKeboolaAPI) findFile(w http.ResponseWriter, fileID string) *mockFile {
+	id, _ := strconv.Atoi(fileID)
+	file, ok := m.files[id]
+
+	if !ok {
+		writeMockError(w, http.StatusNotFound, "storage.files.notFound", fmt.Sprintf("File %s not found", fileID))
+		return nil
+	}
+
+	return file
+}
+
+//prepareFile creates a Storage file, returning the parameters for uploading its content to the file storage.
+func (m *mockKeboolaAPI) prepareFile(w http.ResponseWriter, r *http.Request, params []string) {
+	r.ParseForm()
+
+	if r.Form.Get("name") == "" {
+		writeMockError(w, http.StatusBadRequest, "storage.validation", "name is required")
+		return
+	}
+
+	file := &mockFile{
+		ID:          m.nextID(),
+		Name:        r.Form.Get("name"),
+		IsPublic:    parseMockBool(r.Form.Get("isPublic")),
+		IsPermanent: parseMockBool(r.Form.Get("isPermanent")),
+		Tags:        r.Form["tags[]"],
+		Created:     "2020-01-01T00:00:00+0000",
+		Provider:    m.fileStorageProvider,
+	}
+
+	file.SizeBytes, _ = strconv.Atoi(r.Form.Get("sizeBytes"))
+	m.files[file.ID] = file
+
+	preparedFile := map[string]interface{}{
+		"id":       file.ID,
+		"name":     file.Name,
+		"provider": file.Provider,
+	}
+
+	//Like the Storage API, only the upload parameters of the stack's provider are returned, and those of S3 are otherwise empty.
+	switch file.Provider {
+	case "aws":
+		preparedFile["uploadParams"] = map[string]string{
+			"url":       m.URL + mockFileStoragePath,
+			"key":       strconv.Itoa(file.ID),
+			"acl":       "private",
+			"signature": fmt.Sprintf("signature-%d", file.ID),
+		}
+	case "azure":
+		preparedFile["uploadParams"] = []string{}
+		preparedFile["absUploadParams"] = map[string]interface{}{
+			"blobName":      fmt.Sprintf("files/%d", file.ID),
+			"accountName":   "kbcfiles",
+			"containerName": "kbc-files",
+			"absCredentials": map[string]string{
+				"SASConnectionString": fmt.Sprintf("BlobEndpoint=%s%s/abs/;SharedAccessSignature=sv=2017-11-09&sr=c&sig=signature-%d", m.URL, mockFileStoragePath, file.ID),
+				"expiration":          "2020-01-01T01:00:00+0000",
+			},
+		}
+	case "gcp":
+		preparedFile["uploadParams"] = []string{}
+		preparedFile["gcsUploadParams"] = map[string]interface{}{
+			"projectId":    "kbc-files",
+			"bucket":       "kbc-files",
+			"key":          fmt.Sprintf("files/%d", file.ID),
+			"access_token": fmt.Sprintf("token-%d", file.ID),
+			"token_type":   "Bearer",
+			"expires_in":   3600,
+		}
+	}
+
+	writeMockJSON(w, http.StatusOK, preparedFile)
+}
+
+//uploadToFileStorage stores the content of a prepared file, after checking the credentials of its upload parameters,
+//in the way the file storage of the stack's provider does.
+func (m *mockKeboolaAPI) uploadToFileStorage(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("X-StorageApi-Token") != "" {
+		writeMockError(w, http.StatusForbidden, "AccessDenied", "The Storage API token must not be sent to the file storage")
+		return
+	}
+
+	switch m.fileStorageProvider {
+	case "azure":
+		m.uploadToABS(w, r)
+	case "gcp":
+		m.uploadToGCS(w, r)
+	default:
+		m.uploadToS3(w, r)
+	}
+}
+
+//uploadToS3 stores the content of a prepared file posted as a multipart form, along with its signed upload parameters.
+func (m *mockKeboolaAPI) uploadToS3(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" || r.URL.Path != mockFileStoragePath {
+		writeMockError(w, http.StatusMethodNotAllowed, "MethodNotAllowed", "Files are posted to the bucket")
+		return
+	}
+
+	if err := r.ParseMultipartForm(1 << 20); err != nil {
+		writeMockError(w, http.StatusBadRequest, "MalformedPOSTRequest", err.Error())
+		return
+	}
+
+	fileID, _ := strconv.Atoi(r.FormValue("key"))
+	file, ok := m.files[fileID]
+
+	if !ok || r.FormValue("signature") != fmt.Sprintf("signature-%d", fileID) {
+		writeMockError(w, http.StatusForbidden, "SignatureDoesNotMatch", "Invalid upload signature")
+		return
+	}
+
+	data, _, err := r.FormFile("file")
+
+	if err != nil {
+		writeMockError(w, http.StatusBadRequest, "MalformedPOSTRequest", err.Error())
+		return
+	}
+
+	content, _ := ioutil.ReadAll(data)
+	file.Content = string(content)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+//uploadToABS stores the content of a prepared file put as a block blob, authorised by its shared access signature.
+func (m *mockKeboolaAPI) uploadToABS(w http.ResponseWriter, r *http.Request) {
+	blobName := strings.TrimPrefix(r.URL.Path, mockFileStoragePath+"/abs/kbc-files/")
+
+	if r.Method != "PUT" || blobName == r.URL.Path || r.Header.Get("x-ms-blob-type") != "BlockBlob" {
+		writeMockError(w, http.StatusBadRequest, "InvalidHeaderValue", "Blobs are put to the container as block blobs")
+		return
+	}
+
+	fileID, _ := strconv.Atoi(strings.TrimPrefix(blobName, "files/"))
+	file, ok := m.files[fileID]
+
+	if !ok || r.URL.Query().Get("sig") != fmt.Sprintf("signature-%d", fileID) {
+		writeMockError(w, http.StatusForbidden, "AuthenticationFailed", "Invalid shared access signature")
+		return
+	}
+
+	content, _ := ioutil.ReadAll(r.Body)
+	file.Content = string(content)
+
+	w.WriteHeader(http.StatusCreated)
+}
+
+//uploadToGCS stores the content of a prepared file uploaded as an object, authorised by its access token.
+func (m *mockKeboolaAPI) uploadToGCS(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" || r.URL.Path != mockFileStoragePath+"/gcs/b/kbc-files/o" || r.URL.Query().Get("uploadType") != "media" {
+		writeMockError(w, http.StatusBadRequest, "invalid", "Objects are uploaded to the bucket as media")
+		return
+	}
+
+	fileID, _ := strconv.Atoi(strings.TrimPrefix(r.URL.Query().Get("name"), "files/"))
+	file, ok := m.files[fileID]
+
+	if !ok || r.Header.Get("Authorization") != fmt.Sprintf("Bearer token-%d", fileID) {
+		writeMockError(w, http.StatusUnauthorized, "authError", "Invalid access token")
+		return
+	}
+
+	content, _ := ioutil.ReadAll(r.Body)
+	file.Content = string(content)
+
+	writeMockJSON(w, http.StatusOK, map[string]string{"bucket": "kbc-files", "name": r.URL.Query().Get("name")})
+}
+
+func (m *mockKeboolaAPI) getFile(w http.ResponseWriter, r *http.Request, params []string) {
+	if file := m.findFile(w, params[1]); file != nil {
+		writeMockJSON(w, http.StatusOK, file)
+	}
+}
+
+func (m *mockKeboolaAPI) deleteFile(w http.ResponseWriter, r *http.Request, params []string) {
+	if file := m.findFile(w, params[1]); file != nil {
+		delete(m.files, file.ID)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (m *mockKeboolaAPI) addFileTag(w http.ResponseWriter, r *http.Request, params []string) {
+	if file := m.findFile(w, params[1]); file != nil {
+		r.ParseForm()
+
+		if tag := r.Form.Get("tag"); !containsString(file.Tags, tag) {
+			file.Tags = append(file.Tags, tag)
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (m *mockKeboolaAPI) deleteFileTag(w http.ResponseWriter, r *http.Request, params []string) {
+	if file := m.findFile(w, params[1]); file != nil {
+		tags := []string{}
+
+		for _, tag := range file.Tags {
+			if tag != params[2] {
+				tags = append(tags, tag)
+			}
+		}
+
+		file.Tags = tags
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (m *mockKeboolaAPI) findWorkspace(w http.ResponseWriter, workspaceID string) *mockWorkspace {
+	workspace, ok := m.workspaces[workspaceID]
+
+	if !ok {
+		writeMockError(w, http.StatusNotFound, "storage.workspace.notFound", fmt.Sprintf("Workspace %s not found", workspaceID))
+		return nil
+	}
+
+	return workspace
+}
+
+func (m *mockKeboolaAPI) createWorkspace(w http.ResponseWriter, r *http.Request, params []string) {
+	var request struct {
+		Backend string `json:"backend"`
+	}
+
+	if r.Header.Get("Content-Type") != "application/json" || json.NewDecoder(r.Body).Decode(&request) != nil {
+		writeMockError(w, http.StatusBadRequest, "storage.validation", "Invalid JSON workspace")
+		return
+	}
+
+	workspace := &mockWorkspace{
+		ID:       m.nextID(),
+		tables:   make(map[string]string),
+		password: fmt.Sprintf("secret-%d", m.nextID()),
+	}
+
+	workspace.Connection.Backend = request.Backend
+	workspace.Connection.Database = "KEBOOLA_1234"
+	workspace.Connection.Schema = fmt.Sprintf("WORKSPACE_%d", workspace.ID)
+	workspace.Connection.User = fmt.Sprintf("KEBOOLA_WORKSPACE_%d", workspace.ID)
+
+	switch request.Backend {
+	case "snowflake":
+		workspace.Connection.Host = "mock.snowflakecomputing.com"
+		workspace.Connection.Warehouse = "KEBOOLA_PROD"
+	case "redshift":
+		workspace.Connection.Host = "mock.redshift.amazonaws.com"
+	default:
+		writeMockError(w, http.StatusBadRequest, "storage.workspaces.invalidBackend", fmt.Sprintf("Backend %q is not supported", request.Backend))
+		return
+	}
+
+	m.workspaces[strconv.Itoa(workspace.ID)] = workspace
+
+	writeMockJSON(w, http.StatusCreated, map[string]interface{}{
+		"id": workspace.ID,
+		"connection": map[string]interface{}{
+			"backend":   workspace.Connection.Backend,
+			"host":      workspace.Connection.Host,
+			"database":  workspace.Connection.Database,
+			"schema":    workspace.Connection.Schema,
+			"warehouse": workspace.Connection.Warehouse,
+			"user":      workspace.Connection.User,
+			"password":  workspace.password,
+		},
+	})
+}
+
+func (m *mockKeboolaAPI) getWorkspace(w http.ResponseWriter, r *http.Request, params []string) {
+	if workspace := m.findWorkspace(w, params[1]); workspace != nil {
+		writeMockJSON(w, http.StatusOK, workspace)
+	}
+}
+
+func (m *mockKeboolaAPI) deleteWorkspace(w http.ResponseWriter, r *http.Request, params []string) {
+	if workspace := m.findWorkspace(w, params[1]); workspace != nil {
+		delete(m.workspaces, params[1])
+
+		if parseMockBool(r.URL.Query().Get("async")) {
+			m.startStorageJob(w, "workspaceDelete", nil, nil)
+		} else {
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}
+}
+
+func (m *mockKeboolaAPI) resetWorkspacePassword(w http.ResponseWriter, r *http.Request, params []string) {
+	if workspace := m.findWorkspace(w, params[1]); workspace != nil {
+		workspace.password = fmt.Sprintf("secret-%d", m.nextID())
+		writeMockJSON(w, http.StatusOK, map[string]string{"password": workspace.password})
+	}
+}
+
+//loadWorkspace loads tables into a workspace, recording which table each destination was loaded from.
+func (m *mockKeboolaAPI) loadWorkspace(w http.ResponseWriter, r *http.Request, params []string) {
+	workspace := m.findWorkspace(w, params[1])
+
+	if workspace == nil {
+		return
+	}
+
+	var request struct {
+		Input []struct {
+			Source      string `json:"source"`
+			Destination string `json:"destination"`
+		} `json:"input"`
+		Preserve bool `json:"preserve"`
+	}
+
+	if r.Header.Get("Content-Type") != "application/json" || json.NewDecoder(r.Body).Decode(&request) != nil {
+		writeMockError(w, http.StatusBadRequest, "storage.validation", "Invalid JSON workspace load")
+		return
+	}
+
+	tables := make(map[string]string)
+	if request.Preserve {
+		tables = workspace.tables
+	}
+
+	for _, input := range request.Input {
+		if _, ok := m.tables[input.Source]; !ok {
+			m.startStorageJob(w, "workspaceLoad", nil, fmt.Errorf("Table %s not found", input.Source))
+			return
+		}
+
+		tables[input.Destination] = input.Source
+	}
+
+	workspace.tables = tables
+
+	m.startStorageJob(w, "workspaceLoad", nil, nil)
+}
+
+func containsString(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+//endregion
+
+//region File Import API
+
+func (m *mockKeboolaAPI) uploadFile(w http.ResponseWriter, r *http.Request, params []string) {
+	if err := r.ParseMultipartForm(1 << 20); err != nil {
+		writeMockError(w, http.StatusBadRequest, "invalidUpload", err.Error())
+		return
+	}
+
+	file := &mockFile{
+		ID:      m.nextID(),
+		Name:    r.FormValue("name"),
+		Content: r.FormValue("data"),
+	}
+
+	if data, _, err := r.FormFile("data"); err == nil {
+		content, _ := ioutil.ReadAll(data)
+		file.Content = string(content)
+	}
+
+	m.files[file.ID] = file
+
+	writeMockJSON(w, http.StatusCreated, file)
+}
+
+//endregion
+
+//region Encryption API
+
+//encrypt "encrypts" a plain text value by encoding it along with its scope, so that tests can
+//check which project and component a value was encrypted for.
+func (m *mockKeboolaAPI) encrypt(w http.ResponseWriter, r *http.Request, params []string) {
+	componentID := r.URL.Query().Get("componentId")
+	projectID := r.URL.Query().Get("projectId")
+
+	if componentID == "" || projectID == "" {
+		writeMockError(w, http.StatusBadRequest, "encryption.missingScope", "componentId and projectId are required")
+		return
+	}
+
+	plaintext, _ := ioutil.ReadAll(r.Body)
+
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintf(w, "KBC::ProjectSecure::%s", base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%s/%s/%s", projectID, componentID, plaintext))))
+}
+
+//endregion
+
+//region Syrup API
+
+//startSyrupJob records a Syrup job, which has already finished by the time it is first polled.
+func (m *mockKeboolaAPI) startSyrupJob(w http.ResponseWriter) {
+	job := &mockSyrupJob{ID: m.nextID(), Status: "success"}
+	job.URL = fmt.Sprintf("%s/queue/job/%d", m.URL, job.ID)
+
+	m.syrupJobs[job.ID] = job
+
+	writeMockJSON(w, http.StatusAccepted, map[string]interface{}{
+		"id":     job.ID,
+		"url":    job.URL,
+		"status": "waiting",
+	})
+}
+
+func (m *mockKeboolaAPI) getSyrupJob(w http.ResponseWriter, r *http.Request, params []string) {
+	jobID, _ := strconv.Atoi(params[1])
+	job, ok := m.syrupJobs[jobID]
+
+	if !ok {
+		writeMockError(w, http.StatusNotFound, "notFound", fmt.Sprintf("Job %d not found", jobID))
+		return
+	}
+
+	writeMockJSON(w, http.StatusOK, job)
+}
+
+func decodeMockJSON(w http.ResponseWriter, r *http.Request, value interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(value); err != nil {
+		writeMockError(w, http.StatusBadRequest, "invalidJSON", err.Error())
+		return false
+	}
+
+	return true
+}
+
+func (m *mockKeboolaAPI) provisionSnowflake(w http.ResponseWriter, r *http.Request, params []string) {
+	id := m.nextID()
+
+	writeMockJSON(w, http.StatusCreated, map[string]interface{}{
+		"status": "ok",
+		"credentials": map[string]interface{}{
+			"id":        strconv.Itoa(id),
+			"hostname":  "mock.snowflakecomputing.com",
+			"port":      443,
+			"db":        fmt.Sprintf("SAPI_%d", id),
+			"schema":    fmt.Sprintf("WORKSPACE_%d", id),
+			"warehouse": "MOCK_WAREHOUSE",
+			"user":      fmt.Sprintf("SAPI_WORKSPACE_%d", id),
+			"password":  "mock-password",
+		},
+	})
+}
+
+func (m *mockKeboolaAPI) createGoodDataWriter(w http.ResponseWriter, r *http.Request, params []string) {
+	var request struct {
+		WriterID    string `json:"writerId"`
+		Description string `json:"description"`
+	}
+
+	if !decodeMockJSON(w, r, &request) {
+		return
+	}
+
+	if _, ok := m.components["gooddata-writer"]; !ok {
+		m.components["gooddata-writer"] = make(map[string]*mockConfiguration)
+	}
+
+	if _, ok := m.components["gooddata-writer"][request.WriterID]; ok {
+		writeMockError(w, http.StatusBadRequest, "writerAlreadyExists", fmt.Sprintf("Writer %s already exists", request.WriterID))
+		return
+	}
+
+	m.components["gooddata-writer"][request.WriterID] = &mockConfiguration{
+		ID:            request.WriterID,
+		Name:          request.WriterID,
+		Description:   request.Description,
+		Version:       1,
+		Configuration: map[string]interface{}{},
+	}
+
+	m.startSyrupJob(w)
+}
+
+func (m *mockKeboolaAPI) deleteGoodDataWriter(w http.ResponseWriter, r *http.Request, params []string) {
+	if configuration := m.findConfiguration(w, "gooddata-writer", params[1]); configuration != nil {
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (m *mockKeboolaAPI) createGoodDataTable(w http.ResponseWriter, r *http.Request, params []string) {
+	if configuration := m.findConfiguration(w, "gooddata-writer", params[1]); configuration == nil {
+		return
+	}
+
+	table := map[string]interface{}{}
+
+	if !decodeMockJSON(w, r, &table) {
+		return
+	}
+
+	table["tableId"] = params[2]
+	m.goodDataTables[params[1]+"/"+params[2]] = table
+
+	writeMockJSON(w, http.StatusCreated, table)
+}
+
+func (m *mockKeboolaAPI) findGoodDataTable(w http.ResponseWriter, writerID string, tableID string) map[string]interface{} {
+	table, ok := m.goodDataTables[writerID+"/"+tableID]
+
+	if !ok {
+		//Like the real GoodData Writer, report a missing table as a user error with a numeric code.
+		writeMockJSON(w, http.StatusBadRequest, map[string]interface{}{
+			"error":  fmt.Sprintf("Table %s not found in writer %s", tableID, writerID),
+			"code":   http.StatusBadRequest,
+			"status": "error",
+		})
+		return nil
+	}
+
+	return table
+}
+
+func (m *mockKeboolaAPI) getGoodDataTable(w http.ResponseWriter, r *http.Request, params []string) {
+	if _, ok := m.components["gooddata-writer"][params[1]]; !ok {
+		writeMockJSON(w, http.StatusBadRequest, map[string]interface{}{
+			"error":  fmt.Sprintf("Writer %s does not exist", params[1]),
+			"code":   http.StatusBadRequest,
+			"status": "error",
+		})
+		return
+	}
+
+	if table := m.findGoodDataTable(w, params[1], params[2]); table != nil {
+		writeMockJSON(w, http.StatusOK, table)
+	}
+}
+
+func (m *mockKeboolaAPI) updateGoodDataTable(w http.ResponseWriter, r *http.Request, params []string) {
+	table := m.findGoodDataTable(w, params[1], params[2])
+
+	if table == nil {
+		return
+	}
+
+	changes := map[string]interface{}{}
+
+	if !decodeMockJSON(w, r, &changes) {
+		return
+	}
+
+	for key, value := range changes {
+		table[key] = value
+	}
+
+	table["tableId"] = params[2]
+
+	writeMockJSON(w, http.StatusOK, table)
+}
+
+func (m *mockKeboolaAPI) deleteGoodDataTable(w http.ResponseWriter, r *http.Request, params []string) {
+	if table := m.findGoodDataTable(w, params[1], params[2]); table != nil {
+		delete(m.goodDataTables, params[1]+"/"+params[2])
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (m *mockKeboolaAPI) findOrchestration(w http.ResponseWriter, orchestrationID string) map[string]interface{} {
+	orchestration, ok := m.orchestrations[orchestrationID]
+
+	if !ok {
+		writeMockError(w, http.StatusNotFound, "orchestrationNotFound", fmt.Sprintf("Orchestration %s not found", orchestrationID))
+		return nil
+	}
+
+	return orchestration
+}
+
+func (m *mockKeboolaAPI) createOrchestration(w http.ResponseWriter, r *http.Request, params []string) {
+	orchestration := map[string]interface{}{}
+
+	if !decodeMockJSON(w, r, &orchestration) {
+		return
+	}
+
+	id := m.nextID()
+	token := m.createTokenWithDescription(fmt.Sprintf("Orchestrator %v", orchestration["name"]))
+
+	orchestration["id"] = id
+	orchestration["token"] = map[string]interface{}{"id": token.ID, "description": token.Description}
+
+	m.orchestrations[strconv.Itoa(id)] = orchestration
+
+	writeMockJSON(w, http.StatusCreated, orchestration)
+}
+
+func (m *mockKeboolaAPI) getOrchestration(w http.ResponseWriter, r *http.Request, params []string) {
+	if orchestration := m.findOrchestration(w, params[1]); orchestration != nil {
+		writeMockJSON(w, http.StatusOK, orchestration)
+	}
+}
+
+func (m *mockKeboolaAPI) updateOrchestration(w http.ResponseWriter, r *http.Request, params []string) {
+	orchestration := m.findOrchestration(w, params[1])
+
+	if orchestration == nil {
+		return
+	}
+
+	changes := map[string]interface{}{}
+
+	if !decodeMockJSON(w, r, &changes) {
+		return
+	}
+
+	for key, value := range changes {
+		if key != "id" && key != "token" {
+			orchestration[key] = value
+		}
+	}
+
+	writeMockJSON(w, http.StatusOK, orchestration)
+}
+
+func (m *mockKeboolaAPI) deleteOrchestration(w http.ResponseWriter, r *http.Request, params []string) {
+	if orchestration := m.findOrchestration(w, params[1]); orchestration != nil {
+		delete(m.orchestrations, params[1])
+		delete(m.orchestrationTasks, params[1])
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (m *mockKeboolaAPI) getOrchestrationTasks(w http.ResponseWriter, r *http.Request, params []string) {
+	if orchestration := m.findOrchestration(w, params[1]); orchestration == nil {
+		return
+	}
+
+	tasks := m.orchestrationTasks[params[1]]
+	if tasks == nil {
+		tasks = []map[string]interface{}{}
+	}
+
+	writeMockJSON(w, http.StatusOK, tasks)
+}
+
+func (m *mockKeboolaAPI) updateOrchestrationTasks(w http.ResponseWriter, r *http.Request, params []string) {
+	if orchestration := m.findOrchestration(w, params[1]); orchestration == nil {
+		return
+	}
+
+	tasks := []map[string]interface{}{}
+
+	if !decodeMockJSON(w, r, &tasks) {
+		return
+	}
+
+	for _, task := range tasks {
+		if id, ok := task["id"].(float64); !ok || id == 0 {
+			task["id"] = m.nextID()
+		}
+	}
+
+	m.orchestrationTasks[params[1]] = tasks
+
+	writeMockJSON(w, http.StatusOK, tasks)
+}
+
+//endregion
+
+//region Test helpers
+
+//providers returns a new, unconfigured, instance of the provider for each test case.
+func (m *mockKeboolaAPI) providers() map[string]terraform.ResourceProvider {
+	return map[string]terraform.ResourceProvider{
+		"keboola": Provider(),
+	}
+}
+
+//config returns the given resources configuration, with the provider pointed at the mock API.
+func (m *mockKeboolaAPI) config(resources string) string {
+	return fmt.Sprintf(`
+provider "keboola" {
+	api_key = "%s"
+	host    = "%s"
+}
+%s`, mockAPIKey, m.URL, resources)
+}
+
+//configInBranch returns the given resources configuration, with the provider pointed at a development branch of the mock API.
+func (m *mockKeboolaAPI) configInBranch(branch *mockBranch, resources string) string {
+	return fmt.Sprintf(`
+provider "keboola" {
+	api_key   = "%s"
+	host      = "%s"
+	branch_id = "%d"
+}
+%s`, mockAPIKey, m.URL, branch.ID, resources)
+}
+
+//exists checks whether the mock API has an object at the given path.
+func (m *mockKeboolaAPI) exists(path string) bool {
+	request := httptest.NewRequest("GET", path, nil)
+	request.Header.Set("X-StorageApi-Token", mockAPIKey)
+
+	recorder := httptest.NewRecorder()
+	m.ServeHTTP(recorder, request)
+
+	return recorder.Code == http.StatusOK
+}
+
+//resourcePath builds the path of a resource's object in the mock API from pathFormat,
+//filled in with the given attributes of the resource ("id" being the resource's ID).
+func resourcePath(rs *terraform.ResourceState, pathFormat string, attributes []string) string {
+	values := make([]interface{}, 0, len(attributes))
+
+	for _, attribute := range attributes {
+		if attribute == "id" {
+			values = append(values, rs.Primary.ID)
+		} else {
+			values = append(values, rs.Primary.Attributes[attribute])
+		}
+	}
+
+	return fmt.Sprintf(pathFormat, values...)
+}
+
+//testCheckExists checks that the object behind the named resource exists in the mock API.
+func (m *mockKeboolaAPI) testCheckExists(name string, pathFormat string, attributes ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Record ID is set")
+		}
+
+		if path := resourcePath(rs, pathFormat, attributes); !m.exists(path) {
+			return fmt.Errorf("%s does not exist in the mock Keboola API at %s", name, path)
+		}
+
+		return nil
+	}
+}
+
+//testCheckDestroy checks that the objects behind every resource of the given type have been removed from the mock API.
+func (m *mockKeboolaAPI) testCheckDestroy(resourceType string, pathFormat string, attributes ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != resourceType {
+				continue
+			}
+
+			if path := resourcePath(rs, pathFormat, attributes); m.exists(path) {
+				return fmt.Errorf("%s %s still exists in the mock Keboola API at %s", resourceType, rs.Primary.ID, path)
+			}
+		}
+
+		return nil
+	}
+}
+
+//testCheckImportedAttribute checks that an attribute was read back when importing a resource, which
+//ImportStateVerify cannot tell apart from the attribute not being read back by Create either.
+func testCheckImportedAttribute(key string, expected string) resource.ImportStateCheckFunc {
+	return func(states []*terraform.InstanceState) error {
+		if len(states) != 1 {
+			return fmt.Errorf("Expected 1 imported resource, got %d", len(states))
+		}
+
+		if value := states[0].Attributes[key]; value != expected {
+			return fmt.Errorf("Expected imported %s to be %q, got %q", key, expected, value)
+		}
+
+		return nil
+	}
+}
+
+//testCheckMetadata checks the metadata entries of an object of the mock API, given as
+//provider/key = value, where metadata returns the entries of the object (or nil if it does not exist).
+func (m *mockKeboolaAPI) testCheckMetadata(metadata func() []*mockMetadata, expected map[string]string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		m.mutex.Lock()
+		defer m.mutex.Unlock()
+
+		actual := make(map[string]string)
+		for _, entry := range metadata() {
+			actual[entry.Provider+"/"+entry.Key] = entry.Value
+		}
+
+		if !reflect.DeepEqual(actual, expected) {
+			return fmt.Errorf("Expected metadata %v in the mock Keboola API, got %v", expected, actual)
+		}
+
+		return nil
+	}
+}
+
+//endregion
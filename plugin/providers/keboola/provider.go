@@ -3,54 +3,153 @@ package keboola
 import (
 	"log"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
 	"github.com/hashicorp/terraform/terraform"
 )
 
 // Provider returns a terraform.ResourceProvider for the Keboola provider.
 func Provider() terraform.ResourceProvider {
-	return &schema.Provider{
+	provider := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"api_key": {
 				Type:        schema.TypeString,
 				Required:    true,
 				DefaultFunc: schema.EnvDefaultFunc("STORAGE_API_KEY", nil),
 			},
+			"stack": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("KBC_STACK", defaultStack),
+				ValidateFunc: validateKBCStack,
+				Description:  "The multi-tenant Keboola stack hosting the project (e.g. us-east-1, eu-central-1, north-europe-azure).",
+			},
+			"host": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KBC_HOST", ""),
+				Description: "The Keboola Connection host (e.g. connection.keboola.com), used for single-tenant stacks. Takes precedence over stack.",
+			},
+			"branch_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KBC_BRANCH_ID", ""),
+				Description: "The ID of the development branch in which component configurations are managed, unless a resource sets its own branch_id. Defaults to the default (production) branch.",
+			},
+			"metadata_provider": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KBC_METADATA_PROVIDER", defaultMetadataProvider),
+				Description: "The provider name under which bucket, table and column metadata are managed. Metadata set by other providers is left untouched.",
+			},
+			"max_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      defaultMaxRetries,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "The maximum number of times a request to the Keboola APIs is retried after a transient failure.",
+			},
+			"max_retry_wait": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      int(defaultMaxRetryWait / time.Second),
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "The maximum number of seconds to wait between retries.",
+			},
+			"request_timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      int(defaultRequestTimeout / time.Second),
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "The maximum number of seconds each attempt at a request to the Keboola APIs may take, including reading the response. A request which times out is retried (unless it is a POST), so it may take up to max_retries + 1 times as long in total.",
+			},
+		},
+
+		DataSourcesMap: map[string]*schema.Resource{
+			"keboola_storage_bucket":  dataSourceKeboolaStorageBucket(),
+			"keboola_storage_buckets": dataSourceKeboolaStorageBuckets(),
+			"keboola_storage_table":   dataSourceKeboolaStorageTable(),
+			"keboola_storage_tables":  dataSourceKeboolaStorageTables(),
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			"keboola_storage_table":               resourceKeboolaStorageTable(),
-			"keboola_storage_bucket":              resourceKeboolaStorageBucket(),
-			"keboola_transformation":              resourceKeboolaTransformation(),
-			"keboola_transformation_bucket":       resourceKeboolaTransformationBucket(),
-			"keboola_gooddata_writer":             resourceKeboolaGoodDataWriter(),
-			"keboola_gooddata_writer_v3":          resourceKeboolaGoodDataWriterV3(),
-			"keboola_gooddata_writer_table":       resourceKeboolaGoodDataTable(),
-			"keboola_gooddata_user_management":    resourceKeboolaGoodDataUserManagement(),
-			"keboola_gooddata_user_management_v2": resourceKeboolaGoodDataUserManagementV2(),
-			"keboola_snowflake_writer":            resourceKeboolaSnowflakeWriter(),
-			"keboola_snowflake_writer_tables":     resourceKeboolaSnowflakeWriterTables(),
-			"keboola_postgresql_writer":           resourceKeboolaPostgreSQLWriter(),
-			"keboola_postgresql_writer_tables":    resourceKeboolaPostgreSQLWriterTables(),
-			"keboola_access_token":                resourceKeboolaAccessToken(),
-			"keboola_orchestration":               resourceKeboolaOrchestration(),
-			"keboola_orchestration_tasks":         resourceKeboolaOrchestrationTasks(),
-			"keboola_csvimport_extractor":         resourceKeboolaCSVImportExtractor(),
-			"keboola_snowflake_extractor":         resourceKeboolaSnowflakeExtractor(),
-			"keboola_snowflake_extractor_tables":  resourceKeboolaSnowflakeExtractorTables(),
-			"keboola_ftp_extractor":               resourceKeboolaFTPExtractor(),
-			"keboola_ftp_extractor_file":          resourceKeboolaFTPExtractorFile(),
+			"keboola_storage_table":                           resourceKeboolaStorageTable(),
+			"keboola_storage_file":                            resourceKeboolaStorageFile(),
+			"keboola_storage_table_alias":                     resourceKeboolaStorageTableAlias(),
+			"keboola_storage_table_snapshot":                  resourceKeboolaStorageTableSnapshot(),
+			"keboola_storage_bucket":                          resourceKeboolaStorageBucket(),
+			"keboola_storage_bucket_share":                    resourceKeboolaStorageBucketShare(),
+			"keboola_transformation":                          resourceKeboolaTransformation(),
+			"keboola_transformation_bucket":                   resourceKeboolaTransformationBucket(),
+			"keboola_gooddata_writer":                         resourceKeboolaGoodDataWriter(),
+			"keboola_gooddata_writer_v3":                      resourceKeboolaGoodDataWriterV3(),
+			"keboola_gooddata_writer_table":                   resourceKeboolaGoodDataTable(),
+			"keboola_gooddata_user_management":                resourceKeboolaGoodDataUserManagement(),
+			"keboola_gooddata_user_management_v2":             resourceKeboolaGoodDataUserManagementV2(),
+			"keboola_snowflake_writer":                        resourceKeboolaSnowflakeWriter(),
+			"keboola_snowflake_writer_tables":                 resourceKeboolaSnowflakeWriterTables(),
+			"keboola_postgresql_writer":                       resourceKeboolaPostgreSQLWriter(),
+			"keboola_postgresql_writer_tables":                resourceKeboolaPostgreSQLWriterTables(),
+			"keboola_access_token":                            resourceKeboolaAccessToken(),
+			"keboola_orchestration":                           resourceKeboolaOrchestration(),
+			"keboola_orchestration_tasks":                     resourceKeboolaOrchestrationTasks(),
+			"keboola_csvimport_extractor":                     resourceKeboolaCSVImportExtractor(),
+			"keboola_snowflake_extractor":                     resourceKeboolaSnowflakeExtractor(),
+			"keboola_snowflake_extractor_tables":              resourceKeboolaSnowflakeExtractorTables(),
+			"keboola_ftp_extractor":                           resourceKeboolaFTPExtractor(),
+			"keboola_ftp_extractor_file":                      resourceKeboolaFTPExtractorFile(),
+			"keboola_component_configuration":                 resourceKeboolaComponentConfiguration(),
+			"keboola_component_configuration_row":             resourceKeboolaComponentConfigurationRow(),
+			"keboola_component_configuration_rows_sort_order": resourceKeboolaComponentConfigurationRowsSortOrder(),
+			"keboola_encrypted_value":                         resourceKeboolaEncryptedValue(),
+			"keboola_dev_branch":                              resourceKeboolaDevBranch(),
+			"keboola_workspace":                               resourceKeboolaWorkspace(),
 		},
+	}
 
-		ConfigureFunc: providerConfigure,
+	provider.ConfigureFunc = func(d *schema.ResourceData) (interface{}, error) {
+		client, err := providerConfigure(d)
+
+		if err != nil {
+			return nil, err
+		}
+
+		client.StopContext = provider.StopContext()
+
+		return client, nil
 	}
+
+	return provider
 }
 
-func providerConfigure(d *schema.ResourceData) (interface{}, error) {
+func providerConfigure(d *schema.ResourceData) (*KBCClient, error) {
 	log.Println("[INFO] Initializing Keboola REST client")
+
+	storageURL, err := storageURLFor(d.Get("stack").(string), strings.TrimSpace(d.Get("host").(string)))
+
+	if err != nil {
+		return nil, err
+	}
+
 	client := &KBCClient{
-		APIKey: strings.TrimSpace(d.Get("api_key").(string)),
+		APIKey:           strings.TrimSpace(d.Get("api_key").(string)),
+		StorageURL:       storageURL,
+		BranchID:         strings.TrimSpace(d.Get("branch_id").(string)),
+		MetadataProvider: strings.TrimSpace(d.Get("metadata_provider").(string)),
+		MaxRetries:       d.Get("max_retries").(int),
+		MaxRetryWait:     time.Duration(d.Get("max_retry_wait").(int)) * time.Second,
+		RequestTimeout:   time.Duration(d.Get("request_timeout").(int)) * time.Second,
+	}
+
+	err = client.verifyToken()
+
+	if err != nil {
+		return nil, err
 	}
+
+	log.Printf("[INFO] Using Storage API token %s for Keboola project %s (%d)", client.Token.Description, client.ProjectName(), client.ProjectID())
+
 	return client, nil
 }
@@ -2,7 +2,10 @@ package keboola
 
 import (
 	"log"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform/config"
@@ -26,10 +29,26 @@ func TestProvider(t *testing.T) {
 	}
 }
 
+func newTokenVerificationServer(validToken string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/storage/tokens/verify" || r.Header.Get("X-StorageApi-Token") != validToken {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"error":"Invalid access token","code":"storage.tokenInvalid","status":"error"}`))
+			return
+		}
+
+		w.Write([]byte(`{"id":"1234","description":"terraform","isMasterToken":true,"canManageBuckets":true,"canManageTokens":true,"owner":{"id":567,"name":"Test Project","defaultBackend":"snowflake"}}`))
+	}))
+}
+
 func TestProvider_ApiKey(t *testing.T) {
+	server := newTokenVerificationServer("abcdefg")
+	defer server.Close()
+
 	provider := Provider().(*schema.Provider)
 	c, _ := config.NewRawConfig(map[string]interface{}{
 		"api_key": "abcdefg\n\n\n",
+		"host":    server.URL,
 	})
 
 	provider.Configure(terraform.NewResourceConfig(c))
@@ -53,6 +72,109 @@ func TestProvider_ApiKey(t *testing.T) {
 	}
 }
 
+func TestProvider_VerifiesToken(t *testing.T) {
+	server := newTokenVerificationServer("abcdefg")
+	defer server.Close()
+
+	provider := Provider().(*schema.Provider)
+	c, _ := config.NewRawConfig(map[string]interface{}{
+		"api_key": "abcdefg",
+		"host":    server.URL,
+	})
+
+	err := provider.Configure(terraform.NewResourceConfig(c))
+
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	client := provider.Meta().(*KBCClient)
+
+	if client.ProjectID() != 567 || client.ProjectName() != "Test Project" || !client.Token.CanManageBuckets {
+		t.Fatalf("token details were not kept on the client, got %+v", client.Token)
+	}
+}
+
+func TestProvider_RetrySettings(t *testing.T) {
+	testCases := []struct {
+		setting string
+		value   int
+		valid   bool
+	}{
+		{"max_retries", -1, false},
+		{"max_retries", 0, true},
+		{"max_retry_wait", -1, false},
+		{"max_retry_wait", 0, true},
+		{"request_timeout", -1, false},
+		{"request_timeout", 0, false},
+		{"request_timeout", 1, true},
+	}
+
+	for _, testCase := range testCases {
+		c, _ := config.NewRawConfig(map[string]interface{}{
+			"api_key":        "abcdefg",
+			testCase.setting: testCase.value,
+		})
+
+		_, errors := Provider().(*schema.Provider).Validate(terraform.NewResourceConfig(c))
+
+		if testCase.valid && len(errors) > 0 {
+			t.Fatalf("expected %s = %d to be valid, got: %v", testCase.setting, testCase.value, errors)
+		}
+
+		if !testCase.valid && (len(errors) == 0 || !strings.Contains(errors[0].Error(), testCase.setting)) {
+			t.Fatalf("expected a validation error for %s = %d, got: %v", testCase.setting, testCase.value, errors)
+		}
+	}
+}
+
+func TestProvider_InvalidToken(t *testing.T) {
+	server := newTokenVerificationServer("abcdefg")
+	defer server.Close()
+
+	provider := Provider().(*schema.Provider)
+	c, _ := config.NewRawConfig(map[string]interface{}{
+		"api_key": "wrong",
+		"host":    server.URL,
+	})
+
+	err := provider.Configure(terraform.NewResourceConfig(c))
+
+	if err == nil || !strings.Contains(err.Error(), "is not valid") || !strings.Contains(err.Error(), "Invalid access token") {
+		t.Fatalf("expected an invalid token error, got: %v", err)
+	}
+}
+
+func TestProvider_StackAndHost(t *testing.T) {
+	testCases := []struct {
+		stack    string
+		host     string
+		expected string
+	}{
+		{"", "", "https://connection.keboola.com/v2/"},
+		{"eu-central-1", "", "https://connection.eu-central-1.keboola.com/v2/"},
+		{"north-europe-azure", "", "https://connection.north-europe.azure.keboola.com/v2/"},
+		{"eu-central-1", "connection.acme.keboola.com", "https://connection.acme.keboola.com/v2/"},
+		{"", "http://localhost:8080/", "http://localhost:8080/v2/"},
+	}
+
+	for _, testCase := range testCases {
+		storageURL, err := storageURLFor(testCase.stack, testCase.host)
+
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+
+		if storageURL != testCase.expected {
+			t.Fatalf("expected storage URL %s for stack %q and host %q, got %s", testCase.expected, testCase.stack, testCase.host, storageURL)
+		}
+	}
+
+	if _, err := storageURLFor("mars-1", ""); err == nil {
+		t.Fatal("expected an error for an unknown stack")
+	}
+}
+
 func TestProvider_impl(t *testing.T) {
 	var _ terraform.ResourceProvider = Provider()
 }
@@ -138,12 +138,14 @@ func resourceKeboolaAccessTokenRead(d *schema.ResourceData, meta interface{}) er
 	}
 
 	if hasErrors(err, getAccessTokenResponse) {
-		if getAccessTokenResponse.StatusCode == 404 {
+		err = extractError(err, getAccessTokenResponse)
+
+		if isNotFoundError(err) {
 			d.SetId("")
 			return nil
 		}
 
-		return extractError(err, getAccessTokenResponse)
+		return err
 	}
 
 	var accessToken AccessToken
@@ -177,23 +179,23 @@ func resourceKeboolaAccessTokenUpdate(d *schema.ResourceData, meta interface{})
 
 	var updateAccessTokenQueryString bytes.Buffer
 
-	updateAccessTokenQueryString.WriteString(fmt.Sprintf("description=%s", d.Get("description").(string)))
-	updateAccessTokenQueryString.WriteString(fmt.Sprintf("canManageBuckets=%v", d.Get("can_manage_buckets").(bool)))
-	updateAccessTokenQueryString.WriteString(fmt.Sprintf("canManageTokens=%v", d.Get("can_manage_tokens").(bool)))
-	updateAccessTokenQueryString.WriteString(fmt.Sprintf("canReadAllFileUploads=%v", d.Get("can_read_all_file_uploads").(bool)))
-	updateAccessTokenQueryString.WriteString(fmt.Sprintf("expiresIn=%v", d.Get("expires_in").(int)))
+	updateAccessTokenQueryString.WriteString(fmt.Sprintf("description=%s", url.QueryEscape(d.Get("description").(string))))
+	updateAccessTokenQueryString.WriteString(fmt.Sprintf("&canManageBuckets=%s", url.QueryEscape(strconv.FormatBool(d.Get("can_manage_buckets").(bool)))))
+	updateAccessTokenQueryString.WriteString(fmt.Sprintf("&canManageTokens=%s", url.QueryEscape(strconv.FormatBool(d.Get("can_manage_tokens").(bool)))))
+	updateAccessTokenQueryString.WriteString(fmt.Sprintf("&canReadAllFileUploads=%s", url.QueryEscape(strconv.FormatBool(d.Get("can_read_all_file_uploads").(bool)))))
+	updateAccessTokenQueryString.WriteString(fmt.Sprintf("&expiresIn=%s", url.QueryEscape(strconv.Itoa(d.Get("expires_in").(int)))))
 
 	for key, value := range AsStringArray(d.Get("component_access").([]interface{})) {
-		updateAccessTokenQueryString.WriteString(fmt.Sprintf("componentAccess[%v]=%s", key, value))
+		updateAccessTokenQueryString.WriteString(fmt.Sprintf("&componentAccess%%5B%v%%5D=%s", key, url.QueryEscape(value)))
 	}
 
 	for key, value := range d.Get("bucket_permissions").(map[string]interface{}) {
-		updateAccessTokenQueryString.WriteString(fmt.Sprintf("bucketPermissions[%s]=%s", key, value))
+		updateAccessTokenQueryString.WriteString(fmt.Sprintf("&bucketPermissions%%5B%s%%5D=%s", url.QueryEscape(key), url.QueryEscape(value.(string))))
 	}
 
 	client := meta.(*KBCClient)
 
-	updateAccessTokenResponse, err := client.PutToStorage(fmt.Sprintf("storage/tokens/%s?%s", d.Id(), url.QueryEscape(updateAccessTokenQueryString.String())), buffer.Empty())
+	updateAccessTokenResponse, err := client.PutToStorage(fmt.Sprintf("storage/tokens/%s?%s", d.Id(), updateAccessTokenQueryString.String()), buffer.Empty())
 
 	if hasErrors(err, updateAccessTokenResponse) {
 		return extractError(err, updateAccessTokenResponse)
@@ -61,3 +61,96 @@ const testAccessTokenBasic = `
 		ignore_changes = [ "expires_in", "bucket_permissions" ]
     }
 	}`
+
+func TestUnitAccessToken_Basic(t *testing.T) {
+	mock := newMockKeboolaAPI()
+	defer mock.Close()
+
+	const tokenPath = "/v2/storage/tokens/%s"
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    mock.providers(),
+		CheckDestroy: mock.testCheckDestroy("keboola_access_token", tokenPath, "id"),
+		Steps: []resource.TestStep{
+			{
+				Config: mock.config(testAccessTokenUnit),
+				Check: resource.ComposeTestCheckFunc(
+					mock.testCheckExists("keboola_access_token.test_token", tokenPath, "id"),
+					resource.TestCheckResourceAttr("keboola_access_token.test_token", "description", "test description"),
+					resource.TestCheckResourceAttr("keboola_access_token.test_token", "can_manage_buckets", "true"),
+					resource.TestCheckResourceAttr("keboola_access_token.test_token", "expires_in", "3600"),
+					resource.TestCheckResourceAttr("keboola_access_token.test_token", "bucket_permissions.in.c-test", "read"),
+				),
+			},
+			{
+				Config: mock.config(testAccessTokenUnitUpdate),
+				Check: resource.ComposeTestCheckFunc(
+					mock.testCheckExists("keboola_access_token.test_token", tokenPath, "id"),
+					resource.TestCheckResourceAttr("keboola_access_token.test_token", "description", "updated test description"),
+					resource.TestCheckResourceAttr("keboola_access_token.test_token", "component_access.#", "1"),
+					resource.TestCheckResourceAttr("keboola_access_token.test_token", "component_access.0", "keboola.csv-import"),
+					resource.TestCheckResourceAttr("keboola_access_token.test_token", "bucket_permissions.in.c-test", "write"),
+				),
+			},
+			{
+				Config:            mock.config(testAccessTokenUnitUpdate),
+				ResourceName:      "keboola_access_token.test_token",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: mock.config(testAccessTokenUnitSpecialCharacters),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("keboola_access_token.test_token", "description", "reports & alerts = 100%"),
+					resource.TestCheckResourceAttr("keboola_access_token.test_token", "component_access.#", "2"),
+					resource.TestCheckResourceAttr("keboola_access_token.test_token", "bucket_permissions.out.c-reports", "read"),
+					func(s *terraform.State) error {
+						mock.mutex.Lock()
+						defer mock.mutex.Unlock()
+
+						token := mock.tokens[s.RootModule().Resources["keboola_access_token.test_token"].Primary.ID]
+
+						if token.Description != "reports & alerts = 100%" {
+							return fmt.Errorf("Expected the updated description to be sent encoded, got %q", token.Description)
+						}
+
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+const testAccessTokenUnit = `
+	resource "keboola_access_token" "test_token" {
+		description = "test description"
+		can_manage_buckets = true
+		expires_in = 3600
+		bucket_permissions = {
+			"in.c-test" = "read"
+		}
+	}`
+
+const testAccessTokenUnitUpdate = `
+	resource "keboola_access_token" "test_token" {
+		description = "updated test description"
+		can_manage_buckets = true
+		expires_in = 3600
+		component_access = [ "keboola.csv-import" ]
+		bucket_permissions = {
+			"in.c-test" = "write"
+		}
+	}`
+
+const testAccessTokenUnitSpecialCharacters = `
+	resource "keboola_access_token" "test_token" {
+		description = "reports & alerts = 100%"
+		can_manage_buckets = true
+		expires_in = 3600
+		component_access = [ "keboola.csv-import", "keboola.ex-db-snowflake" ]
+		bucket_permissions = {
+			"in.c-test" = "write"
+			"out.c-reports" = "read"
+		}
+	}`
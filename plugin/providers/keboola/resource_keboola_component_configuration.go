@@ -0,0 +1,276 @@
+package keboola
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/paybyphone/terraform-provider-keboola/plugin/providers/keboola/buffer"
+)
+
+//region Keboola API Contracts
+
+//ComponentConfiguration is the data model for a configuration of any
+//component within the Keboola Storage API.
+type ComponentConfiguration struct {
+	ID            string          `json:"id,omitempty"`
+	Name          string          `json:"name"`
+	Description   string          `json:"description"`
+	Configuration json.RawMessage `json:"configuration"`
+}
+
+//endregion
+
+func resourceKeboolaComponentConfiguration() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceKeboolaComponentConfigurationCreate,
+		Read:   resourceKeboolaComponentConfigurationRead,
+		Update: resourceKeboolaComponentConfigurationUpdate,
+		Delete: resourceKeboolaComponentConfigurationDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceKeboolaComponentConfigurationImport,
+		},
+		CustomizeDiff: customizeBranchIDDiff,
+
+		Schema: map[string]*schema.Schema{
+			"branch_id":               &branchIDSchema,
+			"branch_id_from_provider": &branchIDFromProviderSchema,
+			"component_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"configuration": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "{}",
+				ValidateFunc:     validateJSONObject,
+				DiffSuppressFunc: suppressSemanticallyEquivalentJSON,
+			},
+		},
+	}
+}
+
+//normalizeConfigurationJSON re-encodes a configuration returned by the Storage API, so that
+//equivalent configurations are always stored in the same form. The API returns an empty
+//configuration as an empty JSON array, which is stored as an empty object instead.
+func normalizeConfigurationJSON(configuration json.RawMessage) (string, error) {
+	if len(configuration) == 0 || string(configuration) == "null" || string(configuration) == "[]" {
+		return "{}", nil
+	}
+
+	var value interface{}
+
+	if err := json.Unmarshal(configuration, &value); err != nil {
+		return "", err
+	}
+
+	normalized, err := json.Marshal(value)
+
+	if err != nil {
+		return "", err
+	}
+
+	return string(normalized), nil
+}
+
+//encryptedValuePrefix is how the values encrypted by the Keboola Encryption API start.
+const encryptedValuePrefix = "KBC::"
+
+//keepPlaintextSecrets returns a configuration read back from the Storage API with the encrypted values of its
+//#-prefixed keys replaced by the plain text values they were set from in the previous configuration. The Storage
+//API encrypts these values when the configuration is saved, so they would otherwise never match the plain text.
+func keepPlaintextSecrets(configuration string, previousConfiguration string) string {
+	var value, previousValue interface{}
+
+	if json.Unmarshal([]byte(configuration), &value) != nil || json.Unmarshal([]byte(previousConfiguration), &previousValue) != nil {
+		return configuration
+	}
+
+	withSecrets, err := json.Marshal(withPlaintextSecrets(value, previousValue))
+
+	if err != nil {
+		return configuration
+	}
+
+	return string(withSecrets)
+}
+
+func withPlaintextSecrets(value interface{}, previousValue interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		previousObject, _ := previousValue.(map[string]interface{})
+
+		for key, nested := range value {
+			previousNested, ok := previousObject[key]
+
+			if !ok {
+				continue
+			}
+
+			encrypted, isString := nested.(string)
+			plaintext, wasString := previousNested.(string)
+
+			if strings.HasPrefix(key, "#") && isString && wasString && strings.HasPrefix(encrypted, encryptedValuePrefix) && !strings.HasPrefix(plaintext, encryptedValuePrefix) {
+				value[key] = plaintext
+			} else {
+				value[key] = withPlaintextSecrets(nested, previousNested)
+			}
+		}
+	case []interface{}:
+		previousArray, _ := previousValue.([]interface{})
+
+		for index, nested := range value {
+			if index < len(previousArray) {
+				value[index] = withPlaintextSecrets(nested, previousArray[index])
+			}
+		}
+	}
+
+	return value
+}
+
+func resourceKeboolaComponentConfigurationImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	idParts := strings.SplitN(d.Id(), "/", 2)
+
+	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
+		return nil, fmt.Errorf("unexpected format of ID (%s), expected component_id/config_id", d.Id())
+	}
+
+	d.Set("component_id", idParts[0])
+	d.SetId(idParts[1])
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceKeboolaComponentConfigurationCreate(d *schema.ResourceData, meta interface{}) error {
+	log.Println("[INFO] Creating Component Configuration in Keboola.")
+
+	componentID := d.Get("component_id").(string)
+
+	createConfigurationForm := url.Values{}
+	createConfigurationForm.Add("name", d.Get("name").(string))
+	createConfigurationForm.Add("description", d.Get("description").(string))
+	createConfigurationForm.Add("configuration", d.Get("configuration").(string))
+
+	createConfigurationBuffer := buffer.FromForm(createConfigurationForm)
+
+	client := meta.(*KBCClient)
+	createResponse, err := client.PostToStorage(componentsURI(d, client, "%s/configs", componentID), createConfigurationBuffer)
+
+	if hasErrors(err, createResponse) {
+		return extractError(err, createResponse)
+	}
+
+	var createResult CreateResourceResult
+
+	decoder := json.NewDecoder(createResponse.Body)
+	err = decoder.Decode(&createResult)
+
+	if err != nil {
+		return err
+	}
+
+	d.SetId(string(createResult.ID))
+
+	return resourceKeboolaComponentConfigurationRead(d, meta)
+}
+
+func resourceKeboolaComponentConfigurationRead(d *schema.ResourceData, meta interface{}) error {
+	log.Println("[INFO] Reading Component Configuration from Keboola.")
+
+	if d.Id() == "" {
+		return nil
+	}
+
+	componentID := d.Get("component_id").(string)
+
+	client := meta.(*KBCClient)
+	getResponse, err := client.GetFromStorage(componentsURI(d, client, "%s/configs/%s", componentID, d.Id()))
+
+	if hasErrors(err, getResponse) {
+		err = extractError(err, getResponse)
+
+		if isNotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+
+		return err
+	}
+
+	var componentConfiguration ComponentConfiguration
+
+	decoder := json.NewDecoder(getResponse.Body)
+	err = decoder.Decode(&componentConfiguration)
+
+	if err != nil {
+		return err
+	}
+
+	configuration, err := normalizeConfigurationJSON(componentConfiguration.Configuration)
+
+	if err != nil {
+		return err
+	}
+
+	d.Set("component_id", componentID)
+	d.Set("name", componentConfiguration.Name)
+	d.Set("description", componentConfiguration.Description)
+	d.Set("configuration", keepPlaintextSecrets(configuration, d.Get("configuration").(string)))
+
+	setBranchID(d, client)
+
+	return nil
+}
+
+func resourceKeboolaComponentConfigurationUpdate(d *schema.ResourceData, meta interface{}) error {
+	log.Println("[INFO] Updating Component Configuration in Keboola.")
+
+	componentID := d.Get("component_id").(string)
+
+	updateConfigurationForm := url.Values{}
+	updateConfigurationForm.Add("name", d.Get("name").(string))
+	updateConfigurationForm.Add("description", d.Get("description").(string))
+	updateConfigurationForm.Add("configuration", d.Get("configuration").(string))
+	updateConfigurationForm.Add("changeDescription", "Updated configuration via Terraform")
+
+	updateConfigurationBuffer := buffer.FromForm(updateConfigurationForm)
+
+	client := meta.(*KBCClient)
+	updateResponse, err := client.PutToStorage(componentsURI(d, client, "%s/configs/%s", componentID, d.Id()), updateConfigurationBuffer)
+
+	if hasErrors(err, updateResponse) {
+		return extractError(err, updateResponse)
+	}
+
+	return resourceKeboolaComponentConfigurationRead(d, meta)
+}
+
+func resourceKeboolaComponentConfigurationDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[INFO] Deleting Component Configuration in Keboola: %s", d.Id())
+
+	componentID := d.Get("component_id").(string)
+
+	client := meta.(*KBCClient)
+	destroyResponse, err := client.DeleteFromStorage(componentsURI(d, client, "%s/configs/%s", componentID, d.Id()))
+
+	if hasErrors(err, destroyResponse) {
+		return extractError(err, destroyResponse)
+	}
+
+	d.SetId("")
+
+	return nil
+}
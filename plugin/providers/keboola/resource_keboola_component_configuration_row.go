@@ -0,0 +1,218 @@
+package keboola
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/paybyphone/terraform-provider-keboola/plugin/providers/keboola/buffer"
+)
+
+//region Keboola API Contracts
+
+//ComponentConfigurationRow is the data model for a single row within
+//a configuration of any component within the Keboola Storage API.
+type ComponentConfigurationRow struct {
+	ID            string          `json:"id,omitempty"`
+	Name          string          `json:"name"`
+	Description   string          `json:"description"`
+	IsDisabled    bool            `json:"isDisabled"`
+	Configuration json.RawMessage `json:"configuration"`
+}
+
+//endregion
+
+func resourceKeboolaComponentConfigurationRow() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceKeboolaComponentConfigurationRowCreate,
+		Read:   resourceKeboolaComponentConfigurationRowRead,
+		Update: resourceKeboolaComponentConfigurationRowUpdate,
+		Delete: resourceKeboolaComponentConfigurationRowDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceKeboolaComponentConfigurationRowImport,
+		},
+		CustomizeDiff: customizeBranchIDDiff,
+
+		Schema: map[string]*schema.Schema{
+			"branch_id":               &branchIDSchema,
+			"branch_id_from_provider": &branchIDFromProviderSchema,
+			"component_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"configuration_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"is_disabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"configuration": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "{}",
+				ValidateFunc:     validateJSONObject,
+				DiffSuppressFunc: suppressSemanticallyEquivalentJSON,
+			},
+		},
+	}
+}
+
+func resourceKeboolaComponentConfigurationRowImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	idParts := strings.SplitN(d.Id(), "/", 3)
+
+	if len(idParts) != 3 || idParts[0] == "" || idParts[1] == "" || idParts[2] == "" {
+		return nil, fmt.Errorf("unexpected format of ID (%s), expected component_id/configuration_id/row_id", d.Id())
+	}
+
+	d.Set("component_id", idParts[0])
+	d.Set("configuration_id", idParts[1])
+	d.SetId(idParts[2])
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func componentConfigurationRowForm(d *schema.ResourceData) url.Values {
+	rowForm := url.Values{}
+	rowForm.Add("name", d.Get("name").(string))
+	rowForm.Add("description", d.Get("description").(string))
+	rowForm.Add("isDisabled", strconv.FormatBool(d.Get("is_disabled").(bool)))
+	rowForm.Add("configuration", d.Get("configuration").(string))
+
+	return rowForm
+}
+
+func resourceKeboolaComponentConfigurationRowCreate(d *schema.ResourceData, meta interface{}) error {
+	log.Println("[INFO] Creating Component Configuration Row in Keboola.")
+
+	componentID := d.Get("component_id").(string)
+	configurationID := d.Get("configuration_id").(string)
+
+	createRowBuffer := buffer.FromForm(componentConfigurationRowForm(d))
+
+	client := meta.(*KBCClient)
+	createResponse, err := client.PostToStorage(componentsURI(d, client, "%s/configs/%s/rows", componentID, configurationID), createRowBuffer)
+
+	if hasErrors(err, createResponse) {
+		return extractError(err, createResponse)
+	}
+
+	var createResult CreateResourceResult
+
+	decoder := json.NewDecoder(createResponse.Body)
+	err = decoder.Decode(&createResult)
+
+	if err != nil {
+		return err
+	}
+
+	d.SetId(string(createResult.ID))
+
+	return resourceKeboolaComponentConfigurationRowRead(d, meta)
+}
+
+func resourceKeboolaComponentConfigurationRowRead(d *schema.ResourceData, meta interface{}) error {
+	log.Println("[INFO] Reading Component Configuration Row from Keboola.")
+
+	if d.Id() == "" {
+		return nil
+	}
+
+	componentID := d.Get("component_id").(string)
+	configurationID := d.Get("configuration_id").(string)
+
+	client := meta.(*KBCClient)
+	getResponse, err := client.GetFromStorage(componentsURI(d, client, "%s/configs/%s/rows/%s", componentID, configurationID, d.Id()))
+
+	if hasErrors(err, getResponse) {
+		err = extractError(err, getResponse)
+
+		if isNotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+
+		return err
+	}
+
+	var row ComponentConfigurationRow
+
+	decoder := json.NewDecoder(getResponse.Body)
+	err = decoder.Decode(&row)
+
+	if err != nil {
+		return err
+	}
+
+	configuration, err := normalizeConfigurationJSON(row.Configuration)
+
+	if err != nil {
+		return err
+	}
+
+	d.Set("component_id", componentID)
+	d.Set("configuration_id", configurationID)
+	d.Set("name", row.Name)
+	d.Set("description", row.Description)
+	d.Set("is_disabled", row.IsDisabled)
+	d.Set("configuration", keepPlaintextSecrets(configuration, d.Get("configuration").(string)))
+
+	setBranchID(d, client)
+
+	return nil
+}
+
+func resourceKeboolaComponentConfigurationRowUpdate(d *schema.ResourceData, meta interface{}) error {
+	log.Println("[INFO] Updating Component Configuration Row in Keboola.")
+
+	componentID := d.Get("component_id").(string)
+	configurationID := d.Get("configuration_id").(string)
+
+	updateRowForm := componentConfigurationRowForm(d)
+	updateRowForm.Add("changeDescription", "Updated configuration row via Terraform")
+
+	updateRowBuffer := buffer.FromForm(updateRowForm)
+
+	client := meta.(*KBCClient)
+	updateResponse, err := client.PutToStorage(componentsURI(d, client, "%s/configs/%s/rows/%s", componentID, configurationID, d.Id()), updateRowBuffer)
+
+	if hasErrors(err, updateResponse) {
+		return extractError(err, updateResponse)
+	}
+
+	return resourceKeboolaComponentConfigurationRowRead(d, meta)
+}
+
+func resourceKeboolaComponentConfigurationRowDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[INFO] Deleting Component Configuration Row in Keboola: %s", d.Id())
+
+	componentID := d.Get("component_id").(string)
+	configurationID := d.Get("configuration_id").(string)
+
+	client := meta.(*KBCClient)
+	destroyResponse, err := client.DeleteFromStorage(componentsURI(d, client, "%s/configs/%s/rows/%s", componentID, configurationID, d.Id()))
+
+	if hasErrors(err, destroyResponse) {
+		return extractError(err, destroyResponse)
+	}
+
+	d.SetId("")
+
+	return nil
+}
@@ -0,0 +1,207 @@
+package keboola
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccComponentConfigurationRow_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckComponentConfigurationRowDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testComponentConfigurationRowBasic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("keboola_component_configuration_row.test_row", "name", "test row"),
+					resource.TestCheckResourceAttr("keboola_component_configuration_row.test_row", "is_disabled", "false"),
+					resource.TestCheckResourceAttr("keboola_component_configuration_rows_sort_order.test_order", "row_ids.#", "2"),
+				),
+			},
+			{
+				Config: testComponentConfigurationRowUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("keboola_component_configuration_row.test_row", "name", "new test row"),
+					resource.TestCheckResourceAttr("keboola_component_configuration_row.test_row", "is_disabled", "true"),
+					resource.TestCheckResourceAttrPair("keboola_component_configuration_rows_sort_order.test_order", "row_ids.0", "keboola_component_configuration_row.test_row", "id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckComponentConfigurationRowDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*KBCClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "keboola_component_configuration_row" {
+			continue
+		}
+
+		rowURI := fmt.Sprintf("storage/components/%s/configs/%s/rows/%s", rs.Primary.Attributes["component_id"], rs.Primary.Attributes["configuration_id"], rs.Primary.ID)
+		getResp, err := client.GetFromStorage(rowURI)
+
+		if err == nil && getResp.StatusCode == 200 {
+			return fmt.Errorf("Component configuration row still exists")
+		}
+	}
+
+	return nil
+}
+
+const testComponentConfigurationRowBasic = `
+resource "keboola_component_configuration" "test_config" {
+	component_id = "keboola.ex-db-snowflake"
+	name = "test config"
+}
+
+resource "keboola_component_configuration_row" "test_row" {
+	component_id = "${keboola_component_configuration.test_config.component_id}"
+	configuration_id = "${keboola_component_configuration.test_config.id}"
+	name = "test row"
+	description = "test description"
+	configuration = <<EOF
+{
+	"parameters": {
+		"table": { "schema": "PUBLIC", "tableName": "ORDERS" },
+		"incremental": false
+	}
+}
+EOF
+}
+
+resource "keboola_component_configuration_row" "other_row" {
+	component_id = "${keboola_component_configuration.test_config.component_id}"
+	configuration_id = "${keboola_component_configuration.test_config.id}"
+	name = "other row"
+}
+
+resource "keboola_component_configuration_rows_sort_order" "test_order" {
+	component_id = "${keboola_component_configuration.test_config.component_id}"
+	configuration_id = "${keboola_component_configuration.test_config.id}"
+	row_ids = [
+		"${keboola_component_configuration_row.other_row.id}",
+		"${keboola_component_configuration_row.test_row.id}",
+	]
+}`
+
+const testComponentConfigurationRowUpdate = `
+resource "keboola_component_configuration" "test_config" {
+	component_id = "keboola.ex-db-snowflake"
+	name = "test config"
+}
+
+resource "keboola_component_configuration_row" "test_row" {
+	component_id = "${keboola_component_configuration.test_config.component_id}"
+	configuration_id = "${keboola_component_configuration.test_config.id}"
+	name = "new test row"
+	description = "new test description"
+	is_disabled = true
+	configuration = <<EOF
+{
+	"parameters": {
+		"incremental": true,
+		"table": { "tableName": "ORDERS", "schema": "PUBLIC" }
+	}
+}
+EOF
+}
+
+resource "keboola_component_configuration_row" "other_row" {
+	component_id = "${keboola_component_configuration.test_config.component_id}"
+	configuration_id = "${keboola_component_configuration.test_config.id}"
+	name = "other row"
+}
+
+resource "keboola_component_configuration_rows_sort_order" "test_order" {
+	component_id = "${keboola_component_configuration.test_config.component_id}"
+	configuration_id = "${keboola_component_configuration.test_config.id}"
+	row_ids = [
+		"${keboola_component_configuration_row.test_row.id}",
+		"${keboola_component_configuration_row.other_row.id}",
+	]
+}`
+
+func TestUnitComponentConfigurationRow_Basic(t *testing.T) {
+	mock := newMockKeboolaAPI()
+	defer mock.Close()
+
+	const rowPath = "/v2/storage/components/%s/configs/%s/rows/%s"
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    mock.providers(),
+		CheckDestroy: mock.testCheckDestroy("keboola_component_configuration_row", rowPath, "component_id", "configuration_id", "id"),
+		Steps: []resource.TestStep{
+			{
+				Config: mock.config(testComponentConfigurationRowBasic),
+				Check: resource.ComposeTestCheckFunc(
+					mock.testCheckExists("keboola_component_configuration_row.test_row", rowPath, "component_id", "configuration_id", "id"),
+					mock.testCheckExists("keboola_component_configuration_row.other_row", rowPath, "component_id", "configuration_id", "id"),
+					resource.TestCheckResourceAttr("keboola_component_configuration_row.test_row", "name", "test row"),
+					resource.TestCheckResourceAttr("keboola_component_configuration_row.test_row", "description", "test description"),
+					resource.TestCheckResourceAttr("keboola_component_configuration_row.test_row", "is_disabled", "false"),
+					resource.TestCheckResourceAttr("keboola_component_configuration_row.test_row", "configuration", `{"parameters":{"incremental":false,"table":{"schema":"PUBLIC","tableName":"ORDERS"}}}`),
+					resource.TestCheckResourceAttr("keboola_component_configuration_row.other_row", "configuration", "{}"),
+					resource.TestCheckResourceAttr("keboola_component_configuration_rows_sort_order.test_order", "row_ids.#", "2"),
+					resource.TestCheckResourceAttrPair("keboola_component_configuration_rows_sort_order.test_order", "row_ids.0", "keboola_component_configuration_row.other_row", "id"),
+					resource.TestCheckResourceAttrPair("keboola_component_configuration_rows_sort_order.test_order", "row_ids.1", "keboola_component_configuration_row.test_row", "id"),
+				),
+			},
+			{
+				Config: mock.config(testComponentConfigurationRowUpdate),
+				Check: resource.ComposeTestCheckFunc(
+					mock.testCheckExists("keboola_component_configuration_row.test_row", rowPath, "component_id", "configuration_id", "id"),
+					resource.TestCheckResourceAttr("keboola_component_configuration_row.test_row", "name", "new test row"),
+					resource.TestCheckResourceAttr("keboola_component_configuration_row.test_row", "description", "new test description"),
+					resource.TestCheckResourceAttr("keboola_component_configuration_row.test_row", "is_disabled", "true"),
+					resource.TestCheckResourceAttr("keboola_component_configuration_row.test_row", "configuration", `{"parameters":{"incremental":true,"table":{"schema":"PUBLIC","tableName":"ORDERS"}}}`),
+					resource.TestCheckResourceAttrPair("keboola_component_configuration_rows_sort_order.test_order", "row_ids.0", "keboola_component_configuration_row.test_row", "id"),
+					resource.TestCheckResourceAttrPair("keboola_component_configuration_rows_sort_order.test_order", "row_ids.1", "keboola_component_configuration_row.other_row", "id"),
+				),
+			},
+			{
+				Config:            mock.config(testComponentConfigurationRowUpdate),
+				ResourceName:      "keboola_component_configuration_row.test_row",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs := s.RootModule().Resources["keboola_component_configuration_row.test_row"]
+					return fmt.Sprintf("%s/%s/%s", rs.Primary.Attributes["component_id"], rs.Primary.Attributes["configuration_id"], rs.Primary.ID), nil
+				},
+			},
+			{
+				Config:            mock.config(testComponentConfigurationRowUpdate),
+				ResourceName:      "keboola_component_configuration_rows_sort_order.test_order",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs := s.RootModule().Resources["keboola_component_configuration_rows_sort_order.test_order"]
+					return fmt.Sprintf("%s/%s", rs.Primary.Attributes["component_id"], rs.Primary.ID), nil
+				},
+			},
+		},
+	})
+}
+
+func TestUnitComponentConfigurationRow_InvalidImportID(t *testing.T) {
+	mock := newMockKeboolaAPI()
+	defer mock.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers: mock.providers(),
+		Steps: []resource.TestStep{
+			{
+				Config:        mock.config(testComponentConfigurationRowBasic),
+				ResourceName:  "keboola_component_configuration_row.test_row",
+				ImportState:   true,
+				ImportStateId: "keboola.ex-db-snowflake/1234",
+				ExpectError:   regexp.MustCompile("expected component_id/configuration_id/row_id"),
+			},
+		},
+	})
+}
@@ -0,0 +1,186 @@
+package keboola
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/paybyphone/terraform-provider-keboola/plugin/providers/keboola/buffer"
+)
+
+//region Keboola API Contracts
+
+//ComponentConfigurationRowsSortOrder is the part of a component configuration
+//that holds the order in which its rows are run.
+type ComponentConfigurationRowsSortOrder struct {
+	RowsSortOrder []string `json:"rowsSortOrder"`
+}
+
+//endregion
+
+func resourceKeboolaComponentConfigurationRowsSortOrder() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceKeboolaComponentConfigurationRowsSortOrderCreate,
+		Read:   resourceKeboolaComponentConfigurationRowsSortOrderRead,
+		Update: resourceKeboolaComponentConfigurationRowsSortOrderUpdate,
+		Delete: resourceKeboolaComponentConfigurationRowsSortOrderDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceKeboolaComponentConfigurationRowsSortOrderImport,
+		},
+		CustomizeDiff: customizeBranchIDDiff,
+
+		Schema: map[string]*schema.Schema{
+			"branch_id":               &branchIDSchema,
+			"branch_id_from_provider": &branchIDFromProviderSchema,
+			"component_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"configuration_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"row_ids": {
+				Type:     schema.TypeList,
+				Required: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func resourceKeboolaComponentConfigurationRowsSortOrderImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	idParts := strings.SplitN(d.Id(), "/", 2)
+
+	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
+		return nil, fmt.Errorf("unexpected format of ID (%s), expected component_id/configuration_id", d.Id())
+	}
+
+	d.Set("component_id", idParts[0])
+	d.Set("configuration_id", idParts[1])
+	d.SetId(idParts[1])
+
+	return []*schema.ResourceData{d}, nil
+}
+
+//updateComponentConfigurationRowsSortOrder replaces the rows sort order of a configuration.
+//An empty list of row IDs clears the sort order, so that rows run in the order they were created.
+func updateComponentConfigurationRowsSortOrder(d *schema.ResourceData, componentID string, configurationID string, rowIDs []string, client *KBCClient) error {
+	updateConfigurationForm := url.Values{}
+
+	if len(rowIDs) == 0 {
+		updateConfigurationForm.Add("rowsSortOrder", "")
+	}
+
+	for _, rowID := range rowIDs {
+		updateConfigurationForm.Add("rowsSortOrder[]", rowID)
+	}
+
+	updateConfigurationForm.Add("changeDescription", "Updated rows sort order via Terraform")
+
+	updateConfigurationBuffer := buffer.FromForm(updateConfigurationForm)
+
+	updateResponse, err := client.PutToStorage(componentsURI(d, client, "%s/configs/%s", componentID, configurationID), updateConfigurationBuffer)
+
+	if hasErrors(err, updateResponse) {
+		return extractError(err, updateResponse)
+	}
+
+	return nil
+}
+
+func resourceKeboolaComponentConfigurationRowsSortOrderCreate(d *schema.ResourceData, meta interface{}) error {
+	log.Println("[INFO] Setting Component Configuration Rows Sort Order in Keboola.")
+
+	componentID := d.Get("component_id").(string)
+	configurationID := d.Get("configuration_id").(string)
+
+	client := meta.(*KBCClient)
+	err := updateComponentConfigurationRowsSortOrder(d, componentID, configurationID, AsStringArray(d.Get("row_ids").([]interface{})), client)
+
+	if err != nil {
+		return err
+	}
+
+	d.SetId(configurationID)
+
+	return resourceKeboolaComponentConfigurationRowsSortOrderRead(d, meta)
+}
+
+func resourceKeboolaComponentConfigurationRowsSortOrderRead(d *schema.ResourceData, meta interface{}) error {
+	log.Println("[INFO] Reading Component Configuration Rows Sort Order from Keboola.")
+
+	if d.Id() == "" {
+		return nil
+	}
+
+	componentID := d.Get("component_id").(string)
+
+	client := meta.(*KBCClient)
+	getResponse, err := client.GetFromStorage(componentsURI(d, client, "%s/configs/%s", componentID, d.Id()))
+
+	if hasErrors(err, getResponse) {
+		err = extractError(err, getResponse)
+
+		if isNotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+
+		return err
+	}
+
+	var sortOrder ComponentConfigurationRowsSortOrder
+
+	decoder := json.NewDecoder(getResponse.Body)
+	err = decoder.Decode(&sortOrder)
+
+	if err != nil {
+		return err
+	}
+
+	d.Set("component_id", componentID)
+	d.Set("configuration_id", d.Id())
+	d.Set("row_ids", sortOrder.RowsSortOrder)
+
+	setBranchID(d, client)
+
+	return nil
+}
+
+func resourceKeboolaComponentConfigurationRowsSortOrderUpdate(d *schema.ResourceData, meta interface{}) error {
+	log.Println("[INFO] Updating Component Configuration Rows Sort Order in Keboola.")
+
+	componentID := d.Get("component_id").(string)
+
+	client := meta.(*KBCClient)
+	err := updateComponentConfigurationRowsSortOrder(d, componentID, d.Id(), AsStringArray(d.Get("row_ids").([]interface{})), client)
+
+	if err != nil {
+		return err
+	}
+
+	return resourceKeboolaComponentConfigurationRowsSortOrderRead(d, meta)
+}
+
+func resourceKeboolaComponentConfigurationRowsSortOrderDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[INFO] Clearing Component Configuration Rows Sort Order in Keboola: %s", d.Id())
+
+	componentID := d.Get("component_id").(string)
+
+	client := meta.(*KBCClient)
+	err := updateComponentConfigurationRowsSortOrder(d, componentID, d.Id(), nil, client)
+
+	if err != nil && !isNotFoundError(err) {
+		return err
+	}
+
+	d.SetId("")
+
+	return nil
+}
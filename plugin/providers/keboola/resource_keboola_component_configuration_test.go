@@ -0,0 +1,221 @@
+package keboola
+
+import (
+	"encoding/base64"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccComponentConfiguration_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckComponentConfigurationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testComponentConfigurationBasic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("keboola_component_configuration.test_config", "component_id", "keboola.ex-http"),
+					resource.TestCheckResourceAttr("keboola_component_configuration.test_config", "name", "test name"),
+					resource.TestCheckResourceAttr("keboola_component_configuration.test_config", "description", "test description"),
+				),
+			},
+			{
+				Config: testComponentConfigurationUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("keboola_component_configuration.test_config", "name", "new test name"),
+					resource.TestCheckResourceAttr("keboola_component_configuration.test_config", "description", "new test description"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckComponentConfigurationDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*KBCClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "keboola_component_configuration" {
+			continue
+		}
+
+		configurationURI := fmt.Sprintf("storage/components/%s/configs/%s", rs.Primary.Attributes["component_id"], rs.Primary.ID)
+		getResp, err := client.GetFromStorage(configurationURI)
+
+		if err == nil && getResp.StatusCode == 200 {
+			return fmt.Errorf("Component configuration still exists")
+		}
+	}
+
+	return nil
+}
+
+const testComponentConfigurationBasic = `
+resource "keboola_component_configuration" "test_config" {
+	component_id = "keboola.ex-http"
+	name = "test name"
+	description = "test description"
+	configuration = <<EOF
+{
+	"parameters": {
+		"baseUrl": "https://example.com/",
+		"path": "data.csv"
+	}
+}
+EOF
+}`
+
+const testComponentConfigurationUpdate = `
+resource "keboola_component_configuration" "test_config" {
+	component_id = "keboola.ex-http"
+	name = "new test name"
+	description = "new test description"
+	configuration = <<EOF
+{
+	"parameters": {
+		"path": "other.csv",
+		"baseUrl": "https://example.com/"
+	},
+	"processors": {
+		"after": [ { "definition": { "component": "keboola.processor-move-files" } } ]
+	}
+}
+EOF
+}`
+
+func TestUnitComponentConfiguration_Basic(t *testing.T) {
+	mock := newMockKeboolaAPI()
+	defer mock.Close()
+
+	const configurationPath = "/v2/storage/components/%s/configs/%s"
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    mock.providers(),
+		CheckDestroy: mock.testCheckDestroy("keboola_component_configuration", configurationPath, "component_id", "id"),
+		Steps: []resource.TestStep{
+			{
+				Config: mock.config(testComponentConfigurationBasic),
+				Check: resource.ComposeTestCheckFunc(
+					mock.testCheckExists("keboola_component_configuration.test_config", configurationPath, "component_id", "id"),
+					resource.TestCheckResourceAttr("keboola_component_configuration.test_config", "component_id", "keboola.ex-http"),
+					resource.TestCheckResourceAttr("keboola_component_configuration.test_config", "name", "test name"),
+					resource.TestCheckResourceAttr("keboola_component_configuration.test_config", "configuration", `{"parameters":{"baseUrl":"https://example.com/","path":"data.csv"}}`),
+				),
+			},
+			{
+				Config: mock.config(testComponentConfigurationUpdate),
+				Check: resource.ComposeTestCheckFunc(
+					mock.testCheckExists("keboola_component_configuration.test_config", configurationPath, "component_id", "id"),
+					resource.TestCheckResourceAttr("keboola_component_configuration.test_config", "name", "new test name"),
+					resource.TestCheckResourceAttr("keboola_component_configuration.test_config", "description", "new test description"),
+					resource.TestCheckResourceAttr("keboola_component_configuration.test_config", "configuration", `{"parameters":{"baseUrl":"https://example.com/","path":"other.csv"},"processors":{"after":[{"definition":{"component":"keboola.processor-move-files"}}]}}`),
+				),
+			},
+			{
+				Config:            mock.config(testComponentConfigurationUpdate),
+				ResourceName:      "keboola_component_configuration.test_config",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs := s.RootModule().Resources["keboola_component_configuration.test_config"]
+					return fmt.Sprintf("%s/%s", rs.Primary.Attributes["component_id"], rs.Primary.ID), nil
+				},
+			},
+		},
+	})
+}
+
+func TestUnitComponentConfiguration_InvalidImportID(t *testing.T) {
+	mock := newMockKeboolaAPI()
+	defer mock.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers: mock.providers(),
+		Steps: []resource.TestStep{
+			{
+				Config:        mock.config(testComponentConfigurationBasic),
+				ResourceName:  "keboola_component_configuration.test_config",
+				ImportState:   true,
+				ImportStateId: "1234",
+				ExpectError:   regexp.MustCompile("expected component_id/config_id"),
+			},
+		},
+	})
+}
+
+func testComponentConfigurationWithSecrets(password string) string {
+	return fmt.Sprintf(`
+	resource "keboola_component_configuration" "test_config" {
+		component_id = "keboola.ex-http"
+		name = "test name"
+		configuration = <<EOF
+{
+	"parameters": {
+		"#password": %q,
+		"#apiKey": "KBC::ProjectSecure::already-encrypted",
+		"accounts": [ { "name": "main", "#token": "secret-token" } ]
+	}
+}
+EOF
+	}`, password)
+}
+
+func TestUnitComponentConfiguration_Secrets(t *testing.T) {
+	mock := newMockKeboolaAPI()
+	defer mock.Close()
+
+	const configurationPath = "/v2/storage/components/%s/configs/%s"
+
+	testCheckStoredSecret := func(expectedPassword string) resource.TestCheckFunc {
+		return func(s *terraform.State) error {
+			mock.mutex.Lock()
+			defer mock.mutex.Unlock()
+
+			rs := s.RootModule().Resources["keboola_component_configuration.test_config"]
+			configuration := mock.components["keboola.ex-http"][rs.Primary.ID].Configuration.(map[string]interface{})
+			password := configuration["parameters"].(map[string]interface{})["#password"]
+
+			if expected := "KBC::ProjectSecure::" + base64.StdEncoding.EncodeToString([]byte(expectedPassword)); password != expected {
+				return fmt.Errorf("Expected the stored #password to be %s, got %v", expected, password)
+			}
+
+			return nil
+		}
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    mock.providers(),
+		CheckDestroy: mock.testCheckDestroy("keboola_component_configuration", configurationPath, "component_id", "id"),
+		Steps: []resource.TestStep{
+			{
+				Config: mock.config(testComponentConfigurationWithSecrets("hunter2")),
+				Check: resource.ComposeTestCheckFunc(
+					mock.testCheckExists("keboola_component_configuration.test_config", configurationPath, "component_id", "id"),
+					resource.TestCheckResourceAttr("keboola_component_configuration.test_config", "configuration", `{"parameters":{"#apiKey":"KBC::ProjectSecure::already-encrypted","#password":"hunter2","accounts":[{"#token":"secret-token","name":"main"}]}}`),
+					testCheckStoredSecret("hunter2"),
+				),
+			},
+			{
+				Config: mock.config(testComponentConfigurationWithSecrets("hunter3")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("keboola_component_configuration.test_config", "configuration", `{"parameters":{"#apiKey":"KBC::ProjectSecure::already-encrypted","#password":"hunter3","accounts":[{"#token":"secret-token","name":"main"}]}}`),
+					testCheckStoredSecret("hunter3"),
+				),
+			},
+		},
+	})
+}
+
+func TestKeepPlaintextSecrets(t *testing.T) {
+	read := `{"#password":"KBC::ProjectSecure::abc","user":"admin","#new":"KBC::ProjectSecure::def","nested":[{"#token":"KBC::ProjectSecure::ghi"}]}`
+
+	assert.Equal(t, `{"#new":"KBC::ProjectSecure::def","#password":"hunter2","nested":[{"#token":"secret"}],"user":"admin"}`, keepPlaintextSecrets(read, `{"#password":"hunter2","user":"root","nested":[{"#token":"secret"}]}`))
+	assert.Equal(t, `{"#password":"KBC::ProjectSecure::abc"}`, keepPlaintextSecrets(`{"#password":"KBC::ProjectSecure::abc"}`, `{"#password":"KBC::ProjectSecure::xyz"}`), "A value which was already encrypted should be read back as it is")
+	assert.Equal(t, `{"password":"KBC::ProjectSecure::abc"}`, keepPlaintextSecrets(`{"password":"KBC::ProjectSecure::abc"}`, `{"password":"hunter2"}`), "Only the values of #-prefixed keys are encrypted")
+	assert.Equal(t, `{"#password":"KBC::ProjectSecure::abc"}`, keepPlaintextSecrets(`{"#password":"KBC::ProjectSecure::abc"}`, ""), "An imported configuration has no previous configuration")
+}
@@ -2,7 +2,6 @@ package keboola
 
 import (
 	"encoding/json"
-	"fmt"
 	"log"
 	"net/url"
 
@@ -31,12 +30,15 @@ type CSVUploadSettings struct {
 
 func resourceKeboolaCSVImportExtractor() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceKeboolaCSVImportExtractorCreate,
-		Read:   resourceKeboolaCSVImportExtractorRead,
-		Update: resourceKeboolaCSVImportExtractorUpdate,
-		Delete: resourceKeboolaCSVImportExtractorDelete,
+		Create:        resourceKeboolaCSVImportExtractorCreate,
+		Read:          resourceKeboolaCSVImportExtractorRead,
+		Update:        resourceKeboolaCSVImportExtractorUpdate,
+		Delete:        resourceKeboolaCSVImportExtractorDelete,
+		CustomizeDiff: customizeBranchIDDiff,
 
 		Schema: map[string]*schema.Schema{
+			"branch_id":               &branchIDSchema,
+			"branch_id_from_provider": &branchIDFromProviderSchema,
 			"name": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -100,7 +102,7 @@ func resourceKeboolaCSVImportExtractorCreate(d *schema.ResourceData, meta interf
 	createExtractorBuffer := buffer.FromForm(createExtractorForm)
 
 	client := meta.(*KBCClient)
-	createResponse, err := client.PostToStorage("storage/components/keboola.csv-import/configs", createExtractorBuffer)
+	createResponse, err := client.PostToStorage(componentsURI(d, client, "keboola.csv-import/configs"), createExtractorBuffer)
 
 	if hasErrors(err, createResponse) {
 		return extractError(err, createResponse)
@@ -124,19 +126,21 @@ func resourceKeboolaCSVImportExtractorRead(d *schema.ResourceData, meta interfac
 	log.Println("[INFO] Reading CSV Import Extractor from Keboola.")
 
 	client := meta.(*KBCClient)
-	getCSVExtractorResponse, err := client.GetFromStorage(fmt.Sprintf("storage/components/keboola.csv-import/configs/%s", d.Id()))
+	getCSVExtractorResponse, err := client.GetFromStorage(componentsURI(d, client, "keboola.csv-import/configs/%s", d.Id()))
 
 	if d.Id() == "" {
 		return nil
 	}
 
 	if hasErrors(err, getCSVExtractorResponse) {
-		if getCSVExtractorResponse.StatusCode == 404 {
+		err = extractError(err, getCSVExtractorResponse)
+
+		if isNotFoundError(err) {
 			d.SetId("")
 			return nil
 		}
 
-		return extractError(err, getCSVExtractorResponse)
+		return err
 	}
 
 	var csvImportExtractor CSVImportExtractor
@@ -158,6 +162,8 @@ func resourceKeboolaCSVImportExtractorRead(d *schema.ResourceData, meta interfac
 	d.Set("delimiter", csvImportExtractor.Configuration.Delimiter)
 	d.Set("enclosure", csvImportExtractor.Configuration.Enclosure)
 
+	setBranchID(d, client)
+
 	return nil
 }
 
@@ -187,20 +193,20 @@ func resourceKeboolaCSVImportExtractorUpdate(d *schema.ResourceData, meta interf
 	updateExtractorForm.Add("configuration", string(uploadSettingsJSON))
 	updateExtractorBuffer := buffer.FromForm(updateExtractorForm)
 
-	updateExtractorResponse, err := client.PutToStorage(fmt.Sprintf("storage/components/keboola.csv-import/configs/%s", d.Id()), updateExtractorBuffer)
+	updateExtractorResponse, err := client.PutToStorage(componentsURI(d, client, "keboola.csv-import/configs/%s", d.Id()), updateExtractorBuffer)
 
 	if hasErrors(err, updateExtractorResponse) {
 		return extractError(err, updateExtractorResponse)
 	}
 
-	return resourceKeboolaSnowflakeWriterRead(d, meta)
+	return resourceKeboolaCSVImportExtractorRead(d, meta)
 }
 
 func resourceKeboolaCSVImportExtractorDelete(d *schema.ResourceData, meta interface{}) error {
 	log.Printf("[INFO] Deleting CSV Import Extractor in Keboola: %s", d.Id())
 
 	client := meta.(*KBCClient)
-	destroyResponse, err := client.DeleteFromStorage(fmt.Sprintf("storage/components/keboola.csv-import/configs/%s", d.Id()))
+	destroyResponse, err := client.DeleteFromStorage(componentsURI(d, client, "keboola.csv-import/configs/%s", d.Id()))
 
 	if hasErrors(err, destroyResponse) {
 		return extractError(err, destroyResponse)
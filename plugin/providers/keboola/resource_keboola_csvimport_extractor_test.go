@@ -60,3 +60,77 @@ const testCSVImportExtractorBasic = `
 		enclosure = "'"
 		primary_key = [ "baz" ]
 	}`
+
+const testCSVImportExtractorUpdate = `
+	resource "keboola_csvimport_extractor" "test_extractor" {
+		name = "test_extractor updated"
+		description = "test description updated"
+		destination = "out.foo.baz"
+		delimiter = ","
+		enclosure = "\""
+		incremental = true
+		primary_key = [ "baz", "qux" ]
+	}`
+
+func TestUnitCSVImportExtractor_Basic(t *testing.T) {
+	mock := newMockKeboolaAPI()
+	defer mock.Close()
+
+	const extractorPath = "/v2/storage/components/keboola.csv-import/configs/%s"
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    mock.providers(),
+		CheckDestroy: mock.testCheckDestroy("keboola_csvimport_extractor", extractorPath, "id"),
+		Steps: []resource.TestStep{
+			{
+				Config: mock.config(testCSVImportExtractorBasic),
+				Check: resource.ComposeTestCheckFunc(
+					mock.testCheckExists("keboola_csvimport_extractor.test_extractor", extractorPath, "id"),
+					resource.TestCheckResourceAttr("keboola_csvimport_extractor.test_extractor", "name", "test_extractor"),
+					resource.TestCheckResourceAttr("keboola_csvimport_extractor.test_extractor", "destination", "out.foo.bar"),
+					resource.TestCheckResourceAttr("keboola_csvimport_extractor.test_extractor", "delimiter", "%"),
+					resource.TestCheckResourceAttr("keboola_csvimport_extractor.test_extractor", "primary_key.#", "1"),
+				),
+			},
+			{
+				Config: mock.config(testCSVImportExtractorUpdate),
+				Check: resource.ComposeTestCheckFunc(
+					mock.testCheckExists("keboola_csvimport_extractor.test_extractor", extractorPath, "id"),
+					resource.TestCheckResourceAttr("keboola_csvimport_extractor.test_extractor", "name", "test_extractor updated"),
+					resource.TestCheckResourceAttr("keboola_csvimport_extractor.test_extractor", "description", "test description updated"),
+					resource.TestCheckResourceAttr("keboola_csvimport_extractor.test_extractor", "destination", "out.foo.baz"),
+					resource.TestCheckResourceAttr("keboola_csvimport_extractor.test_extractor", "delimiter", ","),
+					resource.TestCheckResourceAttr("keboola_csvimport_extractor.test_extractor", "incremental", "true"),
+					resource.TestCheckResourceAttr("keboola_csvimport_extractor.test_extractor", "primary_key.#", "2"),
+					testCheckCSVImportExtractorReadBack(mock),
+				),
+			},
+		},
+	})
+}
+
+//testCheckCSVImportExtractorReadBack checks that the state was read back from the CSV Import configuration
+//itself (rather than from a configuration of another component) after the extractor was updated.
+func testCheckCSVImportExtractorReadBack(mock *mockKeboolaAPI) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		mock.mutex.Lock()
+		defer mock.mutex.Unlock()
+
+		rs := s.RootModule().Resources["keboola_csvimport_extractor.test_extractor"]
+		configuration, ok := mock.components["keboola.csv-import"][rs.Primary.ID]
+
+		if !ok {
+			return fmt.Errorf("CSV Import configuration %s not found", rs.Primary.ID)
+		}
+
+		if rs.Primary.Attributes["name"] != configuration.Name {
+			return fmt.Errorf("Expected name %q to be read back from the CSV Import configuration, got %q", configuration.Name, rs.Primary.Attributes["name"])
+		}
+
+		if destination := configuration.Configuration.(map[string]interface{})["destination"]; rs.Primary.Attributes["destination"] != destination {
+			return fmt.Errorf("Expected destination %q to be read back from the CSV Import configuration, got %q", destination, rs.Primary.Attributes["destination"])
+		}
+
+		return nil
+	}
+}
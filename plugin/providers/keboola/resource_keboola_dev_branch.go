@@ -0,0 +1,176 @@
+package keboola
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/url"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/paybyphone/terraform-provider-keboola/plugin/providers/keboola/buffer"
+)
+
+//region Keboola API Contracts
+
+//DevBranch is the data model for a development branch within the Keboola Storage API.
+type DevBranch struct {
+	ID          KBCNumberString `json:"id"`
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	IsDefault   bool            `json:"isDefault"`
+}
+
+//endregion
+
+func resourceKeboolaDevBranch() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceKeboolaDevBranchCreate,
+		Read:   resourceKeboolaDevBranchRead,
+		Update: resourceKeboolaDevBranchUpdate,
+		Delete: resourceKeboolaDevBranchDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultJobTimeout),
+			Delete: schema.DefaultTimeout(defaultJobTimeout),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	}
+}
+
+func resourceKeboolaDevBranchCreate(d *schema.ResourceData, meta interface{}) error {
+	log.Println("[INFO] Creating Development Branch in Keboola.")
+
+	createBranchForm := url.Values{}
+	createBranchForm.Add("name", d.Get("name").(string))
+	createBranchForm.Add("description", d.Get("description").(string))
+
+	createBranchBuffer := buffer.FromForm(createBranchForm)
+
+	client := meta.(*KBCClient)
+	createResponse, err := client.PostToStorage("storage/dev-branches", createBranchBuffer)
+
+	if hasErrors(err, createResponse) {
+		return extractError(err, createResponse)
+	}
+
+	var createResult StorageJobStatus
+
+	decoder := json.NewDecoder(createResponse.Body)
+	err = decoder.Decode(&createResult)
+
+	if err != nil {
+		return err
+	}
+
+	createStatusResult, err := client.waitForStorageJob(createResult.ID, d.Timeout(schema.TimeoutCreate))
+
+	if err != nil {
+		return err
+	}
+
+	d.SetId(string(createStatusResult.Results.ID))
+
+	return resourceKeboolaDevBranchRead(d, meta)
+}
+
+func resourceKeboolaDevBranchRead(d *schema.ResourceData, meta interface{}) error {
+	log.Println("[INFO] Reading Development Branch from Keboola.")
+
+	if d.Id() == "" {
+		return nil
+	}
+
+	client := meta.(*KBCClient)
+	getResponse, err := client.GetFromStorage(fmt.Sprintf("storage/dev-branches/%s", d.Id()))
+
+	if hasErrors(err, getResponse) {
+		err = extractError(err, getResponse)
+
+		if isNotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+
+		return err
+	}
+
+	var devBranch DevBranch
+
+	decoder := json.NewDecoder(getResponse.Body)
+	err = decoder.Decode(&devBranch)
+
+	if err != nil {
+		return err
+	}
+
+	if devBranch.IsDefault {
+		return fmt.Errorf("branch %s is the default branch of the project, which cannot be managed as a keboola_dev_branch", d.Id())
+	}
+
+	d.Set("name", devBranch.Name)
+	d.Set("description", devBranch.Description)
+
+	return nil
+}
+
+func resourceKeboolaDevBranchUpdate(d *schema.ResourceData, meta interface{}) error {
+	log.Println("[INFO] Updating Development Branch in Keboola.")
+
+	updateBranchForm := url.Values{}
+	updateBranchForm.Add("name", d.Get("name").(string))
+	updateBranchForm.Add("description", d.Get("description").(string))
+
+	updateBranchBuffer := buffer.FromForm(updateBranchForm)
+
+	client := meta.(*KBCClient)
+	updateResponse, err := client.PutToStorage(fmt.Sprintf("storage/dev-branches/%s", d.Id()), updateBranchBuffer)
+
+	if hasErrors(err, updateResponse) {
+		return extractError(err, updateResponse)
+	}
+
+	return resourceKeboolaDevBranchRead(d, meta)
+}
+
+func resourceKeboolaDevBranchDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[INFO] Deleting Development Branch in Keboola: %s", d.Id())
+
+	client := meta.(*KBCClient)
+	destroyResponse, err := client.DeleteFromStorage(fmt.Sprintf("storage/dev-branches/%s", d.Id()))
+
+	if hasErrors(err, destroyResponse) {
+		return extractError(err, destroyResponse)
+	}
+
+	var destroyResult StorageJobStatus
+
+	decoder := json.NewDecoder(destroyResponse.Body)
+	err = decoder.Decode(&destroyResult)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = client.waitForStorageJob(destroyResult.ID, d.Timeout(schema.TimeoutDelete))
+
+	if err != nil {
+		return err
+	}
+
+	d.SetId("")
+
+	return nil
+}
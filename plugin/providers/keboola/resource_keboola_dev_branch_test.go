@@ -0,0 +1,231 @@
+package keboola
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccDevBranch_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDevBranchDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testDevBranchBasic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("keboola_dev_branch.test_branch", "name", "test branch"),
+					resource.TestCheckResourceAttr("keboola_dev_branch.test_branch", "description", "test description"),
+				),
+			},
+			{
+				Config: testDevBranchUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("keboola_dev_branch.test_branch", "name", "new test branch"),
+					resource.TestCheckResourceAttr("keboola_dev_branch.test_branch", "description", "new test description"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckDevBranchDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*KBCClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "keboola_dev_branch" {
+			continue
+		}
+
+		branchURI := fmt.Sprintf("storage/dev-branches/%s", rs.Primary.ID)
+		getResp, err := client.GetFromStorage(branchURI)
+
+		if err == nil && getResp.StatusCode == 200 {
+			return fmt.Errorf("Development branch still exists")
+		}
+	}
+
+	return nil
+}
+
+const testDevBranchBasic = `
+resource "keboola_dev_branch" "test_branch" {
+	name = "test branch"
+	description = "test description"
+}`
+
+const testDevBranchUpdate = `
+resource "keboola_dev_branch" "test_branch" {
+	name = "new test branch"
+	description = "new test description"
+}`
+
+const testDevBranchWithConfiguration = `
+resource "keboola_dev_branch" "test_branch" {
+	name = "test branch"
+}
+
+resource "keboola_component_configuration" "test_config" {
+	branch_id = "${keboola_dev_branch.test_branch.id}"
+	component_id = "keboola.ex-http"
+	name = "test name"
+}`
+
+func TestUnitDevBranch_Basic(t *testing.T) {
+	mock := newMockKeboolaAPI()
+	defer mock.Close()
+
+	const branchPath = "/v2/storage/dev-branches/%s"
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    mock.providers(),
+		CheckDestroy: mock.testCheckDestroy("keboola_dev_branch", branchPath, "id"),
+		Steps: []resource.TestStep{
+			{
+				Config: mock.config(testDevBranchBasic),
+				Check: resource.ComposeTestCheckFunc(
+					mock.testCheckExists("keboola_dev_branch.test_branch", branchPath, "id"),
+					resource.TestCheckResourceAttr("keboola_dev_branch.test_branch", "name", "test branch"),
+					resource.TestCheckResourceAttr("keboola_dev_branch.test_branch", "description", "test description"),
+				),
+			},
+			{
+				Config: mock.config(testDevBranchUpdate),
+				Check: resource.ComposeTestCheckFunc(
+					mock.testCheckExists("keboola_dev_branch.test_branch", branchPath, "id"),
+					resource.TestCheckResourceAttr("keboola_dev_branch.test_branch", "name", "new test branch"),
+					resource.TestCheckResourceAttr("keboola_dev_branch.test_branch", "description", "new test description"),
+				),
+			},
+			{
+				Config:            mock.config(testDevBranchUpdate),
+				ResourceName:      "keboola_dev_branch.test_branch",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestUnitDevBranch_ResourceBranchID(t *testing.T) {
+	mock := newMockKeboolaAPI()
+	defer mock.Close()
+
+	const branchConfigurationPath = "/v2/storage/branch/%s/components/%s/configs/%s"
+	const defaultConfigurationPath = "/v2/storage/components/%s/configs/%s"
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    mock.providers(),
+		CheckDestroy: mock.testCheckDestroy("keboola_dev_branch", "/v2/storage/dev-branches/%s", "id"),
+		Steps: []resource.TestStep{
+			{
+				Config: mock.config(testDevBranchWithConfiguration),
+				Check: resource.ComposeTestCheckFunc(
+					mock.testCheckExists("keboola_component_configuration.test_config", branchConfigurationPath, "branch_id", "component_id", "id"),
+					mock.testCheckDestroy("keboola_component_configuration", defaultConfigurationPath, "component_id", "id"),
+					resource.TestCheckResourceAttrPair("keboola_component_configuration.test_config", "branch_id", "keboola_dev_branch.test_branch", "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitDevBranch_ProviderBranchID(t *testing.T) {
+	mock := newMockKeboolaAPI()
+	defer mock.Close()
+
+	branch := mock.addBranch("provider branch", "")
+	branchPath := fmt.Sprintf("/v2/storage/branch/%d/components", branch.ID)
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    mock.providers(),
+		CheckDestroy: mock.testCheckDestroy("keboola_component_configuration", branchPath+"/%s/configs/%s", "component_id", "id"),
+		Steps: []resource.TestStep{
+			{
+				Config: mock.configInBranch(branch, testComponentConfigurationBasic+testTransformationBucketBasic),
+				Check: resource.ComposeTestCheckFunc(
+					mock.testCheckExists("keboola_component_configuration.test_config", branchPath+"/%s/configs/%s", "component_id", "id"),
+					mock.testCheckExists("keboola_transformation_bucket.test_bucket", branchPath+"/transformation/configs/%s", "id"),
+					mock.testCheckDestroy("keboola_component_configuration", "/v2/storage/components/%s/configs/%s", "component_id", "id"),
+					mock.testCheckDestroy("keboola_transformation_bucket", "/v2/storage/components/transformation/configs/%s", "id"),
+					resource.TestCheckResourceAttr("keboola_component_configuration.test_config", "branch_id", strconv.Itoa(branch.ID)),
+					resource.TestCheckResourceAttr("keboola_component_configuration.test_config", "branch_id_from_provider", "true"),
+				),
+			},
+			{
+				Config: mock.configInBranch(branch, testComponentConfigurationUpdate+testTransformationBucketUpdate),
+				Check: resource.ComposeTestCheckFunc(
+					mock.testCheckExists("keboola_component_configuration.test_config", branchPath+"/%s/configs/%s", "component_id", "id"),
+					resource.TestCheckResourceAttr("keboola_component_configuration.test_config", "name", "new test name"),
+					resource.TestCheckResourceAttr("keboola_transformation_bucket.test_bucket", "name", "new test name"),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitDevBranch_ProviderBranchIDChange(t *testing.T) {
+	mock := newMockKeboolaAPI()
+	defer mock.Close()
+
+	firstBranch := mock.addBranch("first branch", "")
+	secondBranch := mock.addBranch("second branch", "")
+
+	configurationPath := func(branch *mockBranch) string {
+		return fmt.Sprintf("/v2/storage/branch/%d/components/%%s/configs/%%s", branch.ID)
+	}
+
+	explicitBranchConfiguration := fmt.Sprintf(`
+resource "keboola_component_configuration" "test_config" {
+	branch_id = "%d"
+	component_id = "keboola.ex-http"
+	name = "test name"
+}`, firstBranch.ID)
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers: mock.providers(),
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			mock.testCheckDestroy("keboola_component_configuration", configurationPath(firstBranch), "component_id", "id"),
+			mock.testCheckDestroy("keboola_component_configuration", configurationPath(secondBranch), "component_id", "id"),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: mock.configInBranch(firstBranch, testComponentConfigurationBasic),
+				Check: resource.ComposeTestCheckFunc(
+					mock.testCheckExists("keboola_component_configuration.test_config", configurationPath(firstBranch), "component_id", "id"),
+					resource.TestCheckResourceAttr("keboola_component_configuration.test_config", "branch_id", strconv.Itoa(firstBranch.ID)),
+					resource.TestCheckResourceAttr("keboola_component_configuration.test_config", "branch_id_from_provider", "true"),
+				),
+			},
+			{
+				//Changing the provider's branch_id recreates the configuration in the new branch.
+				Config: mock.configInBranch(secondBranch, testComponentConfigurationBasic),
+				Check: resource.ComposeTestCheckFunc(
+					mock.testCheckExists("keboola_component_configuration.test_config", configurationPath(secondBranch), "component_id", "id"),
+					mock.testCheckDestroy("keboola_component_configuration", configurationPath(firstBranch), "component_id", "id"),
+					resource.TestCheckResourceAttr("keboola_component_configuration.test_config", "branch_id", strconv.Itoa(secondBranch.ID)),
+					resource.TestCheckResourceAttr("keboola_component_configuration.test_config", "branch_id_from_provider", "true"),
+				),
+			},
+			{
+				//Setting branch_id on the resource itself takes precedence over the provider's branch_id.
+				Config: mock.configInBranch(secondBranch, explicitBranchConfiguration),
+				Check: resource.ComposeTestCheckFunc(
+					mock.testCheckExists("keboola_component_configuration.test_config", configurationPath(firstBranch), "component_id", "id"),
+					mock.testCheckDestroy("keboola_component_configuration", configurationPath(secondBranch), "component_id", "id"),
+					resource.TestCheckResourceAttr("keboola_component_configuration.test_config", "branch_id", strconv.Itoa(firstBranch.ID)),
+					resource.TestCheckResourceAttr("keboola_component_configuration.test_config", "branch_id_from_provider", "false"),
+				),
+			},
+			{
+				//Once it is set on the resource, changing the provider's branch_id no longer affects it.
+				Config:   mock.config(explicitBranchConfiguration),
+				PlanOnly: true,
+			},
+		},
+	})
+}
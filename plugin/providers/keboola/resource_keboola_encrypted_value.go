@@ -0,0 +1,97 @@
+package keboola
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/hashcode"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceKeboolaEncryptedValue() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceKeboolaEncryptedValueCreate,
+		Read:   resourceKeboolaEncryptedValueRead,
+		Delete: resourceKeboolaEncryptedValueDelete,
+
+		Schema: map[string]*schema.Schema{
+			"component_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"value": {
+				Type:      schema.TypeString,
+				Required:  true,
+				ForceNew:  true,
+				Sensitive: true,
+			},
+			"project_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"encrypted_value": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+		},
+	}
+}
+
+//resourceKeboolaEncryptedValueCreate encrypts the value with the Encryption API, scoped to both the
+//project and the component, so that it can only be decrypted by that component within this project.
+func resourceKeboolaEncryptedValueCreate(d *schema.ResourceData, meta interface{}) error {
+	log.Println("[INFO] Encrypting value using the Keboola Encryption API.")
+
+	client := meta.(*KBCClient)
+	projectID := client.ProjectID()
+
+	encryptQuery := url.Values{}
+	encryptQuery.Add("componentId", d.Get("component_id").(string))
+	encryptQuery.Add("projectId", strconv.Itoa(projectID))
+
+	encryptResponse, err := client.PostToEncryption(fmt.Sprintf("encrypt?%s", encryptQuery.Encode()), bytes.NewBufferString(d.Get("value").(string)))
+
+	if hasErrors(err, encryptResponse) {
+		return extractError(err, encryptResponse)
+	}
+
+	encrypted, err := ioutil.ReadAll(encryptResponse.Body)
+
+	if err != nil {
+		return err
+	}
+
+	encryptedValue := strings.TrimSpace(string(encrypted))
+
+	if !strings.HasPrefix(encryptedValue, "KBC::ProjectSecure::") {
+		return fmt.Errorf("unexpected response from the Keboola Encryption API, expected a value starting with 'KBC::ProjectSecure::'")
+	}
+
+	d.SetId(strconv.Itoa(hashcode.String(encryptedValue)))
+	d.Set("project_id", projectID)
+	d.Set("encrypted_value", encryptedValue)
+
+	return resourceKeboolaEncryptedValueRead(d, meta)
+}
+
+//resourceKeboolaEncryptedValueRead does nothing, as an encrypted value only exists in the Terraform state.
+func resourceKeboolaEncryptedValueRead(d *schema.ResourceData, meta interface{}) error {
+	log.Println("[INFO] Reading Encrypted Value from Terraform state.")
+
+	return nil
+}
+
+func resourceKeboolaEncryptedValueDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[INFO] Removing Encrypted Value from Terraform state: %s", d.Id())
+
+	d.SetId("")
+
+	return nil
+}
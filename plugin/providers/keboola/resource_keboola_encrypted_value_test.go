@@ -0,0 +1,86 @@
+package keboola
+
+import (
+	"encoding/base64"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccEncryptedValue_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testEncryptedValueBasic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("keboola_encrypted_value.test_password", "component_id", "keboola.ex-ftp"),
+					resource.TestMatchResourceAttr("keboola_encrypted_value.test_password", "encrypted_value", regexp.MustCompile("^KBC::ProjectSecure::")),
+					resource.TestCheckResourceAttrPair("keboola_ftp_extractor.test_extractor", "hashed_password", "keboola_encrypted_value.test_password", "encrypted_value"),
+				),
+			},
+		},
+	})
+}
+
+const testEncryptedValueBasic = `
+resource "keboola_encrypted_value" "test_password" {
+	component_id = "keboola.ex-ftp"
+	value = "hunter2"
+}
+
+resource "keboola_ftp_extractor" "test_extractor" {
+	name = "test_extractor"
+	host = "some.ftp.site"
+	port = "22"
+	connection_type = "sftp"
+	username = "test_username"
+	hashed_password = "${keboola_encrypted_value.test_password.encrypted_value}"
+}`
+
+const testEncryptedValueUpdate = `
+resource "keboola_encrypted_value" "test_password" {
+	component_id = "keboola.ex-ftp"
+	value = "correct horse battery staple"
+}
+
+resource "keboola_ftp_extractor" "test_extractor" {
+	name = "test_extractor"
+	host = "some.ftp.site"
+	port = "22"
+	connection_type = "sftp"
+	username = "test_username"
+	hashed_password = "${keboola_encrypted_value.test_password.encrypted_value}"
+}`
+
+func TestUnitEncryptedValue_Basic(t *testing.T) {
+	mock := newMockKeboolaAPI()
+	defer mock.Close()
+
+	encryptedFor := func(value string) string {
+		return "KBC::ProjectSecure::" + base64.StdEncoding.EncodeToString([]byte("1234/keboola.ex-ftp/"+value))
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers: mock.providers(),
+		Steps: []resource.TestStep{
+			{
+				Config: mock.config(testEncryptedValueBasic),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("keboola_encrypted_value.test_password", "project_id", "1234"),
+					resource.TestCheckResourceAttr("keboola_encrypted_value.test_password", "encrypted_value", encryptedFor("hunter2")),
+					resource.TestCheckResourceAttr("keboola_ftp_extractor.test_extractor", "hashed_password", encryptedFor("hunter2")),
+				),
+			},
+			{
+				Config: mock.config(testEncryptedValueUpdate),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("keboola_encrypted_value.test_password", "encrypted_value", encryptedFor("correct horse battery staple")),
+					resource.TestCheckResourceAttr("keboola_ftp_extractor.test_extractor", "hashed_password", encryptedFor("correct horse battery staple")),
+				),
+			},
+		},
+	})
+}
@@ -2,7 +2,6 @@ package keboola
 
 import (
 	"encoding/json"
-	"fmt"
 	"log"
 	"net/url"
 
@@ -33,12 +32,15 @@ type FTPSettings struct {
 
 func resourceKeboolaFTPExtractor() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceKeboolaFTPExtractorCreate,
-		Read:   resourceKeboolaFTPExtractorRead,
-		Update: resourceKeboolaFTPExtractorUpdate,
-		Delete: resourceKeboolaFTPExtractorDelete,
+		Create:        resourceKeboolaFTPExtractorCreate,
+		Read:          resourceKeboolaFTPExtractorRead,
+		Update:        resourceKeboolaFTPExtractorUpdate,
+		Delete:        resourceKeboolaFTPExtractorDelete,
+		CustomizeDiff: customizeBranchIDDiff,
 
 		Schema: map[string]*schema.Schema{
+			"branch_id":               &branchIDSchema,
+			"branch_id_from_provider": &branchIDFromProviderSchema,
 			"name": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -106,7 +108,7 @@ func resourceKeboolaFTPExtractorCreate(d *schema.ResourceData, meta interface{})
 	createExtractorBuffer := buffer.FromForm(createExtractorForm)
 
 	client := meta.(*KBCClient)
-	createResponse, err := client.PostToStorage("storage/components/keboola.ex-ftp/configs", createExtractorBuffer)
+	createResponse, err := client.PostToStorage(componentsURI(d, client, "keboola.ex-ftp/configs"), createExtractorBuffer)
 
 	if hasErrors(err, createResponse) {
 		return extractError(err, createResponse)
@@ -130,19 +132,21 @@ func resourceKeboolaFTPExtractorRead(d *schema.ResourceData, meta interface{}) e
 	log.Println("[INFO] Reading FTP Extractor from Keboola.")
 
 	client := meta.(*KBCClient)
-	getFTPExtractorResponse, err := client.GetFromStorage(fmt.Sprintf("storage/components/keboola.ex-ftp/configs/%s", d.Id()))
+	getFTPExtractorResponse, err := client.GetFromStorage(componentsURI(d, client, "keboola.ex-ftp/configs/%s", d.Id()))
 
 	if d.Id() == "" {
 		return nil
 	}
 
 	if hasErrors(err, getFTPExtractorResponse) {
-		if getFTPExtractorResponse.StatusCode == 404 {
+		err = extractError(err, getFTPExtractorResponse)
+
+		if isNotFoundError(err) {
 			d.SetId("")
 			return nil
 		}
 
-		return extractError(err, getFTPExtractorResponse)
+		return err
 	}
 
 	var ftpExtractor FTPExtractor
@@ -165,6 +169,8 @@ func resourceKeboolaFTPExtractorRead(d *schema.ResourceData, meta interface{}) e
 	d.Set("hashed_password", ftpExtractor.Configuration.EncryptedPassword)
 	d.Set("hashed_private_key", ftpExtractor.Configuration.EncryptedPrivateKey)
 
+	setBranchID(d, client)
+
 	return nil
 }
 
@@ -195,7 +201,7 @@ func resourceKeboolaFTPExtractorUpdate(d *schema.ResourceData, meta interface{})
 	updateExtractorForm.Add("configuration", string(uploadSettingsJSON))
 	updateExtractorBuffer := buffer.FromForm(updateExtractorForm)
 
-	updateExtractorResponse, err := client.PutToStorage(fmt.Sprintf("storage/components/keboola.ex-ftp/configs/%s", d.Id()), updateExtractorBuffer)
+	updateExtractorResponse, err := client.PutToStorage(componentsURI(d, client, "keboola.ex-ftp/configs/%s", d.Id()), updateExtractorBuffer)
 
 	if hasErrors(err, updateExtractorResponse) {
 		return extractError(err, updateExtractorResponse)
@@ -208,7 +214,7 @@ func resourceKeboolaFTPExtractorDelete(d *schema.ResourceData, meta interface{})
 	log.Printf("[INFO] Deleting FTP Extractor in Keboola: %s", d.Id())
 
 	client := meta.(*KBCClient)
-	destroyResponse, err := client.DeleteFromStorage(fmt.Sprintf("storage/components/keboola.ex-ftp/configs/%s", d.Id()))
+	destroyResponse, err := client.DeleteFromStorage(componentsURI(d, client, "keboola.ex-ftp/configs/%s", d.Id()))
 
 	if hasErrors(err, destroyResponse) {
 		return extractError(err, destroyResponse)
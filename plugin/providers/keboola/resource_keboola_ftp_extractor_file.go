@@ -2,7 +2,6 @@ package keboola
 
 import (
 	"encoding/json"
-	"fmt"
 	"log"
 	"net/url"
 
@@ -19,12 +18,15 @@ type FTPFile struct {
 
 func resourceKeboolaFTPExtractorFile() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceKeboolaFTPExtractorFileCreate,
-		Read:   resourceKeboolaFTPExtractorFileRead,
-		Update: resourceKeboolaFTPExtractorFileUpdate,
-		Delete: resourceKeboolaFTPExtractorFileDelete,
+		Create:        resourceKeboolaFTPExtractorFileCreate,
+		Read:          resourceKeboolaFTPExtractorFileRead,
+		Update:        resourceKeboolaFTPExtractorFileUpdate,
+		Delete:        resourceKeboolaFTPExtractorFileDelete,
+		CustomizeDiff: customizeBranchIDDiff,
 
 		Schema: map[string]*schema.Schema{
+			"branch_id":               &branchIDSchema,
+			"branch_id_from_provider": &branchIDFromProviderSchema,
 			"extractor_id": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -59,7 +61,7 @@ func resourceKeboolaFTPExtractorFileCreate(d *schema.ResourceData, meta interfac
 	client := meta.(*KBCClient)
 
 	extractorID := d.Get("extractor_id").(string)
-	createResponse, err := client.PostToStorage(fmt.Sprintf("storage/components/keboola.ex-ftp/configs/%s/rows", extractorID), createExtractorBuffer)
+	createResponse, err := client.PostToStorage(componentsURI(d, client, "keboola.ex-ftp/configs/%s/rows", extractorID), createExtractorBuffer)
 
 	if hasErrors(err, createResponse) {
 		return extractError(err, createResponse)
@@ -90,15 +92,17 @@ func resourceKeboolaFTPExtractorFileRead(d *schema.ResourceData, meta interface{
 
 	client := meta.(*KBCClient)
 
-	getResponse, err := client.GetFromStorage(fmt.Sprintf("storage/components/keboola.ex-ftp/configs/%s/rows/%s", extractorID, d.Id()))
+	getResponse, err := client.GetFromStorage(componentsURI(d, client, "keboola.ex-ftp/configs/%s/rows/%s", extractorID, d.Id()))
 
 	if hasErrors(err, getResponse) {
-		if getResponse.StatusCode == 404 {
+		err = extractError(err, getResponse)
+
+		if isNotFoundError(err) {
 			d.SetId("")
 			return nil
 		}
 
-		return extractError(err, getResponse)
+		return err
 	}
 
 	var ftpFile FTPFile
@@ -116,6 +120,8 @@ func resourceKeboolaFTPExtractorFileRead(d *schema.ResourceData, meta interface{
 	d.Set("description", ftpFile.Description)
 	d.Set("configuration", ftpFile.Configuration)
 
+	setBranchID(d, client)
+
 	return nil
 }
 
@@ -132,7 +138,7 @@ func resourceKeboolaFTPExtractorFileUpdate(d *schema.ResourceData, meta interfac
 	updateExtractorForm.Add("configuration", d.Get("configuration").(string))
 	updateExtractorBuffer := buffer.FromForm(updateExtractorForm)
 
-	updateExtractorResponse, err := client.PutToStorage(fmt.Sprintf("storage/components/keboola.ex-ftp/configs/%s/rows/%s", extractorID, d.Id()), updateExtractorBuffer)
+	updateExtractorResponse, err := client.PutToStorage(componentsURI(d, client, "keboola.ex-ftp/configs/%s/rows/%s", extractorID, d.Id()), updateExtractorBuffer)
 
 	if hasErrors(err, updateExtractorResponse) {
 		return extractError(err, updateExtractorResponse)
@@ -147,7 +153,7 @@ func resourceKeboolaFTPExtractorFileDelete(d *schema.ResourceData, meta interfac
 	extractorID := d.Get("extractor_id").(string)
 
 	client := meta.(*KBCClient)
-	destroyResponse, err := client.DeleteFromStorage(fmt.Sprintf("storage/components/keboola.ex-ftp/configs/%s/rows/%s", extractorID, d.Id()))
+	destroyResponse, err := client.DeleteFromStorage(componentsURI(d, client, "keboola.ex-ftp/configs/%s/rows/%s", extractorID, d.Id()))
 
 	if hasErrors(err, destroyResponse) {
 		return extractError(err, destroyResponse)
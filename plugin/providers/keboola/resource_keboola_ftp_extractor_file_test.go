@@ -109,3 +109,34 @@ const testFTPExtractorFileUpdate = `
 		description = "test file description updated"
 		configuration = "{ \"stuff\": { } }"
 	}`
+
+func TestUnitFTPExtractorFile_Basic(t *testing.T) {
+	mock := newMockKeboolaAPI()
+	defer mock.Close()
+
+	const filePath = "/v2/storage/components/keboola.ex-ftp/configs/%s/rows/%s"
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    mock.providers(),
+		CheckDestroy: mock.testCheckDestroy("keboola_ftp_extractor_file", filePath, "extractor_id", "id"),
+		Steps: []resource.TestStep{
+			{
+				Config: mock.config(testFTPExtractorFileBasic),
+				Check: resource.ComposeTestCheckFunc(
+					mock.testCheckExists("keboola_ftp_extractor_file.test_extractor_file", filePath, "extractor_id", "id"),
+					resource.TestCheckResourceAttr("keboola_ftp_extractor_file.test_extractor_file", "name", "test_extractor_file"),
+					resource.TestCheckResourceAttr("keboola_ftp_extractor_file.test_extractor_file", "description", "test file description"),
+				),
+			},
+			{
+				Config: mock.config(testFTPExtractorFileUpdate),
+				Check: resource.ComposeTestCheckFunc(
+					mock.testCheckExists("keboola_ftp_extractor_file.test_extractor_file", filePath, "extractor_id", "id"),
+					resource.TestCheckResourceAttr("keboola_ftp_extractor_file.test_extractor_file", "name", "test_extractor_file_updated"),
+					resource.TestCheckResourceAttr("keboola_ftp_extractor_file.test_extractor_file", "description", "test file description updated"),
+					resource.TestCheckResourceAttr("keboola_ftp_extractor_file.test_extractor_file", "configuration", "{ \"stuff\": { } }"),
+				),
+			},
+		},
+	})
+}
@@ -100,3 +100,39 @@ const testFTPExtractorUpdate = `
 		username = "test_username_updated"
 		hashed_password = "KBC::ProjectSecure::gibberish_goes_in_here_updated"
 	}`
+
+func TestUnitFTPExtractor_Basic(t *testing.T) {
+	mock := newMockKeboolaAPI()
+	defer mock.Close()
+
+	const extractorPath = "/v2/storage/components/keboola.ex-ftp/configs/%s"
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    mock.providers(),
+		CheckDestroy: mock.testCheckDestroy("keboola_ftp_extractor", extractorPath, "id"),
+		Steps: []resource.TestStep{
+			{
+				Config: mock.config(testFTPExtractorBasic),
+				Check: resource.ComposeTestCheckFunc(
+					mock.testCheckExists("keboola_ftp_extractor.test_extractor", extractorPath, "id"),
+					resource.TestCheckResourceAttr("keboola_ftp_extractor.test_extractor", "name", "test_extractor"),
+					resource.TestCheckResourceAttr("keboola_ftp_extractor.test_extractor", "host", "some.ftp.site"),
+					resource.TestCheckResourceAttr("keboola_ftp_extractor.test_extractor", "port", "22"),
+					resource.TestCheckResourceAttr("keboola_ftp_extractor.test_extractor", "username", "test_username"),
+				),
+			},
+			{
+				Config: mock.config(testFTPExtractorUpdate),
+				Check: resource.ComposeTestCheckFunc(
+					mock.testCheckExists("keboola_ftp_extractor.test_extractor", extractorPath, "id"),
+					resource.TestCheckResourceAttr("keboola_ftp_extractor.test_extractor", "name", "test_extractor updated"),
+					resource.TestCheckResourceAttr("keboola_ftp_extractor.test_extractor", "description", "test description updated"),
+					resource.TestCheckResourceAttr("keboola_ftp_extractor.test_extractor", "host", "some.other.ftp.site"),
+					resource.TestCheckResourceAttr("keboola_ftp_extractor.test_extractor", "port", "23"),
+					resource.TestCheckResourceAttr("keboola_ftp_extractor.test_extractor", "username", "test_username_updated"),
+					resource.TestCheckResourceAttr("keboola_ftp_extractor.test_extractor", "hashed_password", "KBC::ProjectSecure::gibberish_goes_in_here_updated"),
+				),
+			},
+		},
+	})
+}
@@ -2,7 +2,6 @@ package keboola
 
 import (
 	"encoding/json"
-	"fmt"
 	"log"
 	"net/url"
 
@@ -52,10 +51,13 @@ func resourceKeboolaGoodDataUserManagement() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: customizeBranchIDDiff,
 
 		DeprecationMessage: "keboola_gooddata_user_management has been deprecated and should be replaced with keboola_gooddata_user_management_v2",
 
 		Schema: map[string]*schema.Schema{
+			"branch_id":               &branchIDSchema,
+			"branch_id_from_provider": &branchIDFromProviderSchema,
 			"name": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -104,7 +106,7 @@ func resourceKeboolaGoodDataUserManagementCreate(d *schema.ResourceData, meta in
 	createUserManagementBuffer := buffer.FromForm(createUserManagementForm)
 
 	client := meta.(*KBCClient)
-	createResponse, err := client.PostToStorage(fmt.Sprintf("storage/components/gd-user-mgmt/configs"), createUserManagementBuffer)
+	createResponse, err := client.PostToStorage(componentsURI(d, client, "gd-user-mgmt/configs"), createUserManagementBuffer)
 
 	if hasErrors(err, createResponse) {
 		return extractError(err, createResponse)
@@ -128,19 +130,21 @@ func resourceKeboolaGoodDataUserManagementRead(d *schema.ResourceData, meta inte
 	log.Println("[INFO] Reading GoodData User Management settings from Keboola.")
 
 	client := meta.(*KBCClient)
-	getResponse, err := client.GetFromStorage(fmt.Sprintf("storage/components/gd-user-mgmt/configs/%s", d.Id()))
+	getResponse, err := client.GetFromStorage(componentsURI(d, client, "gd-user-mgmt/configs/%s", d.Id()))
 
 	if d.Id() == "" {
 		return nil
 	}
 
 	if hasErrors(err, getResponse) {
-		if getResponse.StatusCode == 404 {
+		err = extractError(err, getResponse)
+
+		if isNotFoundError(err) {
 			d.SetId("")
 			return nil
 		}
 
-		return extractError(err, getResponse)
+		return err
 	}
 
 	var goodDataUserManagement GoodDataUserManagement
@@ -158,9 +162,12 @@ func resourceKeboolaGoodDataUserManagementRead(d *schema.ResourceData, meta inte
 	d.Set("id", goodDataUserManagement.ID)
 	d.Set("name", goodDataUserManagement.Name)
 	d.Set("description", goodDataUserManagement.Description)
+	d.Set("writer", goodDataUserManagement.Configuration.Parameters.Writer)
 	d.Set("input", inputs)
 	d.Set("output", outputs)
 
+	setBranchID(d, client)
+
 	return nil
 }
 
@@ -194,7 +201,7 @@ func resourceKeboolaGoodDataUserManagementUpdate(d *schema.ResourceData, meta in
 	updateUserManagementBuffer := buffer.FromForm(updateUserManagementForm)
 
 	client := meta.(*KBCClient)
-	updateResponse, err := client.PutToStorage(fmt.Sprintf("storage/components/gd-user-mgmt/configs/%s", d.Id()), updateUserManagementBuffer)
+	updateResponse, err := client.PutToStorage(componentsURI(d, client, "gd-user-mgmt/configs/%s", d.Id()), updateUserManagementBuffer)
 
 	if hasErrors(err, updateResponse) {
 		return extractError(err, updateResponse)
@@ -207,7 +214,7 @@ func resourceKeboolaGoodDataUserManagementDelete(d *schema.ResourceData, meta in
 	log.Printf("[INFO] Deleting GoodData User Management in Keboola: %s", d.Id())
 
 	client := meta.(*KBCClient)
-	destroyResponse, err := client.DeleteFromStorage(fmt.Sprintf("storage/components/gd-user-mgmt/configs/%s", d.Id()))
+	destroyResponse, err := client.DeleteFromStorage(componentsURI(d, client, "gd-user-mgmt/configs/%s", d.Id()))
 
 	if hasErrors(err, destroyResponse) {
 		return extractError(err, destroyResponse)
@@ -54,3 +54,48 @@ const testUserManagementBasic = `
 		description = "test description"
 		writer = "testwriter"
 	}`
+
+const testUserManagementUpdate = `
+	resource "keboola_gooddata_user_management" "test_config" {
+		name = "new test name"
+		description = "new test description"
+		writer = "otherwriter"
+	}`
+
+func TestUnitGoodDataUserManagement_Basic(t *testing.T) {
+	mock := newMockKeboolaAPI()
+	defer mock.Close()
+
+	const userManagementPath = "/v2/storage/components/gd-user-mgmt/configs/%s"
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    mock.providers(),
+		CheckDestroy: mock.testCheckDestroy("keboola_gooddata_user_management", userManagementPath, "id"),
+		Steps: []resource.TestStep{
+			{
+				Config: mock.config(testUserManagementBasic),
+				Check: resource.ComposeTestCheckFunc(
+					mock.testCheckExists("keboola_gooddata_user_management.test_config", userManagementPath, "id"),
+					resource.TestCheckResourceAttr("keboola_gooddata_user_management.test_config", "name", "test name"),
+					resource.TestCheckResourceAttr("keboola_gooddata_user_management.test_config", "writer", "testwriter"),
+				),
+			},
+			{
+				Config: mock.config(testUserManagementUpdate),
+				Check: resource.ComposeTestCheckFunc(
+					mock.testCheckExists("keboola_gooddata_user_management.test_config", userManagementPath, "id"),
+					resource.TestCheckResourceAttr("keboola_gooddata_user_management.test_config", "name", "new test name"),
+					resource.TestCheckResourceAttr("keboola_gooddata_user_management.test_config", "description", "new test description"),
+					resource.TestCheckResourceAttr("keboola_gooddata_user_management.test_config", "writer", "otherwriter"),
+				),
+			},
+			{
+				Config:            mock.config(testUserManagementUpdate),
+				ResourceName:      "keboola_gooddata_user_management.test_config",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateCheck:  testCheckImportedAttribute("writer", "otherwriter"),
+			},
+		},
+	})
+}
@@ -3,7 +3,6 @@ package keboola
 import (
 	"bytes"
 	"encoding/json"
-	"fmt"
 	"net/url"
 
 	"github.com/hashicorp/terraform/helper/hashcode"
@@ -11,7 +10,7 @@ import (
 	"github.com/paybyphone/terraform-provider-keboola/plugin/providers/keboola/buffer"
 )
 
-const userManagementComponentTemplate = "storage/components/kds-team.app-gd-user-management/configs/%s"
+const userManagementComponentTemplate = "kds-team.app-gd-user-management/configs/%s"
 
 type GoodDataUserManagementComponentV2 struct {
 	Name          string                                `json:"name"`
@@ -57,8 +56,11 @@ func resourceKeboolaGoodDataUserManagementV2() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: customizeBranchIDDiff,
 
 		Schema: map[string]*schema.Schema{
+			"branch_id":               &branchIDSchema,
+			"branch_id_from_provider": &branchIDFromProviderSchema,
 			"name": {
 				Required: true,
 				Type:     schema.TypeString,
@@ -138,7 +140,7 @@ func resourceGoodDataUserManagementCreateV2(d *schema.ResourceData, meta interfa
 		return err
 	}
 
-	res, err := meta.(*KBCClient).PostToStorage(fmt.Sprintf(userManagementComponentTemplate, ""), componentAsBuff)
+	res, err := meta.(*KBCClient).PostToStorage(componentsURI(d, meta.(*KBCClient), userManagementComponentTemplate, ""), componentAsBuff)
 	if hasErrors(err, res) {
 		return extractError(err, res)
 	}
@@ -154,15 +156,17 @@ func resourceGoodDataUserManagementCreateV2(d *schema.ResourceData, meta interfa
 }
 
 func resourceGoodDataUserManagementReadV2(d *schema.ResourceData, meta interface{}) error {
-	res, err := meta.(*KBCClient).GetFromStorage(fmt.Sprintf(userManagementComponentTemplate, d.Id()))
+	res, err := meta.(*KBCClient).GetFromStorage(componentsURI(d, meta.(*KBCClient), userManagementComponentTemplate, d.Id()))
 
 	if hasErrors(err, res) {
-		if res.StatusCode == 404 {
+		err = extractError(err, res)
+
+		if isNotFoundError(err) {
 			d.SetId("")
 			return nil
 		}
 
-		return extractError(err, res)
+		return err
 	}
 
 	component := GoodDataUserManagementComponentV2{}
@@ -199,6 +203,8 @@ func resourceGoodDataUserManagementReadV2(d *schema.ResourceData, meta interface
 		return hashcode.String(i.(map[string]interface{})["source"].(string))
 	}, inputTables))
 
+	setBranchID(d, meta.(*KBCClient))
+
 	return nil
 }
 
@@ -209,7 +215,7 @@ func resourceGoodDataUserManagementUpdateV2(d *schema.ResourceData, meta interfa
 		return err
 	}
 
-	res, err := meta.(*KBCClient).PutToStorage(fmt.Sprintf(userManagementComponentTemplate, d.Id()), componentAsBuff)
+	res, err := meta.(*KBCClient).PutToStorage(componentsURI(d, meta.(*KBCClient), userManagementComponentTemplate, d.Id()), componentAsBuff)
 	if hasErrors(err, res) {
 		return extractError(err, res)
 	}
@@ -218,7 +224,7 @@ func resourceGoodDataUserManagementUpdateV2(d *schema.ResourceData, meta interfa
 }
 
 func resourceGoodDataUserManagementDeleteV2(d *schema.ResourceData, meta interface{}) error {
-	res, err := meta.(*KBCClient).DeleteFromStorage(fmt.Sprintf(userManagementComponentTemplate, d.Id()))
+	res, err := meta.(*KBCClient).DeleteFromStorage(componentsURI(d, meta.(*KBCClient), userManagementComponentTemplate, d.Id()))
 	if hasErrors(err, res) {
 		return extractError(err, res)
 	}
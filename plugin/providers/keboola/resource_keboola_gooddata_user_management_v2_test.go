@@ -59,3 +59,62 @@ const testUserManagementV2Basic = `
 		login = "some-login"
 		hashed_password = "KBC::ProjectSecure::pass"
 	}`
+
+const testUserManagementV2Update = `
+	resource "keboola_gooddata_user_management_v2" "test_config" {
+		name = "new test name"
+		description = "new test description"
+		custom_domain = "other-domain"
+		project_id = "456"
+		login = "other-login"
+		hashed_password = "KBC::ProjectSecure::otherpass"
+
+		input_tables {
+			source = "in.c-users.users"
+			destination = "users.csv"
+			where_column = "active"
+			where_values = [ "1" ]
+			columns = [ "login", "role" ]
+		}
+	}`
+
+func TestUnitGoodDataUserManagement_V2_Basic(t *testing.T) {
+	mock := newMockKeboolaAPI()
+	defer mock.Close()
+
+	const userManagementPath = "/v2/storage/components/kds-team.app-gd-user-management/configs/%s"
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    mock.providers(),
+		CheckDestroy: mock.testCheckDestroy("keboola_gooddata_user_management_v2", userManagementPath, "id"),
+		Steps: []resource.TestStep{
+			{
+				Config: mock.config(testUserManagementV2Basic),
+				Check: resource.ComposeTestCheckFunc(
+					mock.testCheckExists("keboola_gooddata_user_management_v2.test_config", userManagementPath, "id"),
+					resource.TestCheckResourceAttr("keboola_gooddata_user_management_v2.test_config", "name", "test name"),
+					resource.TestCheckResourceAttr("keboola_gooddata_user_management_v2.test_config", "project_id", "123"),
+					resource.TestCheckResourceAttr("keboola_gooddata_user_management_v2.test_config", "login", "some-login"),
+					resource.TestCheckResourceAttr("keboola_gooddata_user_management_v2.test_config", "custom_domain", "domain"),
+				),
+			},
+			{
+				Config: mock.config(testUserManagementV2Update),
+				Check: resource.ComposeTestCheckFunc(
+					mock.testCheckExists("keboola_gooddata_user_management_v2.test_config", userManagementPath, "id"),
+					resource.TestCheckResourceAttr("keboola_gooddata_user_management_v2.test_config", "name", "new test name"),
+					resource.TestCheckResourceAttr("keboola_gooddata_user_management_v2.test_config", "project_id", "456"),
+					resource.TestCheckResourceAttr("keboola_gooddata_user_management_v2.test_config", "login", "other-login"),
+					resource.TestCheckResourceAttr("keboola_gooddata_user_management_v2.test_config", "hashed_password", "KBC::ProjectSecure::otherpass"),
+					resource.TestCheckResourceAttr("keboola_gooddata_user_management_v2.test_config", "input_tables.#", "1"),
+				),
+			},
+			{
+				Config:            mock.config(testUserManagementV2Update),
+				ResourceName:      "keboola_gooddata_user_management_v2.test_config",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -6,7 +6,6 @@ import (
 	"fmt"
 	"log"
 	"net/url"
-	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform/helper/schema"
@@ -38,10 +37,17 @@ func resourceKeboolaGoodDataWriter() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: customizeBranchIDDiff,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultJobTimeout),
+		},
 
 		DeprecationMessage: "keboola_gooddata_writer has been deprecated and should be replaced with keboola_gooddata_writer_v3",
 
 		Schema: map[string]*schema.Schema{
+			"branch_id":               &branchIDSchema,
+			"branch_id_from_provider": &branchIDFromProviderSchema,
 			"writer_id": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -69,13 +75,13 @@ func resourceKeboolaGoodDataWriterCreate(d *schema.ResourceData, meta interface{
 	writerID := d.Get("writer_id").(string)
 	client := meta.(*KBCClient)
 
-	err := provisionGoodDataProject(writerID, d.Get("description").(string), d.Get("auth_token").(string), client)
+	err := provisionGoodDataProject(writerID, d.Get("description").(string), d.Get("auth_token").(string), d.Timeout(schema.TimeoutCreate), client)
 
 	if err != nil {
 		return err
 	}
 
-	createdConfigID, err := createGoodDataWriterConfiguration(writerID, d.Get("name").(string), d.Get("description").(string), client)
+	createdConfigID, err := createGoodDataWriterConfiguration(d, writerID, d.Get("name").(string), d.Get("description").(string), client)
 
 	if err != nil {
 		return err
@@ -86,7 +92,7 @@ func resourceKeboolaGoodDataWriterCreate(d *schema.ResourceData, meta interface{
 	return resourceKeboolaGoodDataWriterRead(d, meta)
 }
 
-func provisionGoodDataProject(writerID string, description string, authToken string, client *KBCClient) error {
+func provisionGoodDataProject(writerID string, description string, authToken string, timeout time.Duration, client *KBCClient) error {
 	createProject := CreateGoodDataProject{
 		WriterID:    writerID,
 		Description: description,
@@ -105,8 +111,7 @@ func provisionGoodDataProject(writerID string, description string, authToken str
 		return extractError(err, createWriterResp)
 	}
 
-	createWriterStatus := "waiting"
-	var createWriterStatusRes StorageJobStatus
+	var createWriterStatusRes SyrupJobStatus
 
 	createWriterDecoder := json.NewDecoder(createWriterResp.Body)
 	err = createWriterDecoder.Decode(&createWriterStatusRes)
@@ -115,41 +120,19 @@ func provisionGoodDataProject(writerID string, description string, authToken str
 		return err
 	}
 
-	jobURL, err := url.Parse(createWriterStatusRes.URL)
-
-	if err != nil {
-		return err
-	}
-
-	for createWriterStatus != "success" && createWriterStatus != "error" {
-		jobStatusResp, jobErr := client.GetFromSyrup(strings.TrimLeft(jobURL.Path, "/"))
-
-		if hasErrors(jobErr, jobStatusResp) {
-			return extractError(jobErr, jobStatusResp)
-		}
-
-		decoder := json.NewDecoder(jobStatusResp.Body)
-		err = decoder.Decode(&createWriterStatusRes)
-
-		if err != nil {
-			return err
-		}
-
-		time.Sleep(250 * time.Millisecond)
-		createWriterStatus = createWriterStatusRes.Status
-	}
+	_, err = client.waitForSyrupJob(createWriterStatusRes.URL, timeout)
 
-	return nil
+	return err
 }
 
-func createGoodDataWriterConfiguration(writerID string, name string, description string, client *KBCClient) (createdID string, err error) {
+func createGoodDataWriterConfiguration(d *schema.ResourceData, writerID string, name string, description string, client *KBCClient) (createdID string, err error) {
 	form := url.Values{}
 	form.Add("name", name)
 	form.Add("description", description)
 
 	formdataBuffer := buffer.FromForm(form)
 
-	createWriterConfigResp, err := client.PutToStorage(fmt.Sprintf("storage/components/gooddata-writer/configs/%s", writerID), formdataBuffer)
+	createWriterConfigResp, err := client.PutToStorage(componentsURI(d, client, "gooddata-writer/configs/%s", writerID), formdataBuffer)
 
 	if err != nil {
 		return "", err
@@ -179,15 +162,17 @@ func resourceKeboolaGoodDataWriterRead(d *schema.ResourceData, meta interface{})
 	}
 
 	client := meta.(*KBCClient)
-	getResp, err := client.GetFromStorage(fmt.Sprintf("storage/components/gooddata-writer/configs/%s", d.Id()))
+	getResp, err := client.GetFromStorage(componentsURI(d, client, "gooddata-writer/configs/%s", d.Id()))
 
 	if hasErrors(err, getResp) {
-		if getResp.StatusCode == 404 {
+		err = extractError(err, getResp)
+
+		if isNotFoundError(err) {
 			d.SetId("")
 			return nil
 		}
 
-		return extractError(err, getResp)
+		return err
 	}
 
 	var goodDataWriter GoodDataWriter
@@ -200,9 +185,12 @@ func resourceKeboolaGoodDataWriterRead(d *schema.ResourceData, meta interface{})
 	}
 
 	d.Set("id", goodDataWriter.ID)
+	d.Set("writer_id", goodDataWriter.ID)
 	d.Set("name", goodDataWriter.Name)
 	d.Set("description", goodDataWriter.Description)
 
+	setBranchID(d, client)
+
 	return nil
 }
 
@@ -215,7 +203,7 @@ func resourceKeboolaGoodDataWriterUpdate(d *schema.ResourceData, meta interface{
 
 	client := meta.(*KBCClient)
 	formdataBuffer := buffer.FromForm(form)
-	putResp, err := client.PutToStorage(fmt.Sprintf("storage/components/gooddata-writer/configs/%s", d.Id()), formdataBuffer)
+	putResp, err := client.PutToStorage(componentsURI(d, client, "gooddata-writer/configs/%s", d.Id()), formdataBuffer)
 
 	if err != nil {
 		return err
@@ -238,7 +226,7 @@ func resourceKeboolaGoodDataWriterDelete(d *schema.ResourceData, meta interface{
 		return extractError(err, delFromSyrupResp)
 	}
 
-	delFromStorageResp, err := client.DeleteFromStorage(fmt.Sprintf("storage/components/gooddata-writer/configs/%s", d.Id()))
+	delFromStorageResp, err := client.DeleteFromStorage(componentsURI(d, client, "gooddata-writer/configs/%s", d.Id()))
 
 	if hasErrors(err, delFromStorageResp) {
 		return extractError(err, delFromStorageResp)
@@ -5,11 +5,17 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"net/http"
+	"strings"
 
 	"github.com/hashicorp/terraform/helper/hashcode"
 	"github.com/hashicorp/terraform/helper/schema"
 )
 
+//goodDataTableNotFoundMessage is how the message of the user error (400) that the GoodData Writer
+//returns for a table which does not exist starts, as it does not report missing tables with a 404.
+const goodDataTableNotFoundMessage = "Table %s not found"
+
 type GoodDataColumn struct {
 	Name            string `json:"name"`
 	DataType        string `json:"dataType"`
@@ -186,12 +192,14 @@ func resourceKeboolaGoodDataTableRead(d *schema.ResourceData, meta interface{})
 	getResponse, err := client.GetFromSyrup(fmt.Sprintf("gooddata-writer/v2/%s/tables/%s?include=columns", writerID, d.Id()))
 
 	if hasErrors(err, getResponse) {
-		if getResponse.StatusCode == 400 || getResponse.StatusCode == 404 {
+		err = extractError(err, getResponse)
+
+		if isNotFoundError(err) || isGoodDataTableNotFoundError(err, d.Id()) {
 			d.SetId("")
 			return nil
 		}
 
-		return extractError(err, getResponse)
+		return err
 	}
 
 	var goodDataTable GoodDataTable
@@ -233,6 +241,13 @@ func resourceKeboolaGoodDataTableRead(d *schema.ResourceData, meta interface{})
 	return nil
 }
 
+//isGoodDataTableNotFoundError returns whether an error is the user error the GoodData Writer returns for a table
+//which does not exist, rather than any other user error (such as a bad request, or a writer which does not exist).
+func isGoodDataTableNotFoundError(err error, tableID string) bool {
+	apiError, ok := err.(*KBCAPIError)
+	return ok && apiError.StatusCode == http.StatusBadRequest && strings.HasPrefix(apiError.Message, fmt.Sprintf(goodDataTableNotFoundMessage, tableID))
+}
+
 func columnSetHash(v interface{}) int {
 	var buffer bytes.Buffer
 	m := v.(map[string]interface{})
@@ -0,0 +1,112 @@
+package keboola
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestUnitGoodDataWriterTable_Basic(t *testing.T) {
+	mock := newMockKeboolaAPI()
+	defer mock.Close()
+
+	const tablePath = "/gooddata-writer/v2/%s/tables/%s"
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    mock.providers(),
+		CheckDestroy: mock.testCheckDestroy("keboola_gooddata_writer_table", tablePath, "writer_id", "id"),
+		Steps: []resource.TestStep{
+			{
+				Config: mock.config(testGoodDataWriterTableBasic),
+				Check: resource.ComposeTestCheckFunc(
+					mock.testCheckExists("keboola_gooddata_writer_table.test_table", tablePath, "writer_id", "id"),
+					resource.TestCheckResourceAttr("keboola_gooddata_writer_table.test_table", "id", "orders"),
+					resource.TestCheckResourceAttr("keboola_gooddata_writer_table.test_table", "export", "false"),
+					resource.TestCheckResourceAttr("keboola_gooddata_writer_table.test_table", "column.#", "1"),
+				),
+			},
+			{
+				Config: mock.config(testGoodDataWriterTableUpdate),
+				Check: resource.ComposeTestCheckFunc(
+					mock.testCheckExists("keboola_gooddata_writer_table.test_table", tablePath, "writer_id", "id"),
+					resource.TestCheckResourceAttr("keboola_gooddata_writer_table.test_table", "export", "true"),
+					resource.TestCheckResourceAttr("keboola_gooddata_writer_table.test_table", "identifier", "dataset.orders"),
+					resource.TestCheckResourceAttr("keboola_gooddata_writer_table.test_table", "incremental_days", "7"),
+					resource.TestCheckResourceAttr("keboola_gooddata_writer_table.test_table", "column.#", "2"),
+				),
+			},
+			{
+				PreConfig: func() {
+					mock.mutex.Lock()
+					defer mock.mutex.Unlock()
+
+					delete(mock.goodDataTables, "testwriter/orders")
+				},
+				Config: mock.config(testGoodDataWriterTableUpdate),
+				Check: resource.ComposeTestCheckFunc(
+					mock.testCheckExists("keboola_gooddata_writer_table.test_table", tablePath, "writer_id", "id"),
+					resource.TestCheckResourceAttr("keboola_gooddata_writer_table.test_table", "export", "true"),
+				),
+			},
+			{
+				PreConfig: func() {
+					mock.mutex.Lock()
+					defer mock.mutex.Unlock()
+
+					writers := mock.components["gooddata-writer"]
+					writers["movedwriter"] = writers["testwriter"]
+					delete(writers, "testwriter")
+				},
+				Config:      mock.config(testGoodDataWriterTableUpdate),
+				ExpectError: regexp.MustCompile("Writer testwriter does not exist"),
+			},
+		},
+	})
+}
+
+const testGoodDataWriterTableBasic = `
+	resource "keboola_gooddata_writer" "test_writer" {
+		writer_id = "testwriter"
+		name = "test name"
+	}
+
+	resource "keboola_gooddata_writer_table" "test_table" {
+		writer_id = "${keboola_gooddata_writer.test_writer.id}"
+		title = "orders"
+		export = false
+
+		column {
+			name = "id"
+			title = "Order ID"
+			type = "CONNECTION_POINT"
+		}
+	}`
+
+const testGoodDataWriterTableUpdate = `
+	resource "keboola_gooddata_writer" "test_writer" {
+		writer_id = "testwriter"
+		name = "test name"
+	}
+
+	resource "keboola_gooddata_writer_table" "test_table" {
+		writer_id = "${keboola_gooddata_writer.test_writer.id}"
+		title = "orders"
+		identifier = "dataset.orders"
+		export = true
+		incremental_days = 7
+
+		column {
+			name = "id"
+			title = "Order ID"
+			type = "CONNECTION_POINT"
+		}
+
+		column {
+			name = "amount"
+			title = "Amount"
+			type = "FACT"
+			data_type = "DECIMAL"
+			data_type_size = "12,2"
+		}
+	}`
@@ -0,0 +1,60 @@
+package keboola
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestUnitGoodDataWriter_Basic(t *testing.T) {
+	mock := newMockKeboolaAPI()
+	defer mock.Close()
+
+	const writerPath = "/v2/storage/components/gooddata-writer/configs/%s"
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    mock.providers(),
+		CheckDestroy: mock.testCheckDestroy("keboola_gooddata_writer", writerPath, "id"),
+		Steps: []resource.TestStep{
+			{
+				Config: mock.config(testGoodDataWriterBasic),
+				Check: resource.ComposeTestCheckFunc(
+					mock.testCheckExists("keboola_gooddata_writer.test_writer", writerPath, "id"),
+					resource.TestCheckResourceAttr("keboola_gooddata_writer.test_writer", "id", "testwriter"),
+					resource.TestCheckResourceAttr("keboola_gooddata_writer.test_writer", "name", "test name"),
+					resource.TestCheckResourceAttr("keboola_gooddata_writer.test_writer", "description", "test description"),
+				),
+			},
+			{
+				Config: mock.config(testGoodDataWriterUpdate),
+				Check: resource.ComposeTestCheckFunc(
+					mock.testCheckExists("keboola_gooddata_writer.test_writer", writerPath, "id"),
+					resource.TestCheckResourceAttr("keboola_gooddata_writer.test_writer", "name", "new test name"),
+					resource.TestCheckResourceAttr("keboola_gooddata_writer.test_writer", "description", "new test description"),
+				),
+			},
+			{
+				Config:                  mock.config(testGoodDataWriterUpdate),
+				ResourceName:            "keboola_gooddata_writer.test_writer",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"auth_token"},
+				ImportStateCheck:        testCheckImportedAttribute("writer_id", "testwriter"),
+			},
+		},
+	})
+}
+
+const testGoodDataWriterBasic = `
+	resource "keboola_gooddata_writer" "test_writer" {
+		writer_id = "testwriter"
+		name = "test name"
+		description = "test description"
+	}`
+
+const testGoodDataWriterUpdate = `
+	resource "keboola_gooddata_writer" "test_writer" {
+		writer_id = "testwriter"
+		name = "new test name"
+		description = "new test description"
+	}`
@@ -3,7 +3,6 @@ package keboola
 import (
 	"bytes"
 	"encoding/json"
-	"fmt"
 	"net/url"
 
 	"github.com/hashicorp/terraform/helper/hashcode"
@@ -11,7 +10,7 @@ import (
 	"github.com/paybyphone/terraform-provider-keboola/plugin/providers/keboola/buffer"
 )
 
-const goodDataWriterComponentTemplate = "storage/components/keboola.gooddata-writer/configs/%s"
+const goodDataWriterComponentTemplate = "keboola.gooddata-writer/configs/%s"
 
 type DateDimension struct {
 	Identifier  string `json:"identifier,omitempty"`
@@ -79,8 +78,11 @@ func resourceKeboolaGoodDataWriterV3() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: customizeBranchIDDiff,
 
 		Schema: map[string]*schema.Schema{
+			"branch_id":               &branchIDSchema,
+			"branch_id_from_provider": &branchIDFromProviderSchema,
 			"project_id": {
 				Required: true,
 				Type:     schema.TypeString,
@@ -212,7 +214,7 @@ func resourceKeboolaGoodDataWriterV3Create(d *schema.ResourceData, meta interfac
 		return err
 	}
 
-	res, err := meta.(*KBCClient).PostToStorage(fmt.Sprintf(goodDataWriterComponentTemplate, ""), componentAsBuffer)
+	res, err := meta.(*KBCClient).PostToStorage(componentsURI(d, meta.(*KBCClient), goodDataWriterComponentTemplate, ""), componentAsBuffer)
 	if hasErrors(err, res) {
 		return extractError(err, res)
 	}
@@ -228,15 +230,17 @@ func resourceKeboolaGoodDataWriterV3Create(d *schema.ResourceData, meta interfac
 }
 
 func resourceKeboolaGoodDataWriterV3Read(d *schema.ResourceData, meta interface{}) error {
-	res, err := meta.(*KBCClient).GetFromStorage(fmt.Sprintf(goodDataWriterComponentTemplate, d.Id()))
+	res, err := meta.(*KBCClient).GetFromStorage(componentsURI(d, meta.(*KBCClient), goodDataWriterComponentTemplate, d.Id()))
 
 	if hasErrors(err, res) {
-		if res.StatusCode == 404 {
+		err = extractError(err, res)
+
+		if isNotFoundError(err) {
 			d.SetId("")
 			return nil
 		}
 
-		return extractError(err, res)
+		return err
 	}
 
 	component := GoodDataWriterComponent{}
@@ -247,6 +251,11 @@ func resourceKeboolaGoodDataWriterV3Read(d *schema.ResourceData, meta interface{
 
 	parameters := component.Configuration.Parameters
 
+	changedSince := make(map[string]string)
+	for _, storageTable := range component.Configuration.Storage.Input.Tables {
+		changedSince[storageTable.Source] = storageTable.ChangedSince
+	}
+
 	tables := make([]interface{}, 0, len(parameters.Tables))
 	for _, table := range parameters.Tables {
 		columns := make([]interface{}, 0, len(table.Columns))
@@ -270,7 +279,7 @@ func resourceKeboolaGoodDataWriterV3Read(d *schema.ResourceData, meta interface{
 		tableDetails := map[string]interface{}{
 			"identifier":    table.Identifier,
 			"title":         table.Title,
-			"changed_since": table.IncrementalDays,
+			"changed_since": changedSince[table.Title],
 			"columns":       columns,
 		}
 
@@ -305,6 +314,8 @@ func resourceKeboolaGoodDataWriterV3Read(d *schema.ResourceData, meta interface{
 		return hashcode.String(i.(map[string]interface{})["name"].(string))
 	}, dateDimensions))
 
+	setBranchID(d, meta.(*KBCClient))
+
 	return nil
 }
 
@@ -313,7 +324,7 @@ func resourceKeboolaGoodDataWriterV3Update(d *schema.ResourceData, meta interfac
 	if err != nil {
 		return err
 	}
-	res, err := meta.(*KBCClient).PutToStorage(fmt.Sprintf(goodDataWriterComponentTemplate, d.Id()), componentAsBuffer)
+	res, err := meta.(*KBCClient).PutToStorage(componentsURI(d, meta.(*KBCClient), goodDataWriterComponentTemplate, d.Id()), componentAsBuffer)
 	if hasErrors(err, res) {
 		return extractError(err, res)
 	}
@@ -321,7 +332,7 @@ func resourceKeboolaGoodDataWriterV3Update(d *schema.ResourceData, meta interfac
 }
 
 func resourceKeboolaGoodDataWriterV3Delete(d *schema.ResourceData, meta interface{}) error {
-	res, err := meta.(*KBCClient).DeleteFromStorage(fmt.Sprintf(goodDataWriterComponentTemplate, d.Id()))
+	res, err := meta.(*KBCClient).DeleteFromStorage(componentsURI(d, meta.(*KBCClient), goodDataWriterComponentTemplate, d.Id()))
 	if hasErrors(err, res) {
 		return extractError(err, res)
 	}
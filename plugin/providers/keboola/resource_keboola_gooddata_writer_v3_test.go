@@ -2,6 +2,7 @@ package keboola
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform/helper/resource"
@@ -60,3 +61,104 @@ const testGoodDataWriterV3Basic = `
 		hashed_password = "KBC::ProjectSecure::pass"
 		load_only =  true
 	}`
+
+const testGoodDataWriterV3Update = `
+	resource "keboola_gooddata_writer_v3" "test_config" {
+		name = "new test name"
+		description = "new test description"
+		project_id = "456"
+		login = "other-login"
+		hashed_password = "KBC::ProjectSecure::otherpass"
+		multi_load = true
+
+		date_dimensions {
+			name = "order_date"
+			template = "gooddata"
+			include_time = true
+		}
+
+		tables {
+			title = "orders"
+			identifier = "dataset.orders"
+			changed_since = "-1 days"
+
+			columns {
+				column_name = "id"
+				title = "Order ID"
+				type = "CONNECTION_POINT"
+			}
+
+			columns {
+				column_name = "created"
+				title = "Created"
+				type = "DATE"
+				format = "yyyy-MM-dd"
+				date_dimension = "order_date"
+			}
+		}
+	}`
+
+func TestUnitGoodDataWriter_V3_Basic(t *testing.T) {
+	mock := newMockKeboolaAPI()
+	defer mock.Close()
+
+	const writerPath = "/v2/storage/components/keboola.gooddata-writer/configs/%s"
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    mock.providers(),
+		CheckDestroy: mock.testCheckDestroy("keboola_gooddata_writer_v3", writerPath, "id"),
+		Steps: []resource.TestStep{
+			{
+				Config: mock.config(testGoodDataWriterV3Basic),
+				Check: resource.ComposeTestCheckFunc(
+					mock.testCheckExists("keboola_gooddata_writer_v3.test_config", writerPath, "id"),
+					resource.TestCheckResourceAttr("keboola_gooddata_writer_v3.test_config", "name", "test name"),
+					resource.TestCheckResourceAttr("keboola_gooddata_writer_v3.test_config", "project_id", "123"),
+					resource.TestCheckResourceAttr("keboola_gooddata_writer_v3.test_config", "load_only", "true"),
+					resource.TestCheckResourceAttr("keboola_gooddata_writer_v3.test_config", "multi_load", "false"),
+				),
+			},
+			{
+				Config: mock.config(testGoodDataWriterV3Update),
+				Check: resource.ComposeTestCheckFunc(
+					mock.testCheckExists("keboola_gooddata_writer_v3.test_config", writerPath, "id"),
+					resource.TestCheckResourceAttr("keboola_gooddata_writer_v3.test_config", "name", "new test name"),
+					resource.TestCheckResourceAttr("keboola_gooddata_writer_v3.test_config", "project_id", "456"),
+					resource.TestCheckResourceAttr("keboola_gooddata_writer_v3.test_config", "login", "other-login"),
+					resource.TestCheckResourceAttr("keboola_gooddata_writer_v3.test_config", "load_only", "false"),
+					resource.TestCheckResourceAttr("keboola_gooddata_writer_v3.test_config", "multi_load", "true"),
+					resource.TestCheckResourceAttr("keboola_gooddata_writer_v3.test_config", "date_dimensions.#", "1"),
+					resource.TestCheckResourceAttr("keboola_gooddata_writer_v3.test_config", "tables.#", "1"),
+					func(s *terraform.State) error {
+						return testCheckGoodDataWriterV3ChangedSince(s.RootModule().Resources["keboola_gooddata_writer_v3.test_config"].Primary, "-1 days")
+					},
+				),
+			},
+			{
+				Config:            mock.config(testGoodDataWriterV3Update),
+				ResourceName:      "keboola_gooddata_writer_v3.test_config",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					return testCheckGoodDataWriterV3ChangedSince(states[0], "-1 days")
+				},
+			},
+		},
+	})
+}
+
+//testCheckGoodDataWriterV3ChangedSince checks that changed_since of the (only) table was read back
+//from the input mapping of the writer. The tables are a set, so their keys hold a hash rather than an index.
+func testCheckGoodDataWriterV3ChangedSince(state *terraform.InstanceState, expected string) error {
+	for key, value := range state.Attributes {
+		if strings.HasPrefix(key, "tables.") && strings.HasSuffix(key, ".changed_since") {
+			if value != expected {
+				return fmt.Errorf("Expected %s to be %q, got %q", key, expected, value)
+			}
+
+			return nil
+		}
+	}
+
+	return fmt.Errorf("changed_since of the GoodData Writer table was not read back")
+}
@@ -163,12 +163,14 @@ func resourceKeboolaOrchestrationRead(d *schema.ResourceData, meta interface{})
 	getResponse, err := client.GetFromSyrup(fmt.Sprintf("orchestrator/orchestrations/%s", d.Id()))
 
 	if hasErrors(err, getResponse) {
-		if getResponse.StatusCode == 404 {
+		err = extractError(err, getResponse)
+
+		if isNotFoundError(err) {
 			d.SetId("")
 			return nil
 		}
 
-		return extractError(err, getResponse)
+		return err
 	}
 
 	var orchestration Orchestration
@@ -240,12 +242,14 @@ func resourceKeboolaOrchestrationDelete(d *schema.ResourceData, meta interface{}
 	getResponse, err := client.GetFromSyrup(fmt.Sprintf("orchestrator/orchestrations/%s", d.Id()))
 
 	if hasErrors(err, getResponse) {
-		if getResponse.StatusCode == 404 {
+		err = extractError(err, getResponse)
+
+		if isNotFoundError(err) {
 			d.SetId("")
 			return nil
 		}
 
-		return extractError(err, getResponse)
+		return err
 	}
 
 	var orchestration Orchestration
@@ -142,12 +142,14 @@ func resourceKeboolaOrchestrationTasksRead(d *schema.ResourceData, meta interfac
 	getResponse, err := client.GetFromSyrup(fmt.Sprintf("orchestrator/orchestrations/%s/tasks", d.Id()))
 
 	if hasErrors(err, getResponse) {
-		if getResponse.StatusCode == 404 {
+		err = extractError(err, getResponse)
+
+		if isNotFoundError(err) {
 			d.SetId("")
 			return nil
 		}
 
-		return extractError(err, getResponse)
+		return err
 	}
 
 	var orchestrationTasks []OrchestrationTask
@@ -0,0 +1,86 @@
+package keboola
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestUnitOrchestrationTasks_Basic(t *testing.T) {
+	mock := newMockKeboolaAPI()
+	defer mock.Close()
+
+	const tasksPath = "/orchestrator/orchestrations/%s/tasks"
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    mock.providers(),
+		CheckDestroy: mock.testCheckDestroy("keboola_orchestration_tasks", tasksPath, "id"),
+		Steps: []resource.TestStep{
+			{
+				Config: mock.config(testOrchestrationTasksBasic),
+				Check: resource.ComposeTestCheckFunc(
+					mock.testCheckExists("keboola_orchestration_tasks.test_tasks", tasksPath, "id"),
+					resource.TestCheckResourceAttrPair("keboola_orchestration_tasks.test_tasks", "orchestration_id", "keboola_orchestration.test_orchestration", "id"),
+					resource.TestCheckResourceAttr("keboola_orchestration_tasks.test_tasks", "task.#", "1"),
+					resource.TestCheckResourceAttr("keboola_orchestration_tasks.test_tasks", "task.0.component", "keboola.ex-db-snowflake"),
+					resource.TestCheckResourceAttr("keboola_orchestration_tasks.test_tasks", "task.0.is_active", "true"),
+				),
+			},
+			{
+				Config: mock.config(testOrchestrationTasksUpdate),
+				Check: resource.ComposeTestCheckFunc(
+					mock.testCheckExists("keboola_orchestration_tasks.test_tasks", tasksPath, "id"),
+					resource.TestCheckResourceAttr("keboola_orchestration_tasks.test_tasks", "task.#", "2"),
+					resource.TestCheckResourceAttr("keboola_orchestration_tasks.test_tasks", "task.0.timeout", "30"),
+					resource.TestCheckResourceAttr("keboola_orchestration_tasks.test_tasks", "task.1.component", "transformation"),
+					resource.TestCheckResourceAttr("keboola_orchestration_tasks.test_tasks", "task.1.phase", "transform"),
+					resource.TestCheckResourceAttr("keboola_orchestration_tasks.test_tasks", "task.1.continue_on_failure", "true"),
+				),
+			},
+		},
+	})
+}
+
+const testOrchestrationTasksBasic = `
+resource "keboola_orchestration" "test_orchestration" {
+	name = "test name"
+}
+
+resource "keboola_orchestration_tasks" "test_tasks" {
+	orchestration_id = "${keboola_orchestration.test_orchestration.id}"
+
+	task {
+		component         = "keboola.ex-db-snowflake"
+		action            = "run"
+		action_parameters = "{\"config\":\"123\"}"
+		is_active         = true
+		phase             = "extract"
+	}
+}`
+
+const testOrchestrationTasksUpdate = `
+resource "keboola_orchestration" "test_orchestration" {
+	name = "test name"
+}
+
+resource "keboola_orchestration_tasks" "test_tasks" {
+	orchestration_id = "${keboola_orchestration.test_orchestration.id}"
+
+	task {
+		component         = "keboola.ex-db-snowflake"
+		action            = "run"
+		action_parameters = "{\"config\":\"123\"}"
+		timeout           = 30
+		is_active         = true
+		phase             = "extract"
+	}
+
+	task {
+		component           = "transformation"
+		action              = "run"
+		action_parameters   = "{\"config\":\"456\",\"phases\":[1]}"
+		is_active           = true
+		continue_on_failure = true
+		phase               = "transform"
+	}
+}`
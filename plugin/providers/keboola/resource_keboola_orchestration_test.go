@@ -86,3 +86,85 @@ resource "keboola_orchestration" "test_orchestration" {
 		channel = "error"
 	}
 }`
+
+const testOrchestrationUpdate = `
+resource "keboola_orchestration" "test_orchestration" {
+	name          = "new test name"
+	enabled       = false
+	schedule_cron = "0 6 * * *"
+
+	notification {
+		email   = "hopefullydoesnot.exist@anywhere.cheese"
+		channel = "error"
+	}
+
+	notification {
+		email   = "alsodoesnot.exist@anywhere.cheese"
+		channel = "processing"
+
+		parameters = {
+			timeout = "30"
+		}
+	}
+}`
+
+func TestUnitOrchestration_Basic(t *testing.T) {
+	mock := newMockKeboolaAPI()
+	defer mock.Close()
+
+	const orchestrationPath = "/orchestrator/orchestrations/%s"
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    mock.providers(),
+		CheckDestroy: mock.testCheckDestroy("keboola_orchestration", orchestrationPath, "id"),
+		Steps: []resource.TestStep{
+			{
+				Config: mock.config(testOrchestrationBasic),
+				Check: resource.ComposeTestCheckFunc(
+					mock.testCheckExists("keboola_orchestration.test_orchestration", orchestrationPath, "id"),
+					resource.TestCheckResourceAttr("keboola_orchestration.test_orchestration", "name", "test name"),
+					resource.TestCheckResourceAttr("keboola_orchestration.test_orchestration", "enabled", "true"),
+					resource.TestCheckResourceAttr("keboola_orchestration.test_orchestration", "notification.#", "1"),
+				),
+			},
+			{
+				Config: mock.config(testOrchestrationUpdate),
+				Check: resource.ComposeTestCheckFunc(
+					mock.testCheckExists("keboola_orchestration.test_orchestration", orchestrationPath, "id"),
+					resource.TestCheckResourceAttr("keboola_orchestration.test_orchestration", "name", "new test name"),
+					resource.TestCheckResourceAttr("keboola_orchestration.test_orchestration", "enabled", "false"),
+					resource.TestCheckResourceAttr("keboola_orchestration.test_orchestration", "schedule_cron", "0 6 * * *"),
+					resource.TestCheckResourceAttr("keboola_orchestration.test_orchestration", "notification.#", "2"),
+					resource.TestCheckResourceAttr("keboola_orchestration.test_orchestration", "notification.1.parameters.timeout", "30"),
+				),
+			},
+			{
+				Config:            mock.config(testOrchestrationUpdate),
+				ResourceName:      "keboola_orchestration.test_orchestration",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestUnitOrchestration_Disabled(t *testing.T) {
+	mock := newMockKeboolaAPI()
+	defer mock.Close()
+
+	const orchestrationPath = "/orchestrator/orchestrations/%s"
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    mock.providers(),
+		CheckDestroy: mock.testCheckDestroy("keboola_orchestration", orchestrationPath, "id"),
+		Steps: []resource.TestStep{
+			{
+				Config: mock.config(testOrchestrationDisabled),
+				Check: resource.ComposeTestCheckFunc(
+					mock.testCheckExists("keboola_orchestration.test_orchestration", orchestrationPath, "id"),
+					resource.TestCheckResourceAttr("keboola_orchestration.test_orchestration", "enabled", "false"),
+				),
+			},
+		},
+	})
+}
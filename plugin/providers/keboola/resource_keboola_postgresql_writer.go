@@ -2,7 +2,6 @@ package keboola
 
 import (
 	"encoding/json"
-	"fmt"
 	"log"
 	"net/url"
 
@@ -80,8 +79,11 @@ func resourceKeboolaPostgreSQLWriter() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: customizeBranchIDDiff,
 
 		Schema: map[string]*schema.Schema{
+			"branch_id":               &branchIDSchema,
+			"branch_id_from_provider": &branchIDFromProviderSchema,
 			"name": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -140,28 +142,32 @@ func resourceKeboolaPostgreSQLWriterCreate(d *schema.ResourceData, meta interfac
 
 	client := meta.(*KBCClient)
 
-	createdPostgreSQLID, err := createPostgreSQLWriterConfiguration(d.Get("name").(string), d.Get("description").(string), client)
+	createdPostgreSQLID, err := createPostgreSQLWriterConfiguration(d, d.Get("name").(string), d.Get("description").(string), client)
 
 	if err != nil {
 		return err
 	}
 
 	postgresqlDatabaseCredentials := d.Get("postgresql_db_parameters").(map[string]interface{})
-	err = createPostgreSQLCredentialsConfiguration(postgresqlDatabaseCredentials, createdPostgreSQLID, client)
+	err = createPostgreSQLCredentialsConfiguration(d, postgresqlDatabaseCredentials, createdPostgreSQLID, client)
+
+	if err != nil {
+		return err
+	}
 
 	d.SetId(createdPostgreSQLID)
 
 	return resourceKeboolaPostgreSQLWriterRead(d, meta)
 }
 
-func createPostgreSQLWriterConfiguration(name string, description string, client *KBCClient) (createdPostgreSQLID string, err error) {
+func createPostgreSQLWriterConfiguration(d *schema.ResourceData, name string, description string, client *KBCClient) (createdPostgreSQLID string, err error) {
 	createWriterForm := url.Values{}
 	createWriterForm.Add("name", name)
 	createWriterForm.Add("description", description)
 
 	createWriterBuffer := buffer.FromForm(createWriterForm)
 
-	createResponse, err := client.PostToStorage("storage/components/keboola.wr-db-pgsql/configs", createWriterBuffer)
+	createResponse, err := client.PostToStorage(componentsURI(d, client, "keboola.wr-db-pgsql/configs"), createWriterBuffer)
 
 	if hasErrors(err, createResponse) {
 		return "", extractError(err, createResponse)
@@ -194,7 +200,7 @@ func mapPostgreSQLCredentialsToConfiguration(source map[string]interface{}) Post
 	return databaseParameters
 }
 
-func createPostgreSQLCredentialsConfiguration(params map[string]interface{}, createdPostgreSQLID string, client *KBCClient) error {
+func createPostgreSQLCredentialsConfiguration(d *schema.ResourceData, params map[string]interface{}, createdPostgreSQLID string, client *KBCClient) error {
 	postgresqlCredentials := PostgreSQLWriterConfiguration{}
 
 	postgresqlCredentials.Parameters.Database = mapPostgreSQLCredentialsToConfiguration(params)
@@ -210,7 +216,7 @@ func createPostgreSQLCredentialsConfiguration(params map[string]interface{}, cre
 	updateCredentialsForm.Add("changeDescription", "Created database credentials")
 
 	updateCredentialsBuffer := buffer.FromForm(updateCredentialsForm)
-	updateCredentialsResponse, err := client.PutToStorage(fmt.Sprintf("storage/components/keboola.wr-db-pgsql/configs/%s", createdPostgreSQLID), updateCredentialsBuffer)
+	updateCredentialsResponse, err := client.PutToStorage(componentsURI(d, client, "keboola.wr-db-pgsql/configs/%s", createdPostgreSQLID), updateCredentialsBuffer)
 
 	if hasErrors(err, updateCredentialsResponse) {
 		return extractError(err, updateCredentialsResponse)
@@ -223,19 +229,21 @@ func resourceKeboolaPostgreSQLWriterRead(d *schema.ResourceData, meta interface{
 	log.Println("[INFO] Reading PostgreSQL Writers from Keboola.")
 
 	client := meta.(*KBCClient)
-	getResponse, err := client.GetFromStorage(fmt.Sprintf("storage/components/keboola.wr-db-pgsql/configs/%s", d.Id()))
+	getResponse, err := client.GetFromStorage(componentsURI(d, client, "keboola.wr-db-pgsql/configs/%s", d.Id()))
 
 	if d.Id() == "" {
 		return nil
 	}
 
 	if hasErrors(err, getResponse) {
-		if getResponse.StatusCode == 404 {
+		err = extractError(err, getResponse)
+
+		if isNotFoundError(err) {
 			d.SetId("")
 			return nil
 		}
 
-		return extractError(err, getResponse)
+		return err
 	}
 
 	var postgresqlWriter PostgreSQLWriter
@@ -251,6 +259,8 @@ func resourceKeboolaPostgreSQLWriterRead(d *schema.ResourceData, meta interface{
 	d.Set("name", postgresqlWriter.Name)
 	d.Set("description", postgresqlWriter.Description)
 
+	setBranchID(d, client)
+
 	return nil
 }
 
@@ -259,7 +269,7 @@ func resourceKeboolaPostgreSQLWriterUpdate(d *schema.ResourceData, meta interfac
 
 	client := meta.(*KBCClient)
 
-	getWriterResponse, err := client.GetFromStorage(fmt.Sprintf("storage/components/keboola.wr-db-pgsql/configs/%s", d.Id()))
+	getWriterResponse, err := client.GetFromStorage(componentsURI(d, client, "keboola.wr-db-pgsql/configs/%s", d.Id()))
 
 	if hasErrors(err, getWriterResponse) {
 		return extractError(err, getWriterResponse)
@@ -290,15 +300,12 @@ func resourceKeboolaPostgreSQLWriterUpdate(d *schema.ResourceData, meta interfac
 
 	updateWriterBuffer := buffer.FromForm(updateCredentialsForm)
 
-	updateWriterResponse, err := client.PutToStorage(fmt.Sprintf("storage/components/keboola.wr-db-pgsql/configs/%s", d.Id()), updateWriterBuffer)
+	updateWriterResponse, err := client.PutToStorage(componentsURI(d, client, "keboola.wr-db-pgsql/configs/%s", d.Id()), updateWriterBuffer)
 
 	if hasErrors(err, updateWriterResponse) {
 		return extractError(err, updateWriterResponse)
 	}
 
-	postgresqlDatabaseCredentials := d.Get("postgresql_db_parameters").(map[string]interface{})
-	err = createPostgreSQLCredentialsConfiguration(postgresqlDatabaseCredentials, d.Id(), client)
-
 	return resourceKeboolaPostgreSQLWriterRead(d, meta)
 }
 
@@ -306,7 +313,7 @@ func resourceKeboolaPostgreSQLWriterDelete(d *schema.ResourceData, meta interfac
 	log.Printf("[INFO] Deleting PostgreSQL Writer in Keboola: %s", d.Id())
 
 	client := meta.(*KBCClient)
-	destroyResponse, err := client.DeleteFromStorage(fmt.Sprintf("storage/components/keboola.wr-db-pgsql/configs/%s", d.Id()))
+	destroyResponse, err := client.DeleteFromStorage(componentsURI(d, client, "keboola.wr-db-pgsql/configs/%s", d.Id()))
 
 	if hasErrors(err, destroyResponse) {
 		return extractError(err, destroyResponse)
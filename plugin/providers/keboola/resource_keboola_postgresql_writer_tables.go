@@ -14,12 +14,15 @@ import (
 
 func resourceKeboolaPostgreSQLWriterTables() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceKeboolaPostgreSQLWriterTablesCreate,
-		Read:   resourceKeboolaPostgreSQLWriterTablesRead,
-		Update: resourceKeboolaPostgreSQLWriterTablesUpdate,
-		Delete: resourceKeboolaPostgreSQLWriterTablesDelete,
+		Create:        resourceKeboolaPostgreSQLWriterTablesCreate,
+		Read:          resourceKeboolaPostgreSQLWriterTablesRead,
+		Update:        resourceKeboolaPostgreSQLWriterTablesUpdate,
+		Delete:        resourceKeboolaPostgreSQLWriterTablesDelete,
+		CustomizeDiff: customizeBranchIDDiff,
 
 		Schema: map[string]*schema.Schema{
+			"branch_id":               &branchIDSchema,
+			"branch_id_from_provider": &branchIDFromProviderSchema,
 			"writer_id": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -152,7 +155,7 @@ func resourceKeboolaPostgreSQLWriterTablesCreate(d *schema.ResourceData, meta in
 
 	client := meta.(*KBCClient)
 
-	getWriterResponse, err := client.GetFromStorage(fmt.Sprintf("storage/components/keboola.wr-db-pgsql/configs/%s", writerID))
+	getWriterResponse, err := client.GetFromStorage(componentsURI(d, client, "keboola.wr-db-pgsql/configs/%s", writerID))
 
 	if hasErrors(err, getWriterResponse) {
 		return extractError(err, getWriterResponse)
@@ -182,7 +185,7 @@ func resourceKeboolaPostgreSQLWriterTablesCreate(d *schema.ResourceData, meta in
 
 	updatePostgreSQLBuffer := buffer.FromForm(updatePostgreSQLForm)
 
-	updateResponse, err := client.PutToStorage(fmt.Sprintf("storage/components/keboola.wr-db-pgsql/configs/%s", writerID), updatePostgreSQLBuffer)
+	updateResponse, err := client.PutToStorage(componentsURI(d, client, "keboola.wr-db-pgsql/configs/%s", writerID), updatePostgreSQLBuffer)
 
 	if hasErrors(err, updateResponse) {
 		return extractError(err, updateResponse)
@@ -202,15 +205,17 @@ func resourceKeboolaPostgreSQLWriterTablesRead(d *schema.ResourceData, meta inte
 
 	client := meta.(*KBCClient)
 
-	getPostgreSQLWriterResponse, err := client.GetFromStorage(fmt.Sprintf("storage/components/keboola.wr-db-pgsql/configs/%s", d.Id()))
+	getPostgreSQLWriterResponse, err := client.GetFromStorage(componentsURI(d, client, "keboola.wr-db-pgsql/configs/%s", d.Id()))
 
 	if hasErrors(err, getPostgreSQLWriterResponse) {
-		if getPostgreSQLWriterResponse.StatusCode == 404 {
+		err = extractError(err, getPostgreSQLWriterResponse)
+
+		if isNotFoundError(err) {
 			d.SetId("")
 			return nil
 		}
 
-		return extractError(err, getPostgreSQLWriterResponse)
+		return err
 	}
 
 	var postgresqlWriter PostgreSQLWriter
@@ -255,6 +260,8 @@ func resourceKeboolaPostgreSQLWriterTablesRead(d *schema.ResourceData, meta inte
 
 	d.Set("table", tables)
 
+	setBranchID(d, client)
+
 	return nil
 }
 
@@ -313,7 +320,7 @@ func resourceKeboolaPostgreSQLWriterTablesUpdate(d *schema.ResourceData, meta in
 
 	client := meta.(*KBCClient)
 
-	getWriterResponse, err := client.GetFromStorage(fmt.Sprintf("storage/components/keboola.wr-db-pgsql/configs/%s", d.Id()))
+	getWriterResponse, err := client.GetFromStorage(componentsURI(d, client, "keboola.wr-db-pgsql/configs/%s", d.Id()))
 
 	if hasErrors(err, getWriterResponse) {
 		return extractError(err, getWriterResponse)
@@ -343,7 +350,7 @@ func resourceKeboolaPostgreSQLWriterTablesUpdate(d *schema.ResourceData, meta in
 
 	updatePostgreSQLBuffer := buffer.FromForm(updatePostgreSQLForm)
 
-	updateResponse, err := client.PutToStorage(fmt.Sprintf("storage/components/keboola.wr-db-pgsql/configs/%s", d.Id()), updatePostgreSQLBuffer)
+	updateResponse, err := client.PutToStorage(componentsURI(d, client, "keboola.wr-db-pgsql/configs/%s", d.Id()), updatePostgreSQLBuffer)
 
 	if hasErrors(err, updateResponse) {
 		return extractError(err, updateResponse)
@@ -357,7 +364,7 @@ func resourceKeboolaPostgreSQLWriterTablesDelete(d *schema.ResourceData, meta in
 
 	client := meta.(*KBCClient)
 
-	getWriterResponse, err := client.GetFromStorage(fmt.Sprintf("storage/components/keboola.wr-db-pgsql/configs/%s", d.Id()))
+	getWriterResponse, err := client.GetFromStorage(componentsURI(d, client, "keboola.wr-db-pgsql/configs/%s", d.Id()))
 
 	if hasErrors(err, getWriterResponse) {
 		return extractError(err, getWriterResponse)
@@ -390,7 +397,7 @@ func resourceKeboolaPostgreSQLWriterTablesDelete(d *schema.ResourceData, meta in
 
 	clearPostgreSQLTablesBuffer := buffer.FromForm(clearPostgreSQLTablesForm)
 
-	clearResponse, err := client.PutToStorage(fmt.Sprintf("storage/components/keboola.wr-db-pgsql/configs/%s", d.Id()), clearPostgreSQLTablesBuffer)
+	clearResponse, err := client.PutToStorage(componentsURI(d, client, "keboola.wr-db-pgsql/configs/%s", d.Id()), clearPostgreSQLTablesBuffer)
 
 	if hasErrors(err, clearResponse) {
 		return extractError(err, clearResponse)
@@ -0,0 +1,181 @@
+package keboola
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestUnitPostgresqlWriterTables_Basic(t *testing.T) {
+	mock := newMockKeboolaAPI()
+	defer mock.Close()
+
+	const writerPath = "/v2/storage/components/keboola.wr-db-pgsql/configs/%s"
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    mock.providers(),
+		CheckDestroy: mock.testCheckDestroy("keboola_postgresql_writer", writerPath, "id"),
+		Steps: []resource.TestStep{
+			{
+				Config: mock.config(testPostgresqlWriterTablesBasic),
+				Check: resource.ComposeTestCheckFunc(
+					mock.testCheckExists("keboola_postgresql_writer_tables.test_tables", writerPath, "id"),
+					resource.TestCheckResourceAttrPair("keboola_postgresql_writer_tables.test_tables", "writer_id", "keboola_postgresql_writer.test_writer", "id"),
+					resource.TestCheckResourceAttr("keboola_postgresql_writer_tables.test_tables", "table.#", "1"),
+					resource.TestCheckResourceAttr("keboola_postgresql_writer_tables.test_tables", "table.0.db_name", "orders"),
+					resource.TestCheckResourceAttr("keboola_postgresql_writer_tables.test_tables", "table.0.column.#", "2"),
+				),
+			},
+			{
+				Config: mock.config(testPostgresqlWriterTablesWriterUpdate),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("keboola_postgresql_writer.test_writer", "description", "updated test description"),
+					resource.TestCheckResourceAttr("keboola_postgresql_writer_tables.test_tables", "table.#", "1"),
+					testCheckPostgresqlWriterTablesKept(mock, 1),
+				),
+			},
+			{
+				Config: mock.config(testPostgresqlWriterTablesUpdate),
+				Check: resource.ComposeTestCheckFunc(
+					mock.testCheckExists("keboola_postgresql_writer_tables.test_tables", writerPath, "id"),
+					resource.TestCheckResourceAttr("keboola_postgresql_writer_tables.test_tables", "table.#", "2"),
+					resource.TestCheckResourceAttr("keboola_postgresql_writer_tables.test_tables", "table.0.incremental", "true"),
+					resource.TestCheckResourceAttr("keboola_postgresql_writer_tables.test_tables", "table.0.primary_key.0", "id"),
+					resource.TestCheckResourceAttr("keboola_postgresql_writer_tables.test_tables", "table.1.table_id", "in.c-crm.customers"),
+				),
+			},
+		},
+	})
+}
+
+//testCheckPostgresqlWriterTablesKept checks that the writer's configuration still holds its tables, which
+//must not be wiped by updating the writer itself (e.g. by saving only its database credentials).
+func testCheckPostgresqlWriterTablesKept(mock *mockKeboolaAPI, expectedTables int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		mock.mutex.Lock()
+		defer mock.mutex.Unlock()
+
+		rs := s.RootModule().Resources["keboola_postgresql_writer.test_writer"]
+		configuration, ok := mock.components["keboola.wr-db-pgsql"][rs.Primary.ID]
+
+		if !ok {
+			return fmt.Errorf("PostgreSQL Writer configuration %s not found", rs.Primary.ID)
+		}
+
+		parameters, _ := configuration.Configuration.(map[string]interface{})["parameters"].(map[string]interface{})
+		tables, _ := parameters["tables"].([]interface{})
+
+		if len(tables) != expectedTables {
+			return fmt.Errorf("Expected the PostgreSQL Writer configuration to keep %d tables, got %d", expectedTables, len(tables))
+		}
+
+		return nil
+	}
+}
+
+const testPostgresqlWriterTablesBasic = `
+	resource "keboola_postgresql_writer" "test_writer" {
+		name = "test_postgresql_writer"
+		description = "test description"
+	}
+
+	resource "keboola_postgresql_writer_tables" "test_tables" {
+		writer_id = "${keboola_postgresql_writer.test_writer.id}"
+
+		table {
+			db_name = "orders"
+			export = true
+			table_id = "in.c-crm.orders"
+
+			column {
+				name = "id"
+				db_name = "id"
+				type = "integer"
+			}
+
+			column {
+				name = "amount"
+				db_name = "amount"
+				type = "numeric"
+				size = "12,2"
+				nullable = true
+			}
+		}
+	}`
+
+const testPostgresqlWriterTablesWriterUpdate = `
+	resource "keboola_postgresql_writer" "test_writer" {
+		name = "test_postgresql_writer"
+		description = "updated test description"
+	}
+
+	resource "keboola_postgresql_writer_tables" "test_tables" {
+		writer_id = "${keboola_postgresql_writer.test_writer.id}"
+
+		table {
+			db_name = "orders"
+			export = true
+			table_id = "in.c-crm.orders"
+
+			column {
+				name = "id"
+				db_name = "id"
+				type = "integer"
+			}
+
+			column {
+				name = "amount"
+				db_name = "amount"
+				type = "numeric"
+				size = "12,2"
+				nullable = true
+			}
+		}
+	}`
+
+const testPostgresqlWriterTablesUpdate = `
+	resource "keboola_postgresql_writer" "test_writer" {
+		name = "test_postgresql_writer"
+		description = "updated test description"
+	}
+
+	resource "keboola_postgresql_writer_tables" "test_tables" {
+		writer_id = "${keboola_postgresql_writer.test_writer.id}"
+
+		table {
+			db_name = "orders"
+			export = true
+			table_id = "in.c-crm.orders"
+			incremental = true
+			primary_key = [ "id" ]
+
+			column {
+				name = "id"
+				db_name = "id"
+				type = "integer"
+			}
+
+			column {
+				name = "amount"
+				db_name = "amount"
+				type = "numeric"
+				size = "12,2"
+				nullable = true
+			}
+		}
+
+		table {
+			db_name = "customers"
+			export = true
+			table_id = "in.c-crm.customers"
+
+			column {
+				name = "name"
+				db_name = "name"
+				type = "varchar"
+				size = "255"
+			}
+		}
+	}`
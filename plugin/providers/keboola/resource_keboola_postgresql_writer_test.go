@@ -82,3 +82,71 @@ const testPostgresqlWriterUpdate = `
 		name = "updated_test_postgresql_writer"
 		description = "updated test description"
 	}`
+
+const testPostgresqlWriterCredentials = `
+	resource "keboola_postgresql_writer" "test_writer" {
+		name = "test_postgresql_writer"
+		description = "test description"
+
+		postgresql_db_parameters = {
+			hostname = "db.example.com"
+			port = "5432"
+			database = "analytics"
+			schema = "public"
+			username = "keboola"
+			hashed_password = "KBC::ProjectSecure::secret"
+		}
+	}`
+
+const testPostgresqlWriterCredentialsUpdate = `
+	resource "keboola_postgresql_writer" "test_writer" {
+		name = "updated_test_postgresql_writer"
+		description = "updated test description"
+
+		postgresql_db_parameters = {
+			hostname = "other-db.example.com"
+			port = "5433"
+			database = "reporting"
+			schema = "public"
+			username = "keboola"
+			hashed_password = "KBC::ProjectSecure::othersecret"
+		}
+	}`
+
+func TestUnitPostgresqlWriter_Basic(t *testing.T) {
+	mock := newMockKeboolaAPI()
+	defer mock.Close()
+
+	const writerPath = "/v2/storage/components/keboola.wr-db-pgsql/configs/%s"
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    mock.providers(),
+		CheckDestroy: mock.testCheckDestroy("keboola_postgresql_writer", writerPath, "id"),
+		Steps: []resource.TestStep{
+			{
+				Config: mock.config(testPostgresqlWriterCredentials),
+				Check: resource.ComposeTestCheckFunc(
+					mock.testCheckExists("keboola_postgresql_writer.test_writer", writerPath, "id"),
+					resource.TestCheckResourceAttr("keboola_postgresql_writer.test_writer", "name", "test_postgresql_writer"),
+					resource.TestCheckResourceAttr("keboola_postgresql_writer.test_writer", "postgresql_db_parameters.hostname", "db.example.com"),
+				),
+			},
+			{
+				Config: mock.config(testPostgresqlWriterCredentialsUpdate),
+				Check: resource.ComposeTestCheckFunc(
+					mock.testCheckExists("keboola_postgresql_writer.test_writer", writerPath, "id"),
+					resource.TestCheckResourceAttr("keboola_postgresql_writer.test_writer", "name", "updated_test_postgresql_writer"),
+					resource.TestCheckResourceAttr("keboola_postgresql_writer.test_writer", "description", "updated test description"),
+					resource.TestCheckResourceAttr("keboola_postgresql_writer.test_writer", "postgresql_db_parameters.hostname", "other-db.example.com"),
+				),
+			},
+			{
+				Config:                  mock.config(testPostgresqlWriterCredentialsUpdate),
+				ResourceName:            "keboola_postgresql_writer.test_writer",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"postgresql_db_parameters"},
+			},
+		},
+	})
+}
@@ -2,7 +2,6 @@ package keboola
 
 import (
 	"encoding/json"
-	"fmt"
 	"log"
 	"net/url"
 
@@ -52,8 +51,11 @@ func resourceKeboolaSnowflakeExtractor() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: customizeBranchIDDiff,
 
 		Schema: map[string]*schema.Schema{
+			"branch_id":               &branchIDSchema,
+			"branch_id_from_provider": &branchIDFromProviderSchema,
 			"name": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -80,7 +82,7 @@ func resourceKeboolaSnowflakeExtractorCreate(d *schema.ResourceData, meta interf
 
 	createExtractorBuffer := buffer.FromForm(createExtractorForm)
 
-	createResponse, err := client.PostToStorage("storage/components/keboola.ex-db-snowflake/configs", createExtractorBuffer)
+	createResponse, err := client.PostToStorage(componentsURI(d, client, "keboola.ex-db-snowflake/configs"), createExtractorBuffer)
 
 	if hasErrors(err, createResponse) {
 		return extractError(err, createResponse)
@@ -102,7 +104,7 @@ func resourceKeboolaSnowflakeExtractorCreate(d *schema.ResourceData, meta interf
 
 	snowflakeDatabaseCredentials := d.Get("snowflake_db_parameters").(map[string]interface{})
 
-	err = createSnowflakeExtractorCredentialsConfiguration(snowflakeDatabaseCredentials, createdSnowflakeID, client)
+	err = createSnowflakeExtractorCredentialsConfiguration(d, snowflakeDatabaseCredentials, createdSnowflakeID, client)
 
 	if err != nil {
 		return err
@@ -117,7 +119,7 @@ func resourceKeboolaSnowflakeExtractorCreate(d *schema.ResourceData, meta interf
 	return resourceKeboolaSnowflakeExtractorRead(d, meta)
 }
 
-func createSnowflakeExtractorCredentialsConfiguration(snowflakeCredentials map[string]interface{}, createdSnowflakeID string, client *KBCClient) error {
+func createSnowflakeExtractorCredentialsConfiguration(d *schema.ResourceData, snowflakeCredentials map[string]interface{}, createdSnowflakeID string, client *KBCClient) error {
 	snowflakeExtractorConfiguration := SnowflakeExtractorConfiguration{}
 
 	snowflakeExtractorConfiguration.Parameters.Database = mapSnowflakeCredentialsToConfiguration(snowflakeCredentials, false)
@@ -134,7 +136,7 @@ func createSnowflakeExtractorCredentialsConfiguration(snowflakeCredentials map[s
 
 	updateConfigurationRequestBuffer := buffer.FromForm(updateConfigurationRequestForm)
 
-	updateConfigurationResponse, err := client.PutToStorage(fmt.Sprintf("storage/components/keboola.ex-db-snowflake/configs/%s", createdSnowflakeID), updateConfigurationRequestBuffer)
+	updateConfigurationResponse, err := client.PutToStorage(componentsURI(d, client, "keboola.ex-db-snowflake/configs/%s", createdSnowflakeID), updateConfigurationRequestBuffer)
 
 	if hasErrors(err, updateConfigurationResponse) {
 		return extractError(err, updateConfigurationResponse)
@@ -151,15 +153,17 @@ func resourceKeboolaSnowflakeExtractorRead(d *schema.ResourceData, meta interfac
 	}
 
 	client := meta.(*KBCClient)
-	getSnowflakeExtractorResponse, err := client.GetFromStorage(fmt.Sprintf("storage/components/keboola.ex-db-snowflake/configs/%s", d.Id()))
+	getSnowflakeExtractorResponse, err := client.GetFromStorage(componentsURI(d, client, "keboola.ex-db-snowflake/configs/%s", d.Id()))
 
 	if hasErrors(err, getSnowflakeExtractorResponse) {
-		if getSnowflakeExtractorResponse.StatusCode == 404 {
+		err = extractError(err, getSnowflakeExtractorResponse)
+
+		if isNotFoundError(err) {
 			d.SetId("")
 			return nil
 		}
 
-		return extractError(err, getSnowflakeExtractorResponse)
+		return err
 	}
 
 	var snowflakeExtractor SnowflakeExtractor
@@ -211,6 +215,8 @@ func resourceKeboolaSnowflakeExtractorRead(d *schema.ResourceData, meta interfac
 		d.Set("snowflake_db_parameters", dbParameters)
 	}
 
+	setBranchID(d, client)
+
 	return nil
 }
 
@@ -219,7 +225,7 @@ func resourceKeboolaSnowflakeExtractorUpdate(d *schema.ResourceData, meta interf
 
 	client := meta.(*KBCClient)
 
-	getExtractorResponse, err := client.GetFromStorage(fmt.Sprintf("storage/components/keboola.ex-db-snowflake/configs/%s", d.Id()))
+	getExtractorResponse, err := client.GetFromStorage(componentsURI(d, client, "keboola.ex-db-snowflake/configs/%s", d.Id()))
 
 	if hasErrors(err, getExtractorResponse) {
 		return extractError(err, getExtractorResponse)
@@ -252,7 +258,7 @@ func resourceKeboolaSnowflakeExtractorUpdate(d *schema.ResourceData, meta interf
 
 	updateCredentialsBuffer := buffer.FromForm(updateCredentialsForm)
 
-	updateCredentialsResponse, err := client.PutToStorage(fmt.Sprintf("storage/components/keboola.ex-db-snowflake/configs/%s", d.Id()), updateCredentialsBuffer)
+	updateCredentialsResponse, err := client.PutToStorage(componentsURI(d, client, "keboola.ex-db-snowflake/configs/%s", d.Id()), updateCredentialsBuffer)
 
 	if hasErrors(err, updateCredentialsResponse) {
 		return extractError(err, updateCredentialsResponse)
@@ -265,7 +271,7 @@ func resourceKeboolaSnowflakeExtractorDelete(d *schema.ResourceData, meta interf
 	log.Printf("[INFO] Deleting Snowflake Extractor in Keboola: %s", d.Id())
 
 	client := meta.(*KBCClient)
-	destroyResponse, err := client.DeleteFromStorage(fmt.Sprintf("storage/components/keboola.ex-db-snowflake/configs/%s", d.Id()))
+	destroyResponse, err := client.DeleteFromStorage(componentsURI(d, client, "keboola.ex-db-snowflake/configs/%s", d.Id()))
 
 	if hasErrors(err, destroyResponse) {
 		return extractError(err, destroyResponse)
@@ -13,12 +13,15 @@ import (
 
 func resourceKeboolaSnowflakeExtractorTables() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceKeboolaSnowflakeExtractorTablesCreate,
-		Read:   resourceKeboolaSnowflakeExtractorTablesRead,
-		Update: resourceKeboolaSnowflakeExtractorTablesUpdate,
-		Delete: resourceKeboolaSnowflakeExtractorTablesDelete,
+		Create:        resourceKeboolaSnowflakeExtractorTablesCreate,
+		Read:          resourceKeboolaSnowflakeExtractorTablesRead,
+		Update:        resourceKeboolaSnowflakeExtractorTablesUpdate,
+		Delete:        resourceKeboolaSnowflakeExtractorTablesDelete,
+		CustomizeDiff: customizeBranchIDDiff,
 
 		Schema: map[string]*schema.Schema{
+			"branch_id":               &branchIDSchema,
+			"branch_id_from_provider": &branchIDFromProviderSchema,
 			"extractor_id": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -149,7 +152,7 @@ func resourceKeboolaSnowflakeExtractorTablesCreate(d *schema.ResourceData, meta
 
 	client := meta.(*KBCClient)
 
-	getExtractorResponse, err := client.GetFromStorage(fmt.Sprintf("storage/components/keboola.ex-db-snowflake/configs/%s", extractorID))
+	getExtractorResponse, err := client.GetFromStorage(componentsURI(d, client, "keboola.ex-db-snowflake/configs/%s", extractorID))
 
 	if hasErrors(err, getExtractorResponse) {
 		return extractError(err, getExtractorResponse)
@@ -178,7 +181,7 @@ func resourceKeboolaSnowflakeExtractorTablesCreate(d *schema.ResourceData, meta
 
 	updateSnowflakeBuffer := buffer.FromForm(updateSnowflakeForm)
 
-	updateResponse, err := client.PutToStorage(fmt.Sprintf("storage/components/keboola.ex-db-snowflake/configs/%s", extractorID), updateSnowflakeBuffer)
+	updateResponse, err := client.PutToStorage(componentsURI(d, client, "keboola.ex-db-snowflake/configs/%s", extractorID), updateSnowflakeBuffer)
 
 	if hasErrors(err, updateResponse) {
 		return extractError(err, updateResponse)
@@ -198,15 +201,17 @@ func resourceKeboolaSnowflakeExtractorTablesRead(d *schema.ResourceData, meta in
 
 	client := meta.(*KBCClient)
 
-	getSnowflakeExtractorResponse, err := client.GetFromStorage(fmt.Sprintf("storage/components/keboola.ex-db-snowflake/configs/%s", d.Id()))
+	getSnowflakeExtractorResponse, err := client.GetFromStorage(componentsURI(d, client, "keboola.ex-db-snowflake/configs/%s", d.Id()))
 
 	if hasErrors(err, getSnowflakeExtractorResponse) {
-		if getSnowflakeExtractorResponse.StatusCode == 404 {
+		err = extractError(err, getSnowflakeExtractorResponse)
+
+		if isNotFoundError(err) {
 			d.SetId("")
 			return nil
 		}
 
-		return extractError(err, getSnowflakeExtractorResponse)
+		return err
 	}
 
 	var SnowflakeExtractor SnowflakeExtractor
@@ -244,6 +249,8 @@ func resourceKeboolaSnowflakeExtractorTablesRead(d *schema.ResourceData, meta in
 
 	d.Set("table", tables)
 
+	setBranchID(d, client)
+
 	return nil
 }
 
@@ -299,7 +306,7 @@ func resourceKeboolaSnowflakeExtractorTablesUpdate(d *schema.ResourceData, meta
 
 	client := meta.(*KBCClient)
 
-	getExtractorResponse, err := client.GetFromStorage(fmt.Sprintf("storage/components/keboola.ex-db-snowflake/configs/%s", d.Id()))
+	getExtractorResponse, err := client.GetFromStorage(componentsURI(d, client, "keboola.ex-db-snowflake/configs/%s", d.Id()))
 
 	if hasErrors(err, getExtractorResponse) {
 		return extractError(err, getExtractorResponse)
@@ -328,7 +335,7 @@ func resourceKeboolaSnowflakeExtractorTablesUpdate(d *schema.ResourceData, meta
 
 	updateSnowflakeBuffer := buffer.FromForm(updateSnowflakeForm)
 
-	updateResponse, err := client.PutToStorage(fmt.Sprintf("storage/components/keboola.ex-db-snowflake/configs/%s", d.Id()), updateSnowflakeBuffer)
+	updateResponse, err := client.PutToStorage(componentsURI(d, client, "keboola.ex-db-snowflake/configs/%s", d.Id()), updateSnowflakeBuffer)
 
 	if hasErrors(err, updateResponse) {
 		return extractError(err, updateResponse)
@@ -342,7 +349,7 @@ func resourceKeboolaSnowflakeExtractorTablesDelete(d *schema.ResourceData, meta
 
 	client := meta.(*KBCClient)
 
-	getExtractorResponse, err := client.GetFromStorage(fmt.Sprintf("storage/components/keboola.ex-db-snowflake/configs/%s", d.Id()))
+	getExtractorResponse, err := client.GetFromStorage(componentsURI(d, client, "keboola.ex-db-snowflake/configs/%s", d.Id()))
 
 	if hasErrors(err, getExtractorResponse) {
 		return extractError(err, getExtractorResponse)
@@ -372,7 +379,7 @@ func resourceKeboolaSnowflakeExtractorTablesDelete(d *schema.ResourceData, meta
 
 	clearSnowflakeTablesBuffer := buffer.FromForm(clearSnowflakeTablesForm)
 
-	clearResponse, err := client.PutToStorage(fmt.Sprintf("storage/components/keboola.ex-db-snowflake/configs/%s", d.Id()), clearSnowflakeTablesBuffer)
+	clearResponse, err := client.PutToStorage(componentsURI(d, client, "keboola.ex-db-snowflake/configs/%s", d.Id()), clearSnowflakeTablesBuffer)
 
 	if hasErrors(err, clearResponse) {
 		return extractError(err, clearResponse)
@@ -383,9 +390,9 @@ func resourceKeboolaSnowflakeExtractorTablesDelete(d *schema.ResourceData, meta
 	return nil
 }
 
-func getSnowflakeExtractorFromId(id string, client *KBCClient) (SnowflakeExtractorFromResponse *SnowflakeExtractor, err error) {
+func getSnowflakeExtractorFromId(d *schema.ResourceData, id string, client *KBCClient) (SnowflakeExtractorFromResponse *SnowflakeExtractor, err error) {
 
-	getExtractorResponse, err := client.GetFromStorage(fmt.Sprintf("storage/components/keboola.ex-db-snowflake/configs/%s", id))
+	getExtractorResponse, err := client.GetFromStorage(componentsURI(d, client, "keboola.ex-db-snowflake/configs/%s", id))
 
 	if hasErrors(err, getExtractorResponse) {
 		return nil, extractError(err, getExtractorResponse)
@@ -0,0 +1,80 @@
+package keboola
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestUnitSnowflakeExtractorTables_Basic(t *testing.T) {
+	mock := newMockKeboolaAPI()
+	defer mock.Close()
+
+	const extractorPath = "/v2/storage/components/keboola.ex-db-snowflake/configs/%s"
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    mock.providers(),
+		CheckDestroy: mock.testCheckDestroy("keboola_snowflake_extractor", extractorPath, "id"),
+		Steps: []resource.TestStep{
+			{
+				Config: mock.config(testSnowflakeExtractorTablesBasic),
+				Check: resource.ComposeTestCheckFunc(
+					mock.testCheckExists("keboola_snowflake_extractor_tables.test_tables", extractorPath, "id"),
+					resource.TestCheckResourceAttrPair("keboola_snowflake_extractor_tables.test_tables", "extractor_id", "keboola_snowflake_extractor.test_extractor", "id"),
+					resource.TestCheckResourceAttr("keboola_snowflake_extractor_tables.test_tables", "table.#", "1"),
+				),
+			},
+			{
+				Config: mock.config(testSnowflakeExtractorTablesUpdate),
+				Check: resource.ComposeTestCheckFunc(
+					mock.testCheckExists("keboola_snowflake_extractor_tables.test_tables", extractorPath, "id"),
+					resource.TestCheckResourceAttr("keboola_snowflake_extractor_tables.test_tables", "table.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+const testSnowflakeExtractorTablesBasic = `
+    resource "keboola_snowflake_extractor" "test_extractor" {
+        name = "test_snowflake_extractor"
+        description = "test description"
+    }
+
+    resource "keboola_snowflake_extractor_tables" "test_tables" {
+        extractor_id = "${keboola_snowflake_extractor.test_extractor.id}"
+
+        table {
+            name = "orders"
+            output_table = "in.c-snowflake.orders"
+            schema = "PUBLIC"
+            table_name = "ORDERS"
+            columns = [ "ID", "AMOUNT" ]
+        }
+    }`
+
+const testSnowflakeExtractorTablesUpdate = `
+    resource "keboola_snowflake_extractor" "test_extractor" {
+        name = "test_snowflake_extractor"
+        description = "test description"
+    }
+
+    resource "keboola_snowflake_extractor_tables" "test_tables" {
+        extractor_id = "${keboola_snowflake_extractor.test_extractor.id}"
+
+        table {
+            name = "orders"
+            output_table = "in.c-snowflake.orders"
+            schema = "PUBLIC"
+            table_name = "ORDERS"
+            columns = [ "ID", "AMOUNT" ]
+            incremental = true
+            primary_key = [ "ID" ]
+        }
+
+        table {
+            name = "active_customers"
+            output_table = "in.c-snowflake.customers"
+            query = "SELECT * FROM CUSTOMERS WHERE ACTIVE = 1"
+        }
+    }`
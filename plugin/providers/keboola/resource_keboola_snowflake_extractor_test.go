@@ -82,3 +82,73 @@ const testSnowflakeExtractorUpdate = `
         name = "updated_test_snowflake_extractor"
         description = "updated test description"
     }`
+
+const testSnowflakeExtractorCredentials = `
+    resource "keboola_snowflake_extractor" "test_extractor" {
+        name = "test_snowflake_extractor"
+        description = "test description"
+
+        snowflake_db_parameters = {
+            hostname = "acme.snowflakecomputing.com"
+            port = "443"
+            database = "ANALYTICS"
+            schema = "PUBLIC"
+            warehouse = "EXTRACTING"
+            username = "KEBOOLA"
+            hashed_password = "KBC::ProjectSecure::secret"
+        }
+    }`
+
+const testSnowflakeExtractorCredentialsUpdate = `
+    resource "keboola_snowflake_extractor" "test_extractor" {
+        name = "updated_test_snowflake_extractor"
+        description = "updated test description"
+
+        snowflake_db_parameters = {
+            hostname = "other.snowflakecomputing.com"
+            port = "443"
+            database = "REPORTING"
+            schema = "PUBLIC"
+            warehouse = "EXTRACTING"
+            username = "KEBOOLA"
+            hashed_password = "KBC::ProjectSecure::othersecret"
+        }
+    }`
+
+func TestUnitSnowflakeExtractor_Basic(t *testing.T) {
+	mock := newMockKeboolaAPI()
+	defer mock.Close()
+
+	const extractorPath = "/v2/storage/components/keboola.ex-db-snowflake/configs/%s"
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    mock.providers(),
+		CheckDestroy: mock.testCheckDestroy("keboola_snowflake_extractor", extractorPath, "id"),
+		Steps: []resource.TestStep{
+			{
+				Config: mock.config(testSnowflakeExtractorCredentials),
+				Check: resource.ComposeTestCheckFunc(
+					mock.testCheckExists("keboola_snowflake_extractor.test_extractor", extractorPath, "id"),
+					resource.TestCheckResourceAttr("keboola_snowflake_extractor.test_extractor", "name", "test_snowflake_extractor"),
+					resource.TestCheckResourceAttr("keboola_snowflake_extractor.test_extractor", "snowflake_db_parameters.hostname", "acme.snowflakecomputing.com"),
+				),
+			},
+			{
+				Config: mock.config(testSnowflakeExtractorCredentialsUpdate),
+				Check: resource.ComposeTestCheckFunc(
+					mock.testCheckExists("keboola_snowflake_extractor.test_extractor", extractorPath, "id"),
+					resource.TestCheckResourceAttr("keboola_snowflake_extractor.test_extractor", "name", "updated_test_snowflake_extractor"),
+					resource.TestCheckResourceAttr("keboola_snowflake_extractor.test_extractor", "description", "updated test description"),
+					resource.TestCheckResourceAttr("keboola_snowflake_extractor.test_extractor", "snowflake_db_parameters.hostname", "other.snowflakecomputing.com"),
+					resource.TestCheckResourceAttr("keboola_snowflake_extractor.test_extractor", "snowflake_db_parameters.database", "REPORTING"),
+				),
+			},
+			{
+				Config:            mock.config(testSnowflakeExtractorCredentialsUpdate),
+				ResourceName:      "keboola_snowflake_extractor.test_extractor",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -105,8 +105,11 @@ func resourceKeboolaSnowflakeWriter() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: customizeBranchIDDiff,
 
 		Schema: map[string]*schema.Schema{
+			"branch_id":               &branchIDSchema,
+			"branch_id_from_provider": &branchIDFromProviderSchema,
 			"name": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -133,7 +136,7 @@ func resourceKeboolaSnowflakeWriterCreate(d *schema.ResourceData, meta interface
 
 	d.Partial(true)
 
-	createdSnowflakeID, err := createSnowflakeWriterConfiguration(d.Get("name").(string), d.Get("description").(string), client)
+	createdSnowflakeID, err := createSnowflakeWriterConfiguration(d, d.Get("name").(string), d.Get("description").(string), client)
 
 	if err != nil {
 		return err
@@ -168,7 +171,7 @@ func resourceKeboolaSnowflakeWriterCreate(d *schema.ResourceData, meta interface
 		}
 	}
 
-	err = createSnowflakeCredentialsConfiguration(snowflakeDatabaseCredentials, createdSnowflakeID, client)
+	err = createSnowflakeCredentialsConfiguration(d, snowflakeDatabaseCredentials, createdSnowflakeID, client)
 
 	if err != nil {
 		return err
@@ -183,14 +186,14 @@ func resourceKeboolaSnowflakeWriterCreate(d *schema.ResourceData, meta interface
 	return resourceKeboolaSnowflakeWriterRead(d, meta)
 }
 
-func createSnowflakeWriterConfiguration(name string, description string, client *KBCClient) (createdSnowflakeID string, err error) {
+func createSnowflakeWriterConfiguration(d *schema.ResourceData, name string, description string, client *KBCClient) (createdSnowflakeID string, err error) {
 	createWriterForm := url.Values{}
 	createWriterForm.Add("name", name)
 	createWriterForm.Add("description", description)
 
 	createWriterBuffer := buffer.FromForm(createWriterForm)
 
-	createResponse, err := client.PostToStorage("storage/components/keboola.wr-db-snowflake/configs", createWriterBuffer)
+	createResponse, err := client.PostToStorage(componentsURI(d, client, "keboola.wr-db-snowflake/configs"), createWriterBuffer)
 
 	if hasErrors(err, createResponse) {
 		return "", extractError(err, createResponse)
@@ -248,7 +251,7 @@ func provisionSnowflakeInstance(client *KBCClient) (provisionedSnowflakeResponse
 	return &provisionedSnowflake, nil
 }
 
-func createSnowflakeCredentialsConfiguration(snowflakeCredentials map[string]interface{}, createdSnowflakeID string, client *KBCClient) error {
+func createSnowflakeCredentialsConfiguration(d *schema.ResourceData, snowflakeCredentials map[string]interface{}, createdSnowflakeID string, client *KBCClient) error {
 	snowflakeWriterConfiguration := SnowflakeWriterConfiguration{}
 
 	snowflakeWriterConfiguration.Parameters.Database = mapSnowflakeCredentialsToConfiguration(snowflakeCredentials, true)
@@ -265,7 +268,7 @@ func createSnowflakeCredentialsConfiguration(snowflakeCredentials map[string]int
 
 	updateConfigurationRequestBuffer := buffer.FromForm(updateConfigurationRequestForm)
 
-	updateConfigurationResponse, err := client.PutToStorage(fmt.Sprintf("storage/components/keboola.wr-db-snowflake/configs/%s", createdSnowflakeID), updateConfigurationRequestBuffer)
+	updateConfigurationResponse, err := client.PutToStorage(componentsURI(d, client, "keboola.wr-db-snowflake/configs/%s", createdSnowflakeID), updateConfigurationRequestBuffer)
 
 	if hasErrors(err, updateConfigurationResponse) {
 		return extractError(err, updateConfigurationResponse)
@@ -278,19 +281,21 @@ func resourceKeboolaSnowflakeWriterRead(d *schema.ResourceData, meta interface{}
 	log.Println("[INFO] Reading Snowflake Writers from Keboola.")
 
 	client := meta.(*KBCClient)
-	getSnowflakeWriterResponse, err := client.GetFromStorage(fmt.Sprintf("storage/components/keboola.wr-db-snowflake/configs/%s", d.Id()))
+	getSnowflakeWriterResponse, err := client.GetFromStorage(componentsURI(d, client, "keboola.wr-db-snowflake/configs/%s", d.Id()))
 
 	if d.Id() == "" {
 		return nil
 	}
 
 	if hasErrors(err, getSnowflakeWriterResponse) {
-		if getSnowflakeWriterResponse.StatusCode == 404 {
+		err = extractError(err, getSnowflakeWriterResponse)
+
+		if isNotFoundError(err) {
 			d.SetId("")
 			return nil
 		}
 
-		return extractError(err, getSnowflakeWriterResponse)
+		return err
 	}
 
 	var snowflakeWriter SnowflakeWriter
@@ -305,7 +310,7 @@ func resourceKeboolaSnowflakeWriterRead(d *schema.ResourceData, meta interface{}
 	d.Set("name", snowflakeWriter.Name)
 	d.Set("description", snowflakeWriter.Description)
 
-	if d.Get("provision_new_database") == false {
+	if d.Get("provision_new_instance") == false {
 		dbParameters := make(map[string]interface{})
 
 		databaseCredentials := snowflakeWriter.Configuration.Parameters.Database
@@ -321,6 +326,8 @@ func resourceKeboolaSnowflakeWriterRead(d *schema.ResourceData, meta interface{}
 		d.Set("snowflake_db_parameters", dbParameters)
 	}
 
+	setBranchID(d, client)
+
 	return nil
 }
 
@@ -329,7 +336,7 @@ func resourceKeboolaSnowflakeWriterUpdate(d *schema.ResourceData, meta interface
 
 	client := meta.(*KBCClient)
 
-	getWriterResponse, err := client.GetFromStorage(fmt.Sprintf("storage/components/keboola.wr-db-snowflake/configs/%s", d.Id()))
+	getWriterResponse, err := client.GetFromStorage(componentsURI(d, client, "keboola.wr-db-snowflake/configs/%s", d.Id()))
 
 	if hasErrors(err, getWriterResponse) {
 		return extractError(err, getWriterResponse)
@@ -364,7 +371,7 @@ func resourceKeboolaSnowflakeWriterUpdate(d *schema.ResourceData, meta interface
 
 	updateCredentialsBuffer := buffer.FromForm(updateCredentialsForm)
 
-	updateCredentialsResponse, err := client.PutToStorage(fmt.Sprintf("storage/components/keboola.wr-db-snowflake/configs/%s", d.Id()), updateCredentialsBuffer)
+	updateCredentialsResponse, err := client.PutToStorage(componentsURI(d, client, "keboola.wr-db-snowflake/configs/%s", d.Id()), updateCredentialsBuffer)
 
 	if hasErrors(err, updateCredentialsResponse) {
 		return extractError(err, updateCredentialsResponse)
@@ -377,7 +384,7 @@ func resourceKeboolaSnowflakeWriterDelete(d *schema.ResourceData, meta interface
 	log.Printf("[INFO] Deleting Snowflake Writer in Keboola: %s", d.Id())
 
 	client := meta.(*KBCClient)
-	destroyResponse, err := client.DeleteFromStorage(fmt.Sprintf("storage/components/keboola.wr-db-snowflake/configs/%s", d.Id()))
+	destroyResponse, err := client.DeleteFromStorage(componentsURI(d, client, "keboola.wr-db-snowflake/configs/%s", d.Id()))
 
 	if hasErrors(err, destroyResponse) {
 		return extractError(err, destroyResponse)
@@ -12,12 +12,15 @@ import (
 
 func resourceKeboolaSnowflakeWriterTables() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceKeboolaSnowflakeWriterTablesCreate,
-		Read:   resourceKeboolaSnowflakeWriterTablesRead,
-		Update: resourceKeboolaSnowflakeWriterTablesUpdate,
-		Delete: resourceKeboolaSnowflakeWriterTablesDelete,
+		Create:        resourceKeboolaSnowflakeWriterTablesCreate,
+		Read:          resourceKeboolaSnowflakeWriterTablesRead,
+		Update:        resourceKeboolaSnowflakeWriterTablesUpdate,
+		Delete:        resourceKeboolaSnowflakeWriterTablesDelete,
+		CustomizeDiff: customizeBranchIDDiff,
 
 		Schema: map[string]*schema.Schema{
+			"branch_id":               &branchIDSchema,
+			"branch_id_from_provider": &branchIDFromProviderSchema,
 			"writer_id": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -185,7 +188,7 @@ func resourceKeboolaSnowflakeWriterTablesCreate(d *schema.ResourceData, meta int
 
 	client := meta.(*KBCClient)
 
-	getWriterResponse, err := client.GetFromStorage(fmt.Sprintf("storage/components/keboola.wr-db-snowflake/configs/%s", writerID))
+	getWriterResponse, err := client.GetFromStorage(componentsURI(d, client, "keboola.wr-db-snowflake/configs/%s", writerID))
 
 	if hasErrors(err, getWriterResponse) {
 		return extractError(err, getWriterResponse)
@@ -215,7 +218,7 @@ func resourceKeboolaSnowflakeWriterTablesCreate(d *schema.ResourceData, meta int
 
 	updateSnowflakeBuffer := buffer.FromForm(updateSnowflakeForm)
 
-	updateResponse, err := client.PutToStorage(fmt.Sprintf("storage/components/keboola.wr-db-snowflake/configs/%s", writerID), updateSnowflakeBuffer)
+	updateResponse, err := client.PutToStorage(componentsURI(d, client, "keboola.wr-db-snowflake/configs/%s", writerID), updateSnowflakeBuffer)
 
 	if hasErrors(err, updateResponse) {
 		return extractError(err, updateResponse)
@@ -235,15 +238,17 @@ func resourceKeboolaSnowflakeWriterTablesRead(d *schema.ResourceData, meta inter
 
 	client := meta.(*KBCClient)
 
-	getSnowflakeWriterResponse, err := client.GetFromStorage(fmt.Sprintf("storage/components/keboola.wr-db-snowflake/configs/%s", d.Id()))
+	getSnowflakeWriterResponse, err := client.GetFromStorage(componentsURI(d, client, "keboola.wr-db-snowflake/configs/%s", d.Id()))
 
 	if hasErrors(err, getSnowflakeWriterResponse) {
-		if getSnowflakeWriterResponse.StatusCode == 404 {
+		err = extractError(err, getSnowflakeWriterResponse)
+
+		if isNotFoundError(err) {
 			d.SetId("")
 			return nil
 		}
 
-		return extractError(err, getSnowflakeWriterResponse)
+		return err
 	}
 
 	var snowflakeWriter SnowflakeWriter
@@ -299,6 +304,8 @@ func resourceKeboolaSnowflakeWriterTablesRead(d *schema.ResourceData, meta inter
 
 	d.Set("table", tables)
 
+	setBranchID(d, client)
+
 	return nil
 }
 
@@ -371,7 +378,7 @@ func resourceKeboolaSnowflakeWriterTablesUpdate(d *schema.ResourceData, meta int
 
 	client := meta.(*KBCClient)
 
-	getWriterResponse, err := client.GetFromStorage(fmt.Sprintf("storage/components/keboola.wr-db-snowflake/configs/%s", d.Id()))
+	getWriterResponse, err := client.GetFromStorage(componentsURI(d, client, "keboola.wr-db-snowflake/configs/%s", d.Id()))
 
 	if hasErrors(err, getWriterResponse) {
 		return extractError(err, getWriterResponse)
@@ -401,7 +408,7 @@ func resourceKeboolaSnowflakeWriterTablesUpdate(d *schema.ResourceData, meta int
 
 	updateSnowflakeBuffer := buffer.FromForm(updateSnowflakeForm)
 
-	updateResponse, err := client.PutToStorage(fmt.Sprintf("storage/components/keboola.wr-db-snowflake/configs/%s", d.Id()), updateSnowflakeBuffer)
+	updateResponse, err := client.PutToStorage(componentsURI(d, client, "keboola.wr-db-snowflake/configs/%s", d.Id()), updateSnowflakeBuffer)
 
 	if hasErrors(err, updateResponse) {
 		return extractError(err, updateResponse)
@@ -415,7 +422,7 @@ func resourceKeboolaSnowflakeWriterTablesDelete(d *schema.ResourceData, meta int
 
 	client := meta.(*KBCClient)
 
-	getWriterResponse, err := client.GetFromStorage(fmt.Sprintf("storage/components/keboola.wr-db-snowflake/configs/%s", d.Id()))
+	getWriterResponse, err := client.GetFromStorage(componentsURI(d, client, "keboola.wr-db-snowflake/configs/%s", d.Id()))
 
 	if hasErrors(err, getWriterResponse) {
 		return extractError(err, getWriterResponse)
@@ -448,7 +455,7 @@ func resourceKeboolaSnowflakeWriterTablesDelete(d *schema.ResourceData, meta int
 
 	clearSnowflakeTablesBuffer := buffer.FromForm(clearSnowflakeTablesForm)
 
-	clearResponse, err := client.PutToStorage(fmt.Sprintf("storage/components/keboola.wr-db-snowflake/configs/%s", d.Id()), clearSnowflakeTablesBuffer)
+	clearResponse, err := client.PutToStorage(componentsURI(d, client, "keboola.wr-db-snowflake/configs/%s", d.Id()), clearSnowflakeTablesBuffer)
 
 	if hasErrors(err, clearResponse) {
 		return extractError(err, clearResponse)
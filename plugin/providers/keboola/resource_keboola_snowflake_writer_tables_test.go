@@ -0,0 +1,116 @@
+package keboola
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestUnitSnowflakeWriterTables_Basic(t *testing.T) {
+	mock := newMockKeboolaAPI()
+	defer mock.Close()
+
+	const writerPath = "/v2/storage/components/keboola.wr-db-snowflake/configs/%s"
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    mock.providers(),
+		CheckDestroy: mock.testCheckDestroy("keboola_snowflake_writer", writerPath, "id"),
+		Steps: []resource.TestStep{
+			{
+				Config: mock.config(testSnowflakeWriterTablesBasic),
+				Check: resource.ComposeTestCheckFunc(
+					mock.testCheckExists("keboola_snowflake_writer_tables.test_tables", writerPath, "id"),
+					resource.TestCheckResourceAttrPair("keboola_snowflake_writer_tables.test_tables", "writer_id", "keboola_snowflake_writer.test_writer", "id"),
+					resource.TestCheckResourceAttr("keboola_snowflake_writer_tables.test_tables", "table.#", "1"),
+				),
+			},
+			{
+				Config: mock.config(testSnowflakeWriterTablesUpdate),
+				Check: resource.ComposeTestCheckFunc(
+					mock.testCheckExists("keboola_snowflake_writer_tables.test_tables", writerPath, "id"),
+					resource.TestCheckResourceAttr("keboola_snowflake_writer_tables.test_tables", "table.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+const testSnowflakeWriterTablesBasic = `
+	resource "keboola_snowflake_writer" "test_writer" {
+		name = "test_snowflake_writer"
+		description = "test description"
+	}
+
+	resource "keboola_snowflake_writer_tables" "test_tables" {
+		writer_id = "${keboola_snowflake_writer.test_writer.id}"
+
+		table {
+			db_name = "ORDERS"
+			export = true
+			table_id = "in.c-crm.orders"
+
+			column {
+				name = "id"
+				db_name = "ID"
+				type = "integer"
+				size = ""
+			}
+
+			column {
+				name = "amount"
+				db_name = "AMOUNT"
+				type = "number"
+				size = "12,2"
+				nullable = true
+			}
+		}
+	}`
+
+const testSnowflakeWriterTablesUpdate = `
+	resource "keboola_snowflake_writer" "test_writer" {
+		name = "test_snowflake_writer"
+		description = "test description"
+	}
+
+	resource "keboola_snowflake_writer_tables" "test_tables" {
+		writer_id = "${keboola_snowflake_writer.test_writer.id}"
+
+		table {
+			db_name = "ORDERS"
+			export = true
+			table_id = "in.c-crm.orders"
+			incremental = true
+			primary_key = [ "id" ]
+			changed_since = "-2 days"
+
+			column {
+				name = "id"
+				db_name = "ID"
+				type = "integer"
+				size = ""
+			}
+
+			column {
+				name = "amount"
+				db_name = "AMOUNT"
+				type = "number"
+				size = "12,2"
+				nullable = true
+			}
+		}
+
+		table {
+			db_name = "CUSTOMERS"
+			export = true
+			table_id = "in.c-crm.customers"
+			where_column = "active"
+			where_values = [ "1" ]
+
+			column {
+				name = "name"
+				db_name = "NAME"
+				type = "varchar"
+				size = "255"
+			}
+		}
+	}`
@@ -84,3 +84,133 @@ const testSnowflakeWriterUpdate = `
 		name = "updated_test_snowflake_writer"
 		description = "updated test description"
 	}`
+
+const testSnowflakeWriterExternal = `
+	resource "keboola_snowflake_writer" "test_writer" {
+		name = "test_snowflake_writer"
+		description = "test description"
+		provision_new_instance = false
+
+		snowflake_db_parameters = {
+			hostname = "acme.snowflakecomputing.com"
+			port = "443"
+			database = "ANALYTICS"
+			schema = "PUBLIC"
+			warehouse = "LOADING"
+			username = "KEBOOLA"
+			hashed_password = "KBC::ProjectSecure::secret"
+		}
+	}`
+
+const testSnowflakeWriterExternalUpdate = `
+	resource "keboola_snowflake_writer" "test_writer" {
+		name = "test_snowflake_writer"
+		description = "test description"
+		provision_new_instance = false
+
+		snowflake_db_parameters = {
+			hostname = "other.snowflakecomputing.com"
+			port = "443"
+			database = "REPORTING"
+			schema = "PUBLIC"
+			warehouse = "LOADING"
+			username = "KEBOOLA"
+			hashed_password = "KBC::ProjectSecure::othersecret"
+		}
+	}`
+
+func TestUnitSnowflakeWriter_Basic(t *testing.T) {
+	mock := newMockKeboolaAPI()
+	defer mock.Close()
+
+	const writerPath = "/v2/storage/components/keboola.wr-db-snowflake/configs/%s"
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    mock.providers(),
+		CheckDestroy: mock.testCheckDestroy("keboola_snowflake_writer", writerPath, "id"),
+		Steps: []resource.TestStep{
+			{
+				Config: mock.config(testSnowflakeWriterBasic),
+				Check: resource.ComposeTestCheckFunc(
+					mock.testCheckExists("keboola_snowflake_writer.test_writer", writerPath, "id"),
+					resource.TestCheckResourceAttr("keboola_snowflake_writer.test_writer", "name", "test_snowflake_writer"),
+					resource.TestCheckResourceAttr("keboola_snowflake_writer.test_writer", "provision_new_instance", "true"),
+				),
+			},
+			{
+				Config: mock.config(testSnowflakeWriterUpdate),
+				Check: resource.ComposeTestCheckFunc(
+					mock.testCheckExists("keboola_snowflake_writer.test_writer", writerPath, "id"),
+					resource.TestCheckResourceAttr("keboola_snowflake_writer.test_writer", "name", "updated_test_snowflake_writer"),
+					resource.TestCheckResourceAttr("keboola_snowflake_writer.test_writer", "description", "updated test description"),
+				),
+			},
+			{
+				Config:                  mock.config(testSnowflakeWriterUpdate),
+				ResourceName:            "keboola_snowflake_writer.test_writer",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"provision_new_instance", "snowflake_db_parameters"},
+			},
+		},
+	})
+}
+
+func TestUnitSnowflakeWriter_ExternalDatabase(t *testing.T) {
+	mock := newMockKeboolaAPI()
+	defer mock.Close()
+
+	const writerPath = "/v2/storage/components/keboola.wr-db-snowflake/configs/%s"
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    mock.providers(),
+		CheckDestroy: mock.testCheckDestroy("keboola_snowflake_writer", writerPath, "id"),
+		Steps: []resource.TestStep{
+			{
+				Config: mock.config(testSnowflakeWriterExternal),
+				Check: resource.ComposeTestCheckFunc(
+					mock.testCheckExists("keboola_snowflake_writer.test_writer", writerPath, "id"),
+					resource.TestCheckResourceAttr("keboola_snowflake_writer.test_writer", "snowflake_db_parameters.hostname", "acme.snowflakecomputing.com"),
+					resource.TestCheckResourceAttr("keboola_snowflake_writer.test_writer", "snowflake_db_parameters.database", "ANALYTICS"),
+				),
+			},
+			{
+				Config: mock.config(testSnowflakeWriterExternalUpdate),
+				Check: resource.ComposeTestCheckFunc(
+					mock.testCheckExists("keboola_snowflake_writer.test_writer", writerPath, "id"),
+					resource.TestCheckResourceAttr("keboola_snowflake_writer.test_writer", "snowflake_db_parameters.hostname", "other.snowflakecomputing.com"),
+					resource.TestCheckResourceAttr("keboola_snowflake_writer.test_writer", "snowflake_db_parameters.database", "REPORTING"),
+					resource.TestCheckResourceAttr("keboola_snowflake_writer.test_writer", "snowflake_db_parameters.hashed_password", "KBC::ProjectSecure::othersecret"),
+				),
+			},
+			{
+				//Credentials changed outside of Terraform must be read back, so that they show up as a diff.
+				PreConfig: func() {
+					mock.mutex.Lock()
+					defer mock.mutex.Unlock()
+
+					for _, configuration := range mock.components["keboola.wr-db-snowflake"] {
+						parameters := configuration.Configuration.(map[string]interface{})["parameters"].(map[string]interface{})
+						parameters["db"].(map[string]interface{})["host"] = "changed.snowflakecomputing.com"
+					}
+				},
+				Config:             mock.config(testSnowflakeWriterExternalUpdate),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: mock.config(testSnowflakeWriterExternalUpdate),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("keboola_snowflake_writer.test_writer", "snowflake_db_parameters.hostname", "other.snowflakecomputing.com"),
+				),
+			},
+			{
+				Config:                  mock.config(testSnowflakeWriterExternalUpdate),
+				ResourceName:            "keboola_snowflake_writer.test_writer",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"provision_new_instance"},
+			},
+		},
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"net/http"
 	"net/url"
 	"strings"
 
@@ -21,6 +22,19 @@ type StorageBucket struct {
 	Stage       string `json:"stage"`
 	Description string `json:"description"`
 	Backend     string `json:"backend,omitempty"`
+
+	RowsCount      int    `json:"rowsCount,omitempty"`
+	DataSizeBytes  int    `json:"dataSizeBytes,omitempty"`
+	Created        string `json:"created,omitempty"`
+	LastChangeDate string `json:"lastChangeDate,omitempty"`
+
+	Metadata     []StorageMetadata `json:"metadata,omitempty"`
+	SourceBucket *struct {
+		ID      string `json:"id"`
+		Project struct {
+			ID KBCNumberString `json:"id"`
+		} `json:"project"`
+	} `json:"sourceBucket,omitempty"`
 }
 
 //endregion
@@ -29,11 +43,18 @@ func resourceKeboolaStorageBucket() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceKeboolaStorageBucketCreate,
 		Read:   resourceKeboolaStorageBucketRead,
+		Update: resourceKeboolaStorageBucketUpdate,
 		Delete: resourceKeboolaStorageBucketDelete,
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
 
+		CustomizeDiff: resourceKeboolaStorageBucketCustomizeDiff,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultJobTimeout),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -54,29 +75,76 @@ func resourceKeboolaStorageBucket() *schema.Resource {
 			"backend": {
 				Type:         schema.TypeString,
 				Optional:     true,
+				Computed:     true,
 				ForceNew:     true,
 				ValidateFunc: validateStorageBucketBackend,
 			},
 			"is_linked": {
-				Type:         schema.TypeBool,
-				Optional:     true,
-				ForceNew:     true,
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Whether the bucket is linked to a bucket shared by another project, given by source_project_id and source_bucket_id. Changing it replaces the bucket, as a bucket cannot be linked or unlinked in place.",
 			},
 			"source_project_id": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				ForceNew:     true,
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The ID of the project sharing the bucket that a linked bucket is linked to. Changing it unlinks the bucket and links it again to the new source.",
 			},
 			"source_bucket_id": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				ForceNew:     true,
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The ID of the shared bucket that a linked bucket is linked to. Changing it unlinks the bucket and links it again to the new source.",
+			},
+			"metadata": &metadataSchema,
+			"deletion_protection": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether deleting (or replacing) the bucket fails while any of its tables has rows.",
+			},
+			"force_delete": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the bucket may be deleted while it still contains tables, deleting them along with it.",
 			},
 		},
 	}
 }
 
+//resourceKeboolaStorageBucketCustomizeDiff checks that a linked bucket has both a source project and
+//bucket, and that these are not set for a bucket which is not linked (and so would be silently ignored).
+func resourceKeboolaStorageBucketCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("is_linked") {
+		return nil
+	}
+
+	if d.Get("is_linked").(bool) {
+		for _, sourceKey := range []string{"source_project_id", "source_bucket_id"} {
+			if d.NewValueKnown(sourceKey) && d.Get(sourceKey).(string) == "" {
+				return fmt.Errorf("%s is required when is_linked is true", sourceKey)
+			}
+		}
+
+		return nil
+	}
+
+	for _, sourceKey := range []string{"source_project_id", "source_bucket_id"} {
+		if d.NewValueKnown(sourceKey) && d.Get(sourceKey).(string) != "" {
+			return fmt.Errorf("%s can only be set when is_linked is true", sourceKey)
+		}
+	}
+
+	return nil
+}
+
 func resourceKeboolaStorageBucketCreate(d *schema.ResourceData, meta interface{}) error {
+	if d.Get("is_linked").(bool) {
+		return resourceKeboolaStorageBucketLink(d, meta)
+	}
+
 	log.Println("[INFO] Creating Storage Bucket in Keboola.")
 
 	createBucketForm := url.Values{}
@@ -85,11 +153,6 @@ func resourceKeboolaStorageBucketCreate(d *schema.ResourceData, meta interface{}
 	createBucketForm.Add("description", d.Get("description").(string))
 	createBucketForm.Add("backend", d.Get("backend").(string))
 
-	if d.Get("is_linked").(bool) == true {
-		createBucketForm.Add("sourceProjectId", d.Get("source_project_id").(string))
-		createBucketForm.Add("sourceBucketId", d.Get("source_bucket_id").(string))
-	}
-
 	createBucketBuffer := buffer.FromForm(createBucketForm)
 
 	client := meta.(*KBCClient)
@@ -110,6 +173,66 @@ func resourceKeboolaStorageBucketCreate(d *schema.ResourceData, meta interface{}
 
 	d.SetId(string(createBucketResult.ID))
 
+	if _, ok := d.GetOk("metadata"); ok {
+		err = updateStorageBucketMetadata(d, client)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return resourceKeboolaStorageBucketRead(d, meta)
+}
+
+//resourceKeboolaStorageBucketLink creates a bucket which is linked to a bucket shared by another project.
+func resourceKeboolaStorageBucketLink(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[INFO] Linking Storage Bucket %s of project %s in Keboola.", d.Get("source_bucket_id"), d.Get("source_project_id"))
+
+	linkBucketForm := url.Values{}
+	linkBucketForm.Add("name", d.Get("name").(string))
+	linkBucketForm.Add("stage", d.Get("stage").(string))
+	linkBucketForm.Add("sourceProjectId", d.Get("source_project_id").(string))
+	linkBucketForm.Add("sourceBucketId", d.Get("source_bucket_id").(string))
+
+	linkBucketBuffer := buffer.FromForm(linkBucketForm)
+
+	client := meta.(*KBCClient)
+	linkResponse, err := client.PostToStorage("storage/buckets/link", linkBucketBuffer)
+
+	if hasErrors(err, linkResponse) {
+		return extractError(err, linkResponse)
+	}
+
+	//The bucket is usually linked by an asynchronous job, but may also be linked straight away.
+	if linkResponse.StatusCode == http.StatusAccepted {
+		linkStatusResult, err := client.waitForAcceptedStorageJob(linkResponse, d.Timeout(schema.TimeoutCreate))
+
+		if err != nil {
+			return err
+		}
+
+		d.SetId(string(linkStatusResult.Results.ID))
+	} else {
+		var linkResult CreateResourceResult
+
+		decoder := json.NewDecoder(linkResponse.Body)
+		err = decoder.Decode(&linkResult)
+
+		if err != nil {
+			return err
+		}
+
+		d.SetId(string(linkResult.ID))
+	}
+
+	if _, ok := d.GetOk("metadata"); ok {
+		err = updateStorageBucketMetadata(d, client)
+
+		if err != nil {
+			return err
+		}
+	}
+
 	return resourceKeboolaStorageBucketRead(d, meta)
 }
 
@@ -124,12 +247,14 @@ func resourceKeboolaStorageBucketRead(d *schema.ResourceData, meta interface{})
 	}
 
 	if hasErrors(err, getResponse) {
-		if getResponse.StatusCode == 404 {
+		err = extractError(err, getResponse)
+
+		if isNotFoundError(err) {
 			d.SetId("")
 			return nil
 		}
 
-		return extractError(err, getResponse)
+		return err
 	}
 
 	var storageBucket StorageBucket
@@ -146,15 +271,101 @@ func resourceKeboolaStorageBucketRead(d *schema.ResourceData, meta interface{})
 	d.Set("stage", storageBucket.Stage)
 	d.Set("description", storageBucket.Description)
 	d.Set("backend", storageBucket.Backend)
+	d.Set("is_linked", storageBucket.SourceBucket != nil)
+
+	if storageBucket.SourceBucket != nil {
+		d.Set("source_project_id", string(storageBucket.SourceBucket.Project.ID))
+		d.Set("source_bucket_id", storageBucket.SourceBucket.ID)
+	} else {
+		d.Set("source_project_id", "")
+		d.Set("source_bucket_id", "")
+	}
+	d.Set("metadata", storageMetadataValues(storageBucket.Metadata, client.MetadataProvider))
+
+	//Buckets which have been imported (or created before deletion_protection was added) are protected.
+	if _, ok := d.GetOkExists("deletion_protection"); !ok {
+		d.Set("deletion_protection", true)
+	}
+
+	if _, ok := d.GetOkExists("force_delete"); !ok {
+		d.Set("force_delete", false)
+	}
 
 	return nil
 }
 
+func resourceKeboolaStorageBucketUpdate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[INFO] Updating Storage Bucket in Keboola: %s", d.Id())
+
+	client := meta.(*KBCClient)
+	err := updateStorageBucketMetadata(d, client)
+
+	if err != nil {
+		return err
+	}
+
+	return resourceKeboolaStorageBucketRead(d, meta)
+}
+
+//updateStorageBucketMetadata sets and deletes the metadata of a bucket (under the provider's
+//metadata_provider), so that it matches the metadata attribute.
+func updateStorageBucketMetadata(d *schema.ResourceData, client *KBCClient) error {
+	bucketURI := fmt.Sprintf("storage/buckets/%s", d.Id())
+	getResponse, err := client.GetFromStorage(bucketURI)
+
+	if hasErrors(err, getResponse) {
+		return extractError(err, getResponse)
+	}
+
+	var storageBucket StorageBucket
+
+	decoder := json.NewDecoder(getResponse.Body)
+	err = decoder.Decode(&storageBucket)
+
+	if err != nil {
+		return err
+	}
+
+	changedMetadata, removedMetadata := diffStorageMetadata(storageBucket.Metadata, client.MetadataProvider, d.Get("metadata").(map[string]interface{}))
+
+	if len(changedMetadata) > 0 {
+		metadataForm := url.Values{}
+		addStorageMetadataForm(metadataForm, "metadata", changedMetadata)
+
+		err = postStorageMetadata(client, bucketURI, metadataForm)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return deleteStorageMetadata(client, bucketURI, removedMetadata)
+}
+
+//resourceKeboolaStorageBucketDelete refuses to delete a bucket which still contains tables, unless force_delete
+//is set, and one whose tables have rows, unless deletion_protection is turned off. Linked buckets are only
+//unlinked from their source, so they can always be deleted.
 func resourceKeboolaStorageBucketDelete(d *schema.ResourceData, meta interface{}) error {
 	log.Printf("[INFO] Deleting Storage Bucket in Keboola: %s", d.Id())
 
 	client := meta.(*KBCClient)
-	destroyResponse, err := client.DeleteFromStorage(fmt.Sprintf("storage/buckets/%s", d.Id()))
+	forceDelete := d.Get("force_delete").(bool)
+
+	if !d.Get("is_linked").(bool) && (d.Get("deletion_protection").(bool) || !forceDelete) {
+		err := checkStorageBucketDeletable(d, client, forceDelete)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	bucketURI := fmt.Sprintf("storage/buckets/%s", d.Id())
+
+	if forceDelete {
+		bucketURI += "?force=true"
+	}
+
+	destroyResponse, err := client.DeleteFromStorage(bucketURI)
 
 	if hasErrors(err, destroyResponse) {
 		return extractError(err, destroyResponse)
@@ -164,3 +375,44 @@ func resourceKeboolaStorageBucketDelete(d *schema.ResourceData, meta interface{}
 
 	return nil
 }
+
+//checkStorageBucketDeletable returns an error if a bucket has tables with rows, or (unless forceDelete) any tables at all.
+func checkStorageBucketDeletable(d *schema.ResourceData, client *KBCClient, forceDelete bool) error {
+	getResponse, err := client.GetFromStorage(fmt.Sprintf("storage/buckets/%s/tables", d.Id()))
+
+	if hasErrors(err, getResponse) {
+		err = extractError(err, getResponse)
+
+		if isNotFoundError(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	var storageTables []StorageTable
+
+	decoder := json.NewDecoder(getResponse.Body)
+	err = decoder.Decode(&storageTables)
+
+	if err != nil {
+		return err
+	}
+
+	rowsCount := 0
+	for _, storageTable := range storageTables {
+		if !storageTable.IsAlias {
+			rowsCount += storageTable.RowsCount
+		}
+	}
+
+	if rowsCount > 0 && d.Get("deletion_protection").(bool) {
+		return fmt.Errorf("bucket %s has %d rows in %d tables, set deletion_protection = false (and force_delete = true) to delete it along with its data", d.Id(), rowsCount, len(storageTables))
+	}
+
+	if len(storageTables) > 0 && !forceDelete {
+		return fmt.Errorf("bucket %s still contains %d tables with %d rows, set force_delete = true to delete them along with the bucket", d.Id(), len(storageTables), rowsCount)
+	}
+
+	return nil
+}
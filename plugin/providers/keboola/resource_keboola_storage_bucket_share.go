@@ -0,0 +1,283 @@
+package keboola
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/paybyphone/terraform-provider-keboola/plugin/providers/keboola/buffer"
+)
+
+//region Keboola API Contracts
+
+//StorageBucketSharing is the data model for the sharing of a storage bucket
+//with other projects within the Keboola Storage API.
+type StorageBucketSharing struct {
+	ID                string `json:"id"`
+	Sharing           string `json:"sharing"`
+	SharingParameters struct {
+		Projects []struct {
+			ID KBCNumberString `json:"id"`
+		} `json:"projects"`
+		Users []struct {
+			ID    KBCNumberString `json:"id"`
+			Email string          `json:"email"`
+		} `json:"users"`
+	} `json:"sharingParameters"`
+	LinkedBy []struct {
+		Project struct {
+			ID KBCNumberString `json:"id"`
+		} `json:"project"`
+	} `json:"linkedBy"`
+}
+
+//endregion
+
+//bucketSharingEndpoints maps the sharing modes of keboola_storage_bucket_share to the Storage API
+//endpoint used to share a bucket in that mode.
+var bucketSharingEndpoints = map[string]string{
+	"organization":         "share-organization",
+	"organization-project": "share-organization-project",
+	"selected-projects":    "share-to-projects",
+	"selected-users":       "share-to-users",
+}
+
+//bucketSharingModes maps the sharing types returned by the Storage API to the sharing modes of keboola_storage_bucket_share.
+var bucketSharingModes = map[string]string{
+	"organization":         "organization",
+	"organization-project": "organization-project",
+	"specific-projects":    "selected-projects",
+	"specific-users":       "selected-users",
+}
+
+func resourceKeboolaStorageBucketShare() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceKeboolaStorageBucketShareCreate,
+		Read:   resourceKeboolaStorageBucketShareRead,
+		Update: resourceKeboolaStorageBucketShareUpdate,
+		Delete: resourceKeboolaStorageBucketShareDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		CustomizeDiff: resourceKeboolaStorageBucketShareCustomizeDiff,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultJobTimeout),
+			Update: schema.DefaultTimeout(defaultJobTimeout),
+			Delete: schema.DefaultTimeout(defaultJobTimeout),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"bucket_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"sharing": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateBucketSharing,
+				Description:  "Who the bucket is shared with: organization (all projects of the organization, for all of their users), organization-project (all projects of the organization, for organization members), selected-projects or selected-users.",
+			},
+			"project_ids": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Description: "The IDs of the projects the bucket is shared with, when sharing is selected-projects.",
+			},
+			"users": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The e-mail addresses of the users the bucket is shared with, when sharing is selected-users.",
+			},
+			"linked_by_project_ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+			},
+		},
+	}
+}
+
+//resourceKeboolaStorageBucketShareCustomizeDiff checks that project_ids and users are set
+//for, and only for, the sharing modes that share the bucket with them.
+func resourceKeboolaStorageBucketShareCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("sharing") {
+		return nil
+	}
+
+	sharing := d.Get("sharing").(string)
+
+	for sharingMode, targetKey := range map[string]string{"selected-projects": "project_ids", "selected-users": "users"} {
+		if !d.NewValueKnown(targetKey) {
+			continue
+		}
+
+		hasTargets := d.Get(targetKey).(*schema.Set).Len() > 0
+
+		if sharing == sharingMode && !hasTargets {
+			return fmt.Errorf("%s is required when sharing is %s", targetKey, sharingMode)
+		}
+
+		if sharing != sharingMode && hasTargets {
+			return fmt.Errorf("%s can only be set when sharing is %s", targetKey, sharingMode)
+		}
+	}
+
+	return nil
+}
+
+//shareStorageBucket shares a bucket in the configured sharing mode. A bucket which is not shared yet is shared using POST,
+//while a bucket which is already shared is switched to the new mode (or its new projects or users) using PUT.
+func shareStorageBucket(d *schema.ResourceData, client *KBCClient, method string, timeout string) error {
+	sharing := d.Get("sharing").(string)
+
+	shareForm := url.Values{}
+
+	for _, projectID := range d.Get("project_ids").(*schema.Set).List() {
+		shareForm.Add("targetProjectIds[]", strconv.Itoa(projectID.(int)))
+	}
+
+	for _, user := range d.Get("users").(*schema.Set).List() {
+		shareForm.Add("targetUsers[]", user.(string))
+	}
+
+	shareURI := fmt.Sprintf("storage/buckets/%s/%s", d.Get("bucket_id").(string), bucketSharingEndpoints[sharing])
+	shareBuffer := buffer.FromForm(shareForm)
+
+	var shareResponse *http.Response
+	var err error
+
+	if method == http.MethodPut {
+		shareResponse, err = client.PutToStorage(shareURI, shareBuffer)
+	} else {
+		shareResponse, err = client.PostToStorage(shareURI, shareBuffer)
+	}
+
+	if hasErrors(err, shareResponse) {
+		return extractError(err, shareResponse)
+	}
+
+	_, err = client.waitForAcceptedStorageJob(shareResponse, d.Timeout(timeout))
+
+	return err
+}
+
+func resourceKeboolaStorageBucketShareCreate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[INFO] Sharing Storage Bucket %s in Keboola.", d.Get("bucket_id"))
+
+	client := meta.(*KBCClient)
+	err := shareStorageBucket(d, client, http.MethodPost, schema.TimeoutCreate)
+
+	if err != nil {
+		return err
+	}
+
+	d.SetId(d.Get("bucket_id").(string))
+
+	return resourceKeboolaStorageBucketShareRead(d, meta)
+}
+
+func resourceKeboolaStorageBucketShareRead(d *schema.ResourceData, meta interface{}) error {
+	log.Println("[INFO] Reading Storage Bucket Sharing from Keboola.")
+
+	if d.Id() == "" {
+		return nil
+	}
+
+	client := meta.(*KBCClient)
+	getResponse, err := client.GetFromStorage(fmt.Sprintf("storage/buckets/%s", d.Id()))
+
+	if hasErrors(err, getResponse) {
+		err = extractError(err, getResponse)
+
+		if isNotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+
+		return err
+	}
+
+	var bucketSharing StorageBucketSharing
+
+	decoder := json.NewDecoder(getResponse.Body)
+	err = decoder.Decode(&bucketSharing)
+
+	if err != nil {
+		return err
+	}
+
+	sharing, ok := bucketSharingModes[bucketSharing.Sharing]
+
+	if !ok {
+		log.Printf("[WARN] Storage Bucket %s is no longer shared (sharing: %q)", d.Id(), bucketSharing.Sharing)
+		d.SetId("")
+		return nil
+	}
+
+	var projectIDs []int
+	for _, project := range bucketSharing.SharingParameters.Projects {
+		projectID, _ := strconv.Atoi(string(project.ID))
+		projectIDs = append(projectIDs, projectID)
+	}
+
+	var users []string
+	for _, user := range bucketSharing.SharingParameters.Users {
+		users = append(users, user.Email)
+	}
+
+	var linkedByProjectIDs []int
+	for _, link := range bucketSharing.LinkedBy {
+		projectID, _ := strconv.Atoi(string(link.Project.ID))
+		linkedByProjectIDs = append(linkedByProjectIDs, projectID)
+	}
+
+	d.Set("bucket_id", bucketSharing.ID)
+	d.Set("sharing", sharing)
+	d.Set("project_ids", projectIDs)
+	d.Set("users", users)
+	d.Set("linked_by_project_ids", linkedByProjectIDs)
+
+	return nil
+}
+
+func resourceKeboolaStorageBucketShareUpdate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[INFO] Updating Storage Bucket Sharing in Keboola: %s", d.Id())
+
+	client := meta.(*KBCClient)
+	err := shareStorageBucket(d, client, http.MethodPut, schema.TimeoutUpdate)
+
+	if err != nil {
+		return err
+	}
+
+	return resourceKeboolaStorageBucketShareRead(d, meta)
+}
+
+func resourceKeboolaStorageBucketShareDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[INFO] Stopping Sharing of Storage Bucket in Keboola: %s", d.Id())
+
+	client := meta.(*KBCClient)
+	destroyResponse, err := client.DeleteFromStorage(fmt.Sprintf("storage/buckets/%s/share", d.Id()))
+
+	if hasErrors(err, destroyResponse) {
+		return extractError(err, destroyResponse)
+	}
+
+	_, err = client.waitForAcceptedStorageJob(destroyResponse, d.Timeout(schema.TimeoutDelete))
+
+	if err != nil {
+		return err
+	}
+
+	d.SetId("")
+
+	return nil
+}
@@ -0,0 +1,266 @@
+package keboola
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccStorageBucketShare_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testAccCheckStorageBucketShareDestroy,
+			testAccCheckStorageBucketDestroy,
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: testStorageBucketShare(`sharing = "organization-project"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("keboola_storage_bucket_share.test_share", "sharing", "organization-project"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckStorageBucketShareDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*KBCClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "keboola_storage_bucket_share" {
+			continue
+		}
+
+		getResp, err := client.GetFromStorage(fmt.Sprintf("storage/buckets/%s", rs.Primary.ID))
+
+		if err != nil || getResp.StatusCode != 200 {
+			continue
+		}
+
+		var bucketSharing StorageBucketSharing
+
+		if err := json.NewDecoder(getResp.Body).Decode(&bucketSharing); err == nil && bucketSharing.Sharing != "" {
+			return fmt.Errorf("Storage bucket %s is still shared", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testStorageBucketShare(share string) string {
+	return fmt.Sprintf(`
+	resource "keboola_storage_bucket" "shared_bucket" {
+		name = "shared_bucket"
+		stage = "out"
+		backend = "snowflake"
+	}
+
+	resource "keboola_storage_bucket_share" "test_share" {
+		bucket_id = "${keboola_storage_bucket.shared_bucket.id}"
+		%s
+	}`, share)
+}
+
+func testCheckMockBucketSharing(mock *mockKeboolaAPI, bucketID string, expected string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		mock.mutex.Lock()
+		defer mock.mutex.Unlock()
+
+		if bucket, ok := mock.buckets[bucketID]; !ok || bucket.Sharing != expected {
+			return fmt.Errorf("Expected bucket %s to be shared as %q", bucketID, expected)
+		}
+
+		return nil
+	}
+}
+
+func TestUnitStorageBucketShare_Basic(t *testing.T) {
+	mock := newMockKeboolaAPI()
+	defer mock.Close()
+
+	const bucketID = "out.c-shared_bucket"
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    mock.providers(),
+		CheckDestroy: mock.testCheckDestroy("keboola_storage_bucket", "/v2/storage/buckets/%s", "id"),
+		Steps: []resource.TestStep{
+			{
+				Config: mock.config(testStorageBucketShare(`sharing = "organization-project"`)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("keboola_storage_bucket_share.test_share", "id", bucketID),
+					resource.TestCheckResourceAttr("keboola_storage_bucket_share.test_share", "sharing", "organization-project"),
+					testCheckMockBucketSharing(mock, bucketID, "organization-project"),
+				),
+			},
+			{
+				Config: mock.config(testStorageBucketShare(`
+		sharing = "selected-projects"
+		project_ids = [ 42, 1234 ]`)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("keboola_storage_bucket_share.test_share", "sharing", "selected-projects"),
+					resource.TestCheckResourceAttr("keboola_storage_bucket_share.test_share", "project_ids.#", "2"),
+					testCheckMockBucketSharing(mock, bucketID, "specific-projects"),
+				),
+			},
+			{
+				Config: mock.config(testStorageBucketShare(`
+		sharing = "selected-projects"
+		project_ids = [ 42, 1234 ]`)),
+				ResourceName:      "keboola_storage_bucket_share.test_share",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: mock.config(testStorageBucketShare(`
+		sharing = "selected-users"
+		users = [ "jane@example.com" ]`)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("keboola_storage_bucket_share.test_share", "sharing", "selected-users"),
+					resource.TestCheckResourceAttr("keboola_storage_bucket_share.test_share", "users.#", "1"),
+					resource.TestCheckResourceAttr("keboola_storage_bucket_share.test_share", "project_ids.#", "0"),
+					testCheckMockBucketSharing(mock, bucketID, "specific-users"),
+				),
+			},
+			{
+				Config:      mock.config(testStorageBucketShare(`sharing = "selected-projects"`)),
+				ExpectError: regexp.MustCompile("project_ids is required when sharing is selected-projects"),
+			},
+			{
+				Config: mock.config(testStorageBucketShare(`
+		sharing = "organization"
+		users = [ "jane@example.com" ]`)),
+				ExpectError: regexp.MustCompile("users can only be set when sharing is selected-users"),
+			},
+			{
+				Config: mock.config(testStorageBucketShare(`sharing = "organization"`)),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckMockBucketSharing(mock, bucketID, "organization"),
+				),
+			},
+			{
+				Config: mock.config(`
+	resource "keboola_storage_bucket" "shared_bucket" {
+		name = "shared_bucket"
+		stage = "out"
+		backend = "snowflake"
+	}`),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckMockBucketSharing(mock, bucketID, ""),
+				),
+			},
+		},
+	})
+}
+
+const testStorageBucketLinked = `
+	resource "keboola_storage_bucket" "linked_bucket" {
+		name = "linked_bucket"
+		stage = "in"
+		is_linked = true
+		source_project_id = "1234"
+		source_bucket_id = "${keboola_storage_bucket_share.test_share.bucket_id}"
+	}`
+
+func TestUnitStorageBucketShare_Link(t *testing.T) {
+	mock := newMockKeboolaAPI()
+	defer mock.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    mock.providers(),
+		CheckDestroy: mock.testCheckDestroy("keboola_storage_bucket", "/v2/storage/buckets/%s", "id"),
+		Steps: []resource.TestStep{
+			{
+				Config: mock.config(testStorageBucketShare(`sharing = "organization"`) + `
+	resource "keboola_storage_bucket" "linked_bucket" {
+		name = "linked_bucket"
+		stage = "in"
+		is_linked = true
+		source_project_id = "1234"
+	}`),
+				ExpectError: regexp.MustCompile("source_bucket_id is required when is_linked is true"),
+			},
+			{
+				Config: mock.config(testStorageBucketShare(`sharing = "organization"`) + `
+	resource "keboola_storage_bucket" "linked_bucket" {
+		name = "linked_bucket"
+		stage = "in"
+		source_project_id = "1234"
+		source_bucket_id = "out.c-shared_bucket"
+	}`),
+				ExpectError: regexp.MustCompile("source_project_id can only be set when is_linked is true"),
+			},
+			{
+				Config: mock.config(testStorageBucketShare(`sharing = "organization"`) + testStorageBucketLinked),
+				Check: resource.ComposeTestCheckFunc(
+					mock.testCheckExists("keboola_storage_bucket.linked_bucket", "/v2/storage/buckets/%s", "id"),
+					resource.TestCheckResourceAttr("keboola_storage_bucket.linked_bucket", "id", "in.c-linked_bucket"),
+					resource.TestCheckResourceAttr("keboola_storage_bucket.linked_bucket", "is_linked", "true"),
+					resource.TestCheckResourceAttr("keboola_storage_bucket.linked_bucket", "source_bucket_id", "out.c-shared_bucket"),
+				),
+			},
+			{
+				Config: mock.config(testStorageBucketShare(`sharing = "organization"`) + testStorageBucketLinked),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("keboola_storage_bucket_share.test_share", "linked_by_project_ids.#", "1"),
+					resource.TestCheckResourceAttr("keboola_storage_bucket_share.test_share", "linked_by_project_ids.0", "1234"),
+				),
+			},
+			{
+				Config:            mock.config(testStorageBucketShare(`sharing = "organization"`) + testStorageBucketLinked),
+				ResourceName:      "keboola_storage_bucket.linked_bucket",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: mock.config(testStorageBucketShare(`sharing = "organization"`) + `
+	resource "keboola_storage_bucket" "linked_bucket" {
+		name = "linked_bucket"
+		stage = "in"
+	}`),
+				Check: resource.ComposeTestCheckFunc(
+					mock.testCheckExists("keboola_storage_bucket.linked_bucket", "/v2/storage/buckets/%s", "id"),
+					resource.TestCheckResourceAttr("keboola_storage_bucket.linked_bucket", "is_linked", "false"),
+					resource.TestCheckResourceAttr("keboola_storage_bucket.linked_bucket", "source_bucket_id", ""),
+				),
+			},
+			{
+				Config: mock.config(testStorageBucketShare(`sharing = "organization"`) + `
+	resource "keboola_storage_bucket" "linked_bucket" {
+		name = "linked_bucket"
+		stage = "in"
+	}`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("keboola_storage_bucket_share.test_share", "linked_by_project_ids.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitStorageBucketShare_LinkedSynchronously(t *testing.T) {
+	mock := newMockKeboolaAPI()
+	defer mock.Close()
+
+	mock.synchronousStorageJobs = true
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    mock.providers(),
+		CheckDestroy: mock.testCheckDestroy("keboola_storage_bucket", "/v2/storage/buckets/%s", "id"),
+		Steps: []resource.TestStep{
+			{
+				Config: mock.config(testStorageBucketShare(`sharing = "organization"`) + testStorageBucketLinked),
+				Check: resource.ComposeTestCheckFunc(
+					mock.testCheckExists("keboola_storage_bucket.linked_bucket", "/v2/storage/buckets/%s", "id"),
+					resource.TestCheckResourceAttr("keboola_storage_bucket.linked_bucket", "id", "in.c-linked_bucket"),
+					resource.TestCheckResourceAttr("keboola_storage_bucket.linked_bucket", "source_bucket_id", "out.c-shared_bucket"),
+				),
+			},
+		},
+	})
+}
@@ -3,6 +3,7 @@ package keboola
 import (
 	"encoding/json"
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform/helper/resource"
@@ -98,3 +99,250 @@ resource "keboola_storage_bucket" "test_bucket" {
 	stage = "out"
 	backend = "snowflake"
 }`
+
+func TestUnitStorageBucket_Basic(t *testing.T) {
+	mock := newMockKeboolaAPI()
+	defer mock.Close()
+
+	const bucketPath = "/v2/storage/buckets/%s"
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    mock.providers(),
+		CheckDestroy: mock.testCheckDestroy("keboola_storage_bucket", bucketPath, "id"),
+		Steps: []resource.TestStep{
+			{
+				Config: mock.config(testStorageBucketBasic),
+				Check: resource.ComposeTestCheckFunc(
+					mock.testCheckExists("keboola_storage_bucket.test_bucket", bucketPath, "id"),
+					resource.TestCheckResourceAttr("keboola_storage_bucket.test_bucket", "id", "out.c-test_bucket_name"),
+					resource.TestCheckResourceAttr("keboola_storage_bucket.test_bucket", "name", "test_bucket_name"),
+					resource.TestCheckResourceAttr("keboola_storage_bucket.test_bucket", "description", "test description"),
+				),
+			},
+			{
+				Config: mock.config(testStorageBucketReplaced),
+				Check: resource.ComposeTestCheckFunc(
+					mock.testCheckExists("keboola_storage_bucket.test_bucket", bucketPath, "id"),
+					resource.TestCheckResourceAttr("keboola_storage_bucket.test_bucket", "id", "in.c-test_bucket_name"),
+					resource.TestCheckResourceAttr("keboola_storage_bucket.test_bucket", "stage", "in"),
+				),
+			},
+			{
+				Config:            mock.config(testStorageBucketReplaced),
+				ResourceName:      "keboola_storage_bucket.test_bucket",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+const testStorageBucketReplaced = `
+resource "keboola_storage_bucket" "test_bucket" {
+	name = "test_bucket_name"
+	description = "test description"
+	stage = "in"
+	backend = "snowflake"
+}`
+
+const testStorageBucketWithMetadata = `
+resource "keboola_storage_bucket" "test_bucket" {
+	name = "test_bucket_name"
+	stage = "in"
+	backend = "snowflake"
+
+	metadata = {
+		"KBC.description" = "Raw data"
+		"owner" = "data-team"
+	}
+}`
+
+const testStorageBucketWithMetadataUpdate = `
+resource "keboola_storage_bucket" "test_bucket" {
+	name = "test_bucket_name"
+	stage = "in"
+	backend = "snowflake"
+
+	metadata = {
+		"KBC.description" = "Cleaned data"
+	}
+}`
+
+func TestUnitStorageBucket_Metadata(t *testing.T) {
+	mock := newMockKeboolaAPI()
+	defer mock.Close()
+
+	bucketMetadata := func() []*mockMetadata {
+		if bucket, ok := mock.buckets["in.c-test_bucket_name"]; ok {
+			return bucket.Metadata
+		}
+
+		return nil
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    mock.providers(),
+		CheckDestroy: mock.testCheckDestroy("keboola_storage_bucket", "/v2/storage/buckets/%s", "id"),
+		Steps: []resource.TestStep{
+			{
+				Config: mock.config(testStorageBucketWithMetadata),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("keboola_storage_bucket.test_bucket", "metadata.%", "2"),
+					resource.TestCheckResourceAttr("keboola_storage_bucket.test_bucket", "metadata.KBC.description", "Raw data"),
+					resource.TestCheckResourceAttr("keboola_storage_bucket.test_bucket", "metadata.owner", "data-team"),
+					mock.testCheckMetadata(bucketMetadata, map[string]string{
+						"terraform/KBC.description": "Raw data",
+						"terraform/owner":           "data-team",
+					}),
+				),
+			},
+			{
+				PreConfig: func() {
+					mock.mutex.Lock()
+					defer mock.mutex.Unlock()
+
+					bucket := mock.buckets["in.c-test_bucket_name"]
+					bucket.Metadata = append(bucket.Metadata, &mockMetadata{"1", "KBC.description", "Set in the UI", "user"})
+				},
+				Config: mock.config(testStorageBucketWithMetadataUpdate),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("keboola_storage_bucket.test_bucket", "metadata.%", "1"),
+					resource.TestCheckResourceAttr("keboola_storage_bucket.test_bucket", "metadata.KBC.description", "Cleaned data"),
+					mock.testCheckMetadata(bucketMetadata, map[string]string{
+						"terraform/KBC.description": "Cleaned data",
+						"user/KBC.description":      "Set in the UI",
+					}),
+				),
+			},
+			{
+				Config:            mock.config(testStorageBucketWithMetadataUpdate),
+				ResourceName:      "keboola_storage_bucket.test_bucket",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestUnitStorageBucket_MetadataProvider(t *testing.T) {
+	mock := newMockKeboolaAPI()
+	defer mock.Close()
+
+	config := fmt.Sprintf(`
+provider "keboola" {
+	api_key           = "%s"
+	host              = "%s"
+	metadata_provider = "data-catalogue"
+}
+%s`, mockAPIKey, mock.URL, testStorageBucketWithMetadataUpdate)
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    mock.providers(),
+		CheckDestroy: mock.testCheckDestroy("keboola_storage_bucket", "/v2/storage/buckets/%s", "id"),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("keboola_storage_bucket.test_bucket", "metadata.KBC.description", "Cleaned data"),
+					mock.testCheckMetadata(func() []*mockMetadata {
+						return mock.buckets["in.c-test_bucket_name"].Metadata
+					}, map[string]string{
+						"data-catalogue/KBC.description": "Cleaned data",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func testStorageBucketWithDeletionSettings(stage string, settings string) string {
+	return fmt.Sprintf(`
+	resource "keboola_storage_bucket" "test_bucket" {
+		name = "test_bucket_name"
+		stage = "%s"
+		backend = "snowflake"
+		%s
+	}`, stage, settings)
+}
+
+func TestUnitStorageBucket_DeletionProtection(t *testing.T) {
+	mock := newMockKeboolaAPI()
+	defer mock.Close()
+
+	const tableID = "in.c-test_bucket_name.orders"
+
+	//The table is not managed by the configuration, as is the case for tables loaded by extractors.
+	addTable := func(rowsCount int) func() {
+		return func() {
+			mock.mutex.Lock()
+			defer mock.mutex.Unlock()
+
+			table := &mockTable{
+				ID:             tableID,
+				Name:           "orders",
+				Columns:        []string{"id"},
+				PrimaryKey:     []string{},
+				IndexedColumns: []string{},
+				RowsCount:      rowsCount,
+			}
+
+			table.Bucket.ID = "in.c-test_bucket_name"
+			mock.tables[tableID] = table
+		}
+	}
+
+	testCheckTableDeleted := func(s *terraform.State) error {
+		mock.mutex.Lock()
+		defer mock.mutex.Unlock()
+
+		if _, ok := mock.tables[tableID]; ok {
+			return fmt.Errorf("Storage table %s has not been deleted along with its bucket", tableID)
+		}
+
+		return nil
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    mock.providers(),
+		CheckDestroy: mock.testCheckDestroy("keboola_storage_bucket", "/v2/storage/buckets/%s", "id"),
+		Steps: []resource.TestStep{
+			{
+				Config: mock.config(testStorageBucketWithDeletionSettings("in", "")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("keboola_storage_bucket.test_bucket", "deletion_protection", "true"),
+					resource.TestCheckResourceAttr("keboola_storage_bucket.test_bucket", "force_delete", "false"),
+				),
+			},
+			{
+				PreConfig:   addTable(5),
+				Config:      mock.config(testStorageBucketWithDeletionSettings("out", "")),
+				ExpectError: regexp.MustCompile("bucket in.c-test_bucket_name has 5 rows in 1 tables, set deletion_protection = false"),
+			},
+			{
+				PreConfig:   addTable(0),
+				Config:      mock.config(testStorageBucketWithDeletionSettings("out", "")),
+				ExpectError: regexp.MustCompile("bucket in.c-test_bucket_name still contains 1 tables with 0 rows, set force_delete = true"),
+			},
+			{
+				Config: mock.config(testStorageBucketWithDeletionSettings("in", "force_delete = true")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("keboola_storage_bucket.test_bucket", "force_delete", "true"),
+				),
+			},
+			{
+				Config: mock.config(testStorageBucketWithDeletionSettings("out", "force_delete = true")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("keboola_storage_bucket.test_bucket", "id", "out.c-test_bucket_name"),
+					testCheckTableDeleted,
+				),
+			},
+			{
+				Config:                  mock.config(testStorageBucketWithDeletionSettings("out", "force_delete = true")),
+				ResourceName:            "keboola_storage_bucket.test_bucket",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"force_delete"},
+			},
+		},
+	})
+}
@@ -0,0 +1,438 @@
+package keboola
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"mime/multipart"
+	"net/url"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/paybyphone/terraform-provider-keboola/plugin/providers/keboola/buffer"
+)
+
+//region Keboola API Contracts
+
+//StorageFile is the data model for a file within the Keboola Storage API. A prepared file holds the
+//parameters for uploading it to the file storage of its provider (aws, azure or gcp).
+type StorageFile struct {
+	ID              KBCNumberString `json:"id,omitempty"`
+	Name            string          `json:"name"`
+	SizeBytes       int             `json:"sizeBytes"`
+	IsPublic        bool            `json:"isPublic"`
+	IsPermanent     bool            `json:"isPermanent"`
+	Tags            []string        `json:"tags"`
+	Created         string          `json:"created,omitempty"`
+	Provider        string          `json:"provider,omitempty"`
+	UploadParams    json.RawMessage `json:"uploadParams,omitempty"`
+	ABSUploadParams json.RawMessage `json:"absUploadParams,omitempty"`
+	GCSUploadParams json.RawMessage `json:"gcsUploadParams,omitempty"`
+}
+
+//ABSUploadParams are the parameters for uploading a prepared file to Azure Blob Storage.
+type ABSUploadParams struct {
+	BlobName      string `json:"blobName"`
+	AccountName   string `json:"accountName"`
+	ContainerName string `json:"containerName"`
+	Credentials   struct {
+		SASConnectionString string `json:"SASConnectionString"`
+	} `json:"absCredentials"`
+}
+
+//GCSUploadParams are the parameters for uploading a prepared file to Google Cloud Storage.
+type GCSUploadParams struct {
+	Bucket      string `json:"bucket"`
+	Key         string `json:"key"`
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+}
+
+//endregion
+
+func resourceKeboolaStorageFile() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceKeboolaStorageFileCreate,
+		Read:   resourceKeboolaStorageFileRead,
+		Update: resourceKeboolaStorageFileUpdate,
+		Delete: resourceKeboolaStorageFileDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceKeboolaStorageFileImport,
+		},
+		CustomizeDiff: resourceKeboolaStorageFileCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"source_file": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The path of the local file to upload. The file is uploaded again (as a new Storage file) whenever its content changes.",
+			},
+			"source_file_hash": {
+				Type:     schema.TypeString,
+				Computed: true,
+				ForceNew: true,
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The name of the Storage file, which defaults to the name of the local file.",
+			},
+			"tags": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+			"is_permanent": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+				Default:  false,
+			},
+			"is_public": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+				Default:  false,
+			},
+			"size_bytes": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"created": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+//resourceKeboolaStorageFileImport imports a Storage file using its ID and the path of the local file it was
+//uploaded from (file_id/source_file), so that the file is not uploaded again by the next apply.
+func resourceKeboolaStorageFileImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	idParts := strings.SplitN(d.Id(), "/", 2)
+
+	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
+		return nil, fmt.Errorf("unexpected format of ID (%s), expected file_id/source_file", d.Id())
+	}
+
+	d.SetId(idParts[0])
+	d.Set("source_file", idParts[1])
+
+	return []*schema.ResourceData{d}, nil
+}
+
+//resourceKeboolaStorageFileCustomizeDiff records the hash of the content of source_file, so that a changed file is uploaded again.
+func resourceKeboolaStorageFileCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("source_file") {
+		d.SetNewComputed("source_file_hash")
+		return nil
+	}
+
+	sourceFileContent, err := ioutil.ReadFile(d.Get("source_file").(string))
+
+	if err != nil {
+		return err
+	}
+
+	if sourceFileHash := fmt.Sprintf("%x", sha256.Sum256(sourceFileContent)); sourceFileHash != d.Get("source_file_hash").(string) {
+		return d.SetNew("source_file_hash", sourceFileHash)
+	}
+
+	return nil
+}
+
+func resourceKeboolaStorageFileCreate(d *schema.ResourceData, meta interface{}) error {
+	sourceFile := d.Get("source_file").(string)
+
+	log.Printf("[INFO] Uploading %s to Storage Files in Keboola.", sourceFile)
+
+	sourceFileContent, err := ioutil.ReadFile(sourceFile)
+
+	if err != nil {
+		return err
+	}
+
+	name := d.Get("name").(string)
+	if name == "" {
+		name = filepath.Base(sourceFile)
+	}
+
+	prepareForm := url.Values{}
+	prepareForm.Add("name", name)
+	prepareForm.Add("sizeBytes", strconv.Itoa(len(sourceFileContent)))
+	prepareForm.Add("isPermanent", strconv.FormatBool(d.Get("is_permanent").(bool)))
+	prepareForm.Add("isPublic", strconv.FormatBool(d.Get("is_public").(bool)))
+
+	for _, tag := range d.Get("tags").(*schema.Set).List() {
+		prepareForm.Add("tags[]", tag.(string))
+	}
+
+	client := meta.(*KBCClient)
+	prepareResponse, err := client.PostToStorage("storage/files/prepare", buffer.FromForm(prepareForm))
+
+	if hasErrors(err, prepareResponse) {
+		return extractError(err, prepareResponse)
+	}
+
+	var preparedFile StorageFile
+
+	decoder := json.NewDecoder(prepareResponse.Body)
+	err = decoder.Decode(&preparedFile)
+
+	if err != nil {
+		return err
+	}
+
+	err = uploadPreparedStorageFile(client, preparedFile, sourceFileContent)
+
+	if err != nil {
+		return err
+	}
+
+	d.SetId(string(preparedFile.ID))
+	d.Set("source_file_hash", fmt.Sprintf("%x", sha256.Sum256(sourceFileContent)))
+
+	return resourceKeboolaStorageFileRead(d, meta)
+}
+
+//gcsUploadURL is the URL of the Google Cloud Storage upload API.
+var gcsUploadURL = "https://storage.googleapis.com/upload/storage/v1/"
+
+//uploadPreparedStorageFile uploads the content of a prepared Storage file to the file storage of the stack,
+//with the upload parameters of its provider.
+func uploadPreparedStorageFile(client *KBCClient, preparedFile StorageFile, content []byte) error {
+	switch preparedFile.Provider {
+	case "", "aws":
+		return uploadStorageFileToS3(client, preparedFile, content)
+	case "azure":
+		return uploadStorageFileToABS(client, preparedFile, content)
+	case "gcp":
+		return uploadStorageFileToGCS(client, preparedFile, content)
+	}
+
+	return fmt.Errorf("unsupported file storage provider %s for Storage file %s", preparedFile.Provider, preparedFile.ID)
+}
+
+//uploadStorageFileToS3 posts a prepared Storage file to S3, along with the fields of its upload parameters
+//(such as the key and signed policy). The parameters also hold the URL to post the file to.
+func uploadStorageFileToS3(client *KBCClient, preparedFile StorageFile, content []byte) error {
+	var uploadParams map[string]string
+
+	if err := json.Unmarshal(preparedFile.UploadParams, &uploadParams); err != nil {
+		return fmt.Errorf("unexpected upload parameters for Storage file %s: %s", preparedFile.ID, err)
+	}
+
+	uploadURL, ok := uploadParams["url"]
+
+	if !ok {
+		return fmt.Errorf("no upload URL was returned for Storage file %s", preparedFile.ID)
+	}
+
+	fieldNames := make([]string, 0, len(uploadParams))
+	for name := range uploadParams {
+		if name != "url" {
+			fieldNames = append(fieldNames, name)
+		}
+	}
+
+	sort.Strings(fieldNames)
+
+	uploadBuffer := &bytes.Buffer{}
+	uploadRequestWriter := multipart.NewWriter(uploadBuffer)
+
+	for _, name := range fieldNames {
+		uploadRequestWriter.WriteField(name, uploadParams[name])
+	}
+
+	//The file must be the last field of the form.
+	fileWriter, err := uploadRequestWriter.CreateFormFile("file", preparedFile.Name)
+
+	if err != nil {
+		return err
+	}
+
+	fileWriter.Write(content)
+	uploadRequestWriter.Close()
+
+	uploadResponse, err := client.PostToFileUpload(uploadURL, uploadBuffer, uploadRequestWriter.FormDataContentType(), nil)
+
+	if hasErrors(err, uploadResponse) {
+		return extractError(err, uploadResponse)
+	}
+
+	return nil
+}
+
+//uploadStorageFileToABS puts a prepared Storage file as a block blob to Azure Blob Storage, using the
+//blob endpoint and shared access signature of the SAS connection string in its upload parameters.
+func uploadStorageFileToABS(client *KBCClient, preparedFile StorageFile, content []byte) error {
+	var uploadParams ABSUploadParams
+
+	if err := json.Unmarshal(preparedFile.ABSUploadParams, &uploadParams); err != nil {
+		return fmt.Errorf("unexpected upload parameters for Storage file %s: %s", preparedFile.ID, err)
+	}
+
+	var blobEndpoint, sharedAccessSignature string
+
+	for _, setting := range strings.Split(uploadParams.Credentials.SASConnectionString, ";") {
+		if settingParts := strings.SplitN(setting, "=", 2); len(settingParts) == 2 {
+			switch settingParts[0] {
+			case "BlobEndpoint":
+				blobEndpoint = strings.TrimRight(settingParts[1], "/")
+			case "SharedAccessSignature":
+				sharedAccessSignature = settingParts[1]
+			}
+		}
+	}
+
+	if blobEndpoint == "" || sharedAccessSignature == "" {
+		return fmt.Errorf("no SAS connection string was returned for Storage file %s", preparedFile.ID)
+	}
+
+	var blobPath []string
+	for _, segment := range strings.Split(uploadParams.ContainerName+"/"+uploadParams.BlobName, "/") {
+		blobPath = append(blobPath, url.PathEscape(segment))
+	}
+
+	uploadURL := fmt.Sprintf("%s/%s?%s", blobEndpoint, strings.Join(blobPath, "/"), sharedAccessSignature)
+	uploadResponse, err := client.PutToFileUpload(uploadURL, bytes.NewBuffer(content), "application/octet-stream", map[string]string{"x-ms-blob-type": "BlockBlob"})
+
+	if hasErrors(err, uploadResponse) {
+		return extractError(err, uploadResponse)
+	}
+
+	return nil
+}
+
+//uploadStorageFileToGCS uploads a prepared Storage file as an object to Google Cloud Storage, using the
+//bucket, key and access token in its upload parameters.
+func uploadStorageFileToGCS(client *KBCClient, preparedFile StorageFile, content []byte) error {
+	var uploadParams GCSUploadParams
+
+	if err := json.Unmarshal(preparedFile.GCSUploadParams, &uploadParams); err != nil {
+		return fmt.Errorf("unexpected upload parameters for Storage file %s: %s", preparedFile.ID, err)
+	}
+
+	if uploadParams.AccessToken == "" {
+		return fmt.Errorf("no access token was returned for Storage file %s", preparedFile.ID)
+	}
+
+	tokenType := uploadParams.TokenType
+	if tokenType == "" {
+		tokenType = "Bearer"
+	}
+
+	uploadURL := fmt.Sprintf("%sb/%s/o?uploadType=media&name=%s", gcsUploadURL, url.PathEscape(uploadParams.Bucket), url.QueryEscape(uploadParams.Key))
+	uploadResponse, err := client.PostToFileUpload(uploadURL, bytes.NewBuffer(content), "application/octet-stream", map[string]string{"Authorization": tokenType + " " + uploadParams.AccessToken})
+
+	if hasErrors(err, uploadResponse) {
+		return extractError(err, uploadResponse)
+	}
+
+	return nil
+}
+
+func resourceKeboolaStorageFileRead(d *schema.ResourceData, meta interface{}) error {
+	log.Println("[INFO] Reading Storage File from Keboola.")
+
+	if d.Id() == "" {
+		return nil
+	}
+
+	client := meta.(*KBCClient)
+	getResponse, err := client.GetFromStorage(fmt.Sprintf("storage/files/%s", d.Id()))
+
+	if hasErrors(err, getResponse) {
+		err = extractError(err, getResponse)
+
+		if isNotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+
+		return err
+	}
+
+	var storageFile StorageFile
+
+	decoder := json.NewDecoder(getResponse.Body)
+	err = decoder.Decode(&storageFile)
+
+	if err != nil {
+		return err
+	}
+
+	d.Set("name", storageFile.Name)
+	d.Set("tags", storageFile.Tags)
+	d.Set("is_permanent", storageFile.IsPermanent)
+	d.Set("is_public", storageFile.IsPublic)
+	d.Set("size_bytes", storageFile.SizeBytes)
+	d.Set("created", storageFile.Created)
+
+	//An imported file is given the hash of its source_file, as long as it is the size of the Storage file, so
+	//that it is not uploaded again. A source_file which has a different size is uploaded again by the next apply.
+	if sourceFile, ok := d.GetOk("source_file"); ok && d.Get("source_file_hash").(string) == "" {
+		if sourceFileContent, err := ioutil.ReadFile(sourceFile.(string)); err == nil && len(sourceFileContent) == storageFile.SizeBytes {
+			d.Set("source_file_hash", fmt.Sprintf("%x", sha256.Sum256(sourceFileContent)))
+		}
+	}
+
+	return nil
+}
+
+//resourceKeboolaStorageFileUpdate adds and removes the tags of a Storage file. Everything else about
+//a Storage file (including its content) is immutable, so any other change replaces the file.
+func resourceKeboolaStorageFileUpdate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[INFO] Updating tags of Storage File in Keboola: %s", d.Id())
+
+	client := meta.(*KBCClient)
+
+	if d.HasChange("tags") {
+		oldTags, newTags := d.GetChange("tags")
+
+		for _, tag := range oldTags.(*schema.Set).Difference(newTags.(*schema.Set)).List() {
+			deleteResponse, err := client.DeleteFromStorage(fmt.Sprintf("storage/files/%s/tags/%s", d.Id(), url.PathEscape(tag.(string))))
+
+			if hasErrors(err, deleteResponse) {
+				return extractError(err, deleteResponse)
+			}
+		}
+
+		for _, tag := range newTags.(*schema.Set).Difference(oldTags.(*schema.Set)).List() {
+			tagForm := url.Values{}
+			tagForm.Add("tag", tag.(string))
+
+			tagResponse, err := client.PostToStorage(fmt.Sprintf("storage/files/%s/tags", d.Id()), buffer.FromForm(tagForm))
+
+			if hasErrors(err, tagResponse) {
+				return extractError(err, tagResponse)
+			}
+		}
+	}
+
+	return resourceKeboolaStorageFileRead(d, meta)
+}
+
+func resourceKeboolaStorageFileDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[INFO] Deleting Storage File in Keboola: %s", d.Id())
+
+	client := meta.(*KBCClient)
+	destroyResponse, err := client.DeleteFromStorage(fmt.Sprintf("storage/files/%s", d.Id()))
+
+	if hasErrors(err, destroyResponse) {
+		return extractError(err, destroyResponse)
+	}
+
+	d.SetId("")
+
+	return nil
+}
@@ -0,0 +1,314 @@
+package keboola
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccStorageFile_Basic(t *testing.T) {
+	sourceFile, err := ioutil.TempFile("", "model-*.json")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sourceFile.Close()
+	defer os.Remove(sourceFile.Name())
+
+	if err := ioutil.WriteFile(sourceFile.Name(), []byte(`{"weights": [1, 2, 3]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckStorageFileDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testStorageFile(sourceFile.Name(), `"model"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("keboola_storage_file.test_file", "name", filepath.Base(sourceFile.Name())),
+					resource.TestCheckResourceAttr("keboola_storage_file.test_file", "tags.#", "1"),
+					resource.TestCheckResourceAttr("keboola_storage_file.test_file", "size_bytes", "22"),
+				),
+			},
+			{
+				Config: testStorageFile(sourceFile.Name(), `"model", "latest"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("keboola_storage_file.test_file", "tags.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckStorageFileDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*KBCClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "keboola_storage_file" {
+			continue
+		}
+
+		getResp, err := client.GetFromStorage(fmt.Sprintf("storage/files/%s", rs.Primary.ID))
+
+		if err == nil && getResp.StatusCode == 200 {
+			return fmt.Errorf("Storage file still exists")
+		}
+	}
+
+	return nil
+}
+
+func testStorageFile(sourceFile string, tags string) string {
+	return fmt.Sprintf(`
+	resource "keboola_storage_file" "test_file" {
+		source_file = "%s"
+		tags = [ %s ]
+	}`, sourceFile, tags)
+}
+
+func TestUnitStorageFile_Basic(t *testing.T) {
+	mock := newMockKeboolaAPI()
+	defer mock.Close()
+
+	sourceFile, err := ioutil.TempFile("", "model-*.json")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sourceFile.Close()
+	defer os.Remove(sourceFile.Name())
+
+	writeSourceFile := func(content string) func() {
+		return func() {
+			if err := ioutil.WriteFile(sourceFile.Name(), []byte(content), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	var fileID string
+
+	testCheckFile := func(expectedContent string, expectedTags []string, recreated bool) resource.TestCheckFunc {
+		return func(s *terraform.State) error {
+			mock.mutex.Lock()
+			defer mock.mutex.Unlock()
+
+			rs := s.RootModule().Resources["keboola_storage_file.test_file"]
+			id, _ := strconv.Atoi(rs.Primary.ID)
+			file, ok := mock.files[id]
+
+			if !ok {
+				return fmt.Errorf("Storage file %s not found", rs.Primary.ID)
+			}
+
+			if recreated != (fileID != rs.Primary.ID) {
+				return fmt.Errorf("Expected Storage file %s to be recreated: %t", fileID, recreated)
+			}
+
+			if previousID, _ := strconv.Atoi(fileID); recreated && mock.files[previousID] != nil {
+				return fmt.Errorf("Previous Storage file %s was not deleted", fileID)
+			}
+
+			if file.Content != expectedContent {
+				return fmt.Errorf("Expected content %q, got %q", expectedContent, file.Content)
+			}
+
+			tags := append([]string{}, file.Tags...)
+			sort.Strings(tags)
+
+			if !reflect.DeepEqual(tags, expectedTags) {
+				return fmt.Errorf("Expected tags %v, got %v", expectedTags, tags)
+			}
+
+			fileID = rs.Primary.ID
+
+			return nil
+		}
+	}
+
+	writeSourceFile(`{"weights": [1, 2, 3]}`)()
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    mock.providers(),
+		CheckDestroy: mock.testCheckDestroy("keboola_storage_file", "/v2/storage/files/%s", "id"),
+		Steps: []resource.TestStep{
+			{
+				Config: mock.config(testStorageFile(sourceFile.Name(), `"model"`)),
+				Check: resource.ComposeTestCheckFunc(
+					mock.testCheckExists("keboola_storage_file.test_file", "/v2/storage/files/%s", "id"),
+					resource.TestCheckResourceAttr("keboola_storage_file.test_file", "name", filepath.Base(sourceFile.Name())),
+					resource.TestCheckResourceAttr("keboola_storage_file.test_file", "size_bytes", "22"),
+					resource.TestCheckResourceAttr("keboola_storage_file.test_file", "is_permanent", "false"),
+					resource.TestCheckResourceAttrSet("keboola_storage_file.test_file", "source_file_hash"),
+					testCheckFile(`{"weights": [1, 2, 3]}`, []string{"model"}, true),
+				),
+			},
+			{
+				Config: mock.config(testStorageFile(sourceFile.Name(), `"model", "latest"`)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("keboola_storage_file.test_file", "tags.#", "2"),
+					testCheckFile(`{"weights": [1, 2, 3]}`, []string{"latest", "model"}, false),
+				),
+			},
+			{
+				PreConfig: writeSourceFile(`{"weights": [4, 5, 6, 7]}`),
+				Config:    mock.config(testStorageFile(sourceFile.Name(), `"latest"`)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("keboola_storage_file.test_file", "size_bytes", "25"),
+					testCheckFile(`{"weights": [4, 5, 6, 7]}`, []string{"latest"}, true),
+				),
+			},
+			{
+				Config:            mock.config(testStorageFile(sourceFile.Name(), `"latest"`)),
+				ResourceName:      "keboola_storage_file.test_file",
+				ImportState:       true,
+				ImportStateIdFunc: testStorageFileImportID(sourceFile.Name()),
+				ImportStateVerify: true,
+				ImportStateCheck: testCheckStorageFileImportPlanEmpty(map[string]interface{}{
+					"source_file": sourceFile.Name(),
+					"tags":        []interface{}{"latest"},
+				}),
+			},
+			{
+				//A source_file which is not the size of the imported file is uploaded again.
+				PreConfig:         writeSourceFile(`{"weights": [8, 9]}`),
+				Config:            mock.config(testStorageFile(sourceFile.Name(), `"latest"`)),
+				ResourceName:      "keboola_storage_file.test_file",
+				ImportState:       true,
+				ImportStateIdFunc: testStorageFileImportID(sourceFile.Name()),
+				ImportStateCheck:  testCheckImportedAttribute("source_file_hash", ""),
+			},
+		},
+	})
+}
+
+func TestUnitStorageFile_FileStorageProviders(t *testing.T) {
+	sourceFile, err := ioutil.TempFile("", "model-*.json")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sourceFile.Close()
+	defer os.Remove(sourceFile.Name())
+
+	if err := ioutil.WriteFile(sourceFile.Name(), []byte(`{"weights": [1, 2, 3]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, provider := range []string{"aws", "azure", "gcp"} {
+		t.Run(provider, func(t *testing.T) {
+			mock := newMockKeboolaAPI()
+			defer mock.Close()
+
+			mock.fileStorageProvider = provider
+
+			defaultGCSUploadURL := gcsUploadURL
+			gcsUploadURL = mock.URL + mockFileStoragePath + "/gcs/"
+			defer func() { gcsUploadURL = defaultGCSUploadURL }()
+
+			resource.UnitTest(t, resource.TestCase{
+				Providers:    mock.providers(),
+				CheckDestroy: mock.testCheckDestroy("keboola_storage_file", "/v2/storage/files/%s", "id"),
+				Steps: []resource.TestStep{
+					{
+						Config: mock.config(testStorageFile(sourceFile.Name(), `"model"`)),
+						Check: resource.ComposeTestCheckFunc(
+							mock.testCheckExists("keboola_storage_file.test_file", "/v2/storage/files/%s", "id"),
+							testCheckStorageFileContent(mock, `{"weights": [1, 2, 3]}`),
+						),
+					},
+				},
+			})
+		})
+	}
+
+	t.Run("unsupported", func(t *testing.T) {
+		mock := newMockKeboolaAPI()
+		defer mock.Close()
+
+		mock.fileStorageProvider = "oss"
+
+		resource.UnitTest(t, resource.TestCase{
+			Providers: mock.providers(),
+			Steps: []resource.TestStep{
+				{
+					Config:      mock.config(testStorageFile(sourceFile.Name(), `"model"`)),
+					ExpectError: regexp.MustCompile("unsupported file storage provider oss"),
+				},
+			},
+		})
+	})
+}
+
+func testCheckStorageFileContent(mock *mockKeboolaAPI, expectedContent string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		mock.mutex.Lock()
+		defer mock.mutex.Unlock()
+
+		rs := s.RootModule().Resources["keboola_storage_file.test_file"]
+		id, _ := strconv.Atoi(rs.Primary.ID)
+
+		if file, ok := mock.files[id]; !ok || file.Content != expectedContent {
+			return fmt.Errorf("Expected Storage file %s to have been uploaded with content %q", rs.Primary.ID, expectedContent)
+		}
+
+		return nil
+	}
+}
+
+func testStorageFileImportID(sourceFile string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources["keboola_storage_file.test_file"]
+
+		if !ok {
+			return "", fmt.Errorf("Not found: keboola_storage_file.test_file")
+		}
+
+		return fmt.Sprintf("%s/%s", rs.Primary.ID, sourceFile), nil
+	}
+}
+
+//testCheckStorageFileImportPlanEmpty plans an imported Storage file against its configuration, which must
+//neither change the file nor upload it again.
+func testCheckStorageFileImportPlanEmpty(config map[string]interface{}) resource.ImportStateCheckFunc {
+	return func(states []*terraform.InstanceState) error {
+		if len(states) != 1 {
+			return fmt.Errorf("Expected 1 imported resource, got %d", len(states))
+		}
+
+		diff, err := resourceKeboolaStorageFile().Diff(states[0], terraform.NewResourceConfigRaw(config), nil)
+
+		if err != nil {
+			return err
+		}
+
+		if !diff.Empty() {
+			var changes []string
+
+			for key, attributeDiff := range diff.CopyAttributes() {
+				changes = append(changes, fmt.Sprintf("%s: %q => %q", key, attributeDiff.Old, attributeDiff.New))
+			}
+
+			sort.Strings(changes)
+
+			return fmt.Errorf("Expected no changes after import, got %s", strings.Join(changes, ", "))
+		}
+
+		return nil
+	}
+}
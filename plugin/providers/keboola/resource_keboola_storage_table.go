@@ -2,15 +2,21 @@ package keboola
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"log"
 	"mime/multipart"
+	"net/http"
 	"net/url"
+	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
-	"time"
 
+	"github.com/hashicorp/terraform/configs/hcl2shim"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/paybyphone/terraform-provider-keboola/plugin/providers/keboola/buffer"
 )
@@ -28,6 +34,44 @@ type StorageTable struct {
 	Columns        []string `json:"columns"`
 	PrimaryKey     []string `json:"primaryKey"`
 	IndexedColumns []string `json:"indexedColumns"`
+	Bucket         struct {
+		ID string `json:"id"`
+	} `json:"bucket"`
+
+	IsAlias        bool   `json:"isAlias,omitempty"`
+	RowsCount      int    `json:"rowsCount,omitempty"`
+	DataSizeBytes  int    `json:"dataSizeBytes,omitempty"`
+	Created        string `json:"created,omitempty"`
+	LastImportDate string `json:"lastImportDate,omitempty"`
+	LastChangeDate string `json:"lastChangeDate,omitempty"`
+
+	Metadata       []StorageMetadata     `json:"metadata,omitempty"`
+	ColumnMetadata StorageColumnMetadata `json:"columnMetadata,omitempty"`
+
+	IsTyped    bool                    `json:"isTyped"`
+	Definition *StorageTableDefinition `json:"definition,omitempty"`
+}
+
+//StorageTableDefinition is the definition of a typed table, whose columns have
+//native data types, within the Keboola Storage API.
+type StorageTableDefinition struct {
+	Name             string                    `json:"name,omitempty"`
+	PrimaryKeysNames []string                  `json:"primaryKeysNames"`
+	Columns          []StorageColumnDefinition `json:"columns"`
+}
+
+//StorageColumnDefinition is the definition of a single column of a typed table.
+type StorageColumnDefinition struct {
+	Name       string            `json:"name"`
+	Definition StorageColumnType `json:"definition"`
+}
+
+//StorageColumnType is the native data type of a column of a typed table.
+type StorageColumnType struct {
+	Type     string `json:"type,omitempty"`
+	Length   string `json:"length,omitempty"`
+	Nullable bool   `json:"nullable"`
+	Default  string `json:"default,omitempty"`
 }
 
 //UploadFileResult contains the id of the CSV file uploaded to AWS S3.
@@ -41,7 +85,18 @@ func resourceKeboolaStorageTable() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceKeboolaStorageTableCreate,
 		Read:   resourceKeboolaStorageTableRead,
+		Update: resourceKeboolaStorageTableUpdate,
 		Delete: resourceKeboolaStorageTableDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		CustomizeDiff: resourceKeboolaStorageTableCustomizeDiff,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultJobTimeout),
+			Update: schema.DefaultTimeout(defaultJobTimeout),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"bucket_id": {
@@ -72,7 +127,6 @@ func resourceKeboolaStorageTable() *schema.Resource {
 			"primary_key": {
 				Type:     schema.TypeList,
 				Optional: true,
-				ForceNew: true,
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
@@ -80,11 +134,96 @@ func resourceKeboolaStorageTable() *schema.Resource {
 			"columns": {
 				Type:     schema.TypeSet,
 				Optional: true,
-				ForceNew: true,
+				Computed: true,
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
 			},
+			"column": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"columns"},
+				Description:   "The columns of a typed table, with their native data types. Typed tables are created with these columns instead of columns.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"type": {
+							Type:             schema.TypeString,
+							Required:         true,
+							DiffSuppressFunc: suppressStorageColumnTypeDiff,
+							Description:      "The native data type of the column (e.g. VARCHAR, NUMBER or TIMESTAMP). Aliases such as INTEGER are compared as the type the backend reports them as. Changing it recreates the table.",
+						},
+						"length": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							Computed:         true,
+							DiffSuppressFunc: suppressStorageColumnTypeDiff,
+							Description:      "The length of the column (e.g. 255 or 38,2). Defaults to the length the backend gives to the data type.",
+						},
+						"nullable": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
+						"default": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+			"from_snapshot_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"column"},
+				Description:   "The ID of a snapshot to create the table from, restoring the columns and data of the table the snapshot was taken of.",
+			},
+			"source_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The path of a local CSV file (with a header row) whose rows are loaded into the table. The table is reloaded whenever the content of the file changes.",
+			},
+			"source_file_hash": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"incremental": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the rows of source_file are added to the rows already in the table (updating those with the same primary key), rather than replacing them.",
+			},
+			"deletion_protection": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether deleting (or replacing) the table fails while it has rows.",
+			},
+			"allow_column_drop": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether columns removed from columns may be dropped from the table, along with all of their data.",
+			},
+			"metadata": &metadataSchema,
+			"column_metadata": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"column": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"metadata": &metadataSchema,
+					},
+				},
+			},
 			"indexed_columns": {
 				Type:     schema.TypeList,
 				Optional: true,
@@ -102,32 +241,35 @@ func resourceKeboolaStorageTableCreate(d *schema.ResourceData, meta interface{})
 	log.Println("[INFO] Creating Storage Table in Keboola.")
 
 	client := meta.(*KBCClient)
-	columns := AsStringArray(d.Get("columns").(*schema.Set).List())
 
-	uploadFileBuffer := &bytes.Buffer{}
-	uploadFileRequestWriter := multipart.NewWriter(uploadFileBuffer)
-	uploadFileRequestWriter.SetBoundary("----terraform-provider-keboola----")
-	uploadFileRequestWriter.WriteField("name", "from-text-input.csv")
-	uploadFileRequestWriter.WriteField("data", strings.Join(columns, ","))
-	uploadFileRequestWriter.Close()
+	if len(d.Get("column").([]interface{})) > 0 {
+		err := createTypedStorageTable(d, client)
 
-	uploadResponse, err := client.PostToFileImport("upload-file", uploadFileBuffer)
+		if err != nil {
+			return err
+		}
 
-	if hasErrors(err, uploadResponse) {
-		return extractError(err, uploadResponse)
+		return resourceKeboolaStorageTableCreated(d, meta)
 	}
 
-	var uploadResult UploadFileResult
+	if _, ok := d.GetOk("from_snapshot_id"); ok {
+		err := restoreStorageTableFromSnapshot(d, client)
 
-	uploadResponseDecoder := json.NewDecoder(uploadResponse.Body)
-	err = uploadResponseDecoder.Decode(&uploadResult)
+		if err != nil {
+			return err
+		}
+
+		return resourceKeboolaStorageTableCreated(d, meta)
+	}
+
+	columns := AsStringArray(d.Get("columns").(*schema.Set).List())
+
+	fileID, err := uploadStorageFile(client, "from-text-input.csv", []byte(strings.Join(columns, ",")))
 
 	if err != nil {
 		return err
 	}
 
-	fileID := uploadResult.ID
-
 	loadTableForm := url.Values{}
 	loadTableForm.Add("name", d.Get("name").(string))
 	loadTableForm.Add("primaryKey", strings.Join(AsStringArray(d.Get("primary_key").([]interface{})), ","))
@@ -164,33 +306,356 @@ func resourceKeboolaStorageTableCreate(d *schema.ResourceData, meta interface{})
 		return err
 	}
 
-	tableLoadStatus := "waiting"
+	tableLoadStatusResult, err := client.waitForStorageJob(loadTableResult.ID, d.Timeout(schema.TimeoutCreate))
+
+	if err != nil {
+		return err
+	}
+
+	d.SetId(string(tableLoadStatusResult.Results.ID))
+
+	return resourceKeboolaStorageTableCreated(d, meta)
+}
+
+//restoreStorageTableFromSnapshot creates a table from a snapshot, with the columns, primary key and data of the
+//table the snapshot was taken of. The restored primary key is then replaced by primary_key, if they differ.
+func restoreStorageTableFromSnapshot(d *schema.ResourceData, client *KBCClient) error {
+	snapshotID := d.Get("from_snapshot_id").(string)
+
+	log.Printf("[INFO] Restoring Storage Table from Snapshot %s", snapshotID)
+
+	restoreForm := url.Values{}
+	restoreForm.Add("name", d.Get("name").(string))
+	restoreForm.Add("snapshotId", snapshotID)
+
+	restoreResponse, err := client.PostToStorage(fmt.Sprintf("storage/buckets/%s/tables-async", d.Get("bucket_id").(string)), buffer.FromForm(restoreForm))
+
+	if hasErrors(err, restoreResponse) {
+		return extractError(err, restoreResponse)
+	}
+
+	var restoreJob StorageJobStatus
+
+	restoreDecoder := json.NewDecoder(restoreResponse.Body)
+	err = restoreDecoder.Decode(&restoreJob)
+
+	if err != nil {
+		return err
+	}
+
+	restoreStatus, err := client.waitForStorageJob(restoreJob.ID, d.Timeout(schema.TimeoutCreate))
+
+	if err != nil {
+		return err
+	}
+
+	d.SetId(string(restoreStatus.Results.ID))
+
+	getResponse, err := client.GetFromStorage(fmt.Sprintf("storage/tables/%s", d.Id()))
+
+	if hasErrors(err, getResponse) {
+		return extractError(err, getResponse)
+	}
+
+	var restoredTable StorageTable
+
+	decoder := json.NewDecoder(getResponse.Body)
+	err = decoder.Decode(&restoredTable)
+
+	if err != nil {
+		return err
+	}
 
-	var tableLoadStatusResult StorageJobStatus
+	primaryKey := AsStringArray(d.Get("primary_key").([]interface{}))
 
-	for tableLoadStatus != "success" && tableLoadStatus != "error" {
-		jobStatusResponse, err := client.GetFromStorage(fmt.Sprintf("storage/jobs/%v", loadTableResult.ID))
+	if strings.Join(restoredTable.PrimaryKey, ",") == strings.Join(primaryKey, ",") {
+		return nil
+	}
 
-		if hasErrors(err, jobStatusResponse) {
-			return extractError(err, jobStatusResponse)
+	if len(restoredTable.PrimaryKey) > 0 {
+		if err := deleteStorageTablePrimaryKey(d, client); err != nil {
+			return err
 		}
+	}
+
+	return createStorageTablePrimaryKey(d, client, primaryKey)
+}
 
-		jobStatusDecoder := json.NewDecoder(jobStatusResponse.Body)
-		err = jobStatusDecoder.Decode(&tableLoadStatusResult)
+//resourceKeboolaStorageTableCreated sets the metadata of a table that has just been created, before reading it back.
+func resourceKeboolaStorageTableCreated(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*KBCClient)
+
+	if _, ok := d.GetOk("source_file"); ok {
+		err := importStorageTableSourceFile(d, client, schema.TimeoutCreate)
 
 		if err != nil {
 			return err
 		}
-
-		time.Sleep(250 * time.Millisecond)
-		tableLoadStatus = tableLoadStatusResult.Status
 	}
 
-	d.SetId(tableLoadStatusResult.Results.ID)
+	_, hasMetadata := d.GetOk("metadata")
+	_, hasColumnMetadata := d.GetOk("column_metadata")
+
+	if hasMetadata || hasColumnMetadata {
+		err := updateStorageTableMetadata(d, client)
+
+		if err != nil {
+			return err
+		}
+	}
 
 	return resourceKeboolaStorageTableRead(d, meta)
 }
 
+//uploadStorageFile uploads the content of a CSV file through the File Import API, returning the ID of the uploaded file.
+func uploadStorageFile(client *KBCClient, name string, content []byte) (int, error) {
+	uploadFileBuffer := &bytes.Buffer{}
+	uploadFileRequestWriter := multipart.NewWriter(uploadFileBuffer)
+	uploadFileRequestWriter.SetBoundary("----terraform-provider-keboola----")
+	uploadFileRequestWriter.WriteField("name", name)
+
+	dataWriter, err := uploadFileRequestWriter.CreateFormFile("data", name)
+
+	if err != nil {
+		return 0, err
+	}
+
+	dataWriter.Write(content)
+	uploadFileRequestWriter.Close()
+
+	uploadResponse, err := client.PostToFileImport("upload-file", uploadFileBuffer)
+
+	if hasErrors(err, uploadResponse) {
+		return 0, extractError(err, uploadResponse)
+	}
+
+	var uploadResult UploadFileResult
+
+	uploadResponseDecoder := json.NewDecoder(uploadResponse.Body)
+	err = uploadResponseDecoder.Decode(&uploadResult)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return uploadResult.ID, nil
+}
+
+//storageTableSourceFileColumns returns the columns of the header row of the content of a source_file.
+func storageTableSourceFileColumns(sourceFileContent []byte, delimiter string) ([]interface{}, error) {
+	reader := csv.NewReader(bytes.NewReader(sourceFileContent))
+
+	if delimiter != "" {
+		reader.Comma = []rune(delimiter)[0]
+	}
+
+	header, err := reader.Read()
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the header row of source_file: %s", err)
+	}
+
+	var columns []interface{}
+	for _, column := range header {
+		columns = append(columns, column)
+	}
+
+	return columns, nil
+}
+
+//importStorageTableSourceFile loads the rows of the table's source_file into it, either replacing the rows
+//already in the table, or (when incremental) adding to them, and records the hash of the loaded content.
+func importStorageTableSourceFile(d *schema.ResourceData, client *KBCClient, timeout string) error {
+	sourceFile := d.Get("source_file").(string)
+
+	log.Printf("[INFO] Loading %s into Storage Table %s", sourceFile, d.Id())
+
+	sourceFileContent, err := ioutil.ReadFile(sourceFile)
+
+	if err != nil {
+		return err
+	}
+
+	fileID, err := uploadStorageFile(client, filepath.Base(sourceFile), sourceFileContent)
+
+	if err != nil {
+		return err
+	}
+
+	importForm := url.Values{}
+	importForm.Add("dataFileId", strconv.Itoa(fileID))
+
+	if d.Get("incremental").(bool) {
+		importForm.Add("incremental", "1")
+	} else {
+		importForm.Add("incremental", "0")
+	}
+
+	if d.Get("delimiter") != "" {
+		importForm.Add("delimiter", d.Get("delimiter").(string))
+	} else {
+		importForm.Add("delimiter", ",")
+	}
+
+	if d.Get("enclosure") != "" {
+		importForm.Add("enclosure", d.Get("enclosure").(string))
+	} else {
+		importForm.Add("enclosure", "\"")
+	}
+
+	importResponse, err := client.PostToStorage(fmt.Sprintf("storage/tables/%s/import-async", d.Id()), buffer.FromForm(importForm))
+
+	if hasErrors(err, importResponse) {
+		return extractError(err, importResponse)
+	}
+
+	if _, err := client.waitForAcceptedStorageJob(importResponse, d.Timeout(timeout)); err != nil {
+		return err
+	}
+
+	d.Set("source_file_hash", fmt.Sprintf("%x", sha256.Sum256(sourceFileContent)))
+
+	return nil
+}
+
+//storageColumnTypes returns the data types of the columns of a typed table, as set in its column blocks, by column name.
+func storageColumnTypes(columns []interface{}) map[string]StorageColumnType {
+	columnTypes := make(map[string]StorageColumnType)
+
+	for _, column := range columns {
+		column := column.(map[string]interface{})
+
+		columnTypes[column["name"].(string)] = StorageColumnType{
+			Type:     column["type"].(string),
+			Length:   column["length"].(string),
+			Nullable: column["nullable"].(bool),
+			Default:  column["default"].(string),
+		}
+	}
+
+	return columnTypes
+}
+
+//storageColumnTypeAliases are the data types which the backend reports as another type, along with
+//the length which the alias always has, if any (e.g. an INTEGER column is reported as NUMBER(38,0)).
+var storageColumnTypeAliases = map[string]StorageColumnType{
+	"INT":              {Type: "NUMBER", Length: "38,0"},
+	"INTEGER":          {Type: "NUMBER", Length: "38,0"},
+	"BIGINT":           {Type: "NUMBER", Length: "38,0"},
+	"SMALLINT":         {Type: "NUMBER", Length: "38,0"},
+	"TINYINT":          {Type: "NUMBER", Length: "38,0"},
+	"BYTEINT":          {Type: "NUMBER", Length: "38,0"},
+	"DECIMAL":          {Type: "NUMBER"},
+	"NUMERIC":          {Type: "NUMBER"},
+	"STRING":           {Type: "VARCHAR"},
+	"TEXT":             {Type: "VARCHAR"},
+	"CHAR VARYING":     {Type: "VARCHAR"},
+	"DOUBLE":           {Type: "FLOAT"},
+	"DOUBLE PRECISION": {Type: "FLOAT"},
+	"REAL":             {Type: "FLOAT"},
+	"FLOAT4":           {Type: "FLOAT"},
+	"FLOAT8":           {Type: "FLOAT"},
+}
+
+//storageColumnTypeDefaultLengths are the lengths which the backend gives to columns of these data types
+//when no length is set.
+var storageColumnTypeDefaultLengths = map[string]string{
+	"NUMBER":  "38,0",
+	"VARCHAR": "16777216",
+}
+
+//normalizedStorageColumnType returns the data type of a column as the backend reports it, resolving
+//aliases and adding the default length, so that a column block is not planned to change (or to be
+//recreated) only because the backend reports its type differently from the configuration.
+func normalizedStorageColumnType(columnType StorageColumnType) StorageColumnType {
+	columnType.Type = strings.Join(strings.Fields(strings.ToUpper(columnType.Type)), " ")
+	columnType.Length = stripWhitespace(columnType.Length)
+
+	if alias, ok := storageColumnTypeAliases[columnType.Type]; ok {
+		columnType.Type = alias.Type
+
+		if alias.Length != "" {
+			columnType.Length = alias.Length
+		}
+	}
+
+	if columnType.Length == "" {
+		columnType.Length = storageColumnTypeDefaultLengths[columnType.Type]
+	}
+
+	return columnType
+}
+
+//suppressStorageColumnTypeDiff suppresses diffs between the data type and length of a column block and those
+//the backend reports for it, such as INTEGER being reported as NUMBER with a length of 38,0.
+//noinspection GoUnusedParameter
+func suppressStorageColumnTypeDiff(k, old, new string, d *schema.ResourceData) bool {
+	columnKey := k[:strings.LastIndex(k, ".")+1]
+	oldType, newType := d.GetChange(columnKey + "type")
+	oldLength, newLength := d.GetChange(columnKey + "length")
+
+	oldColumnType := normalizedStorageColumnType(StorageColumnType{Type: oldType.(string), Length: oldLength.(string)})
+	newColumnType := normalizedStorageColumnType(StorageColumnType{Type: newType.(string), Length: newLength.(string)})
+
+	return oldColumnType.Type == newColumnType.Type && oldColumnType.Length == newColumnType.Length
+}
+
+//createTypedStorageTable creates a table whose columns have native data types, using the tables-definition
+//endpoint, rather than by loading a CSV header (which leaves all of the columns untyped).
+func createTypedStorageTable(d *schema.ResourceData, client *KBCClient) error {
+	tableDefinition := StorageTableDefinition{
+		Name:             d.Get("name").(string),
+		PrimaryKeysNames: AsStringArray(d.Get("primary_key").([]interface{})),
+	}
+
+	columnTypes := storageColumnTypes(d.Get("column").([]interface{}))
+
+	for _, column := range d.Get("column").([]interface{}) {
+		columnName := column.(map[string]interface{})["name"].(string)
+
+		tableDefinition.Columns = append(tableDefinition.Columns, StorageColumnDefinition{
+			Name:       columnName,
+			Definition: columnTypes[columnName],
+		})
+	}
+
+	tableDefinitionJSON, err := json.Marshal(tableDefinition)
+
+	if err != nil {
+		return err
+	}
+
+	createResponse, err := client.PostJSONToStorage(fmt.Sprintf("storage/buckets/%s/tables-definition", d.Get("bucket_id").(string)), bytes.NewBuffer(tableDefinitionJSON))
+
+	if hasErrors(err, createResponse) {
+		return extractError(err, createResponse)
+	}
+
+	//The table is usually created by an asynchronous job, but may also be created straight away.
+	if createResponse.StatusCode == http.StatusAccepted {
+		createStatusResult, err := client.waitForAcceptedStorageJob(createResponse, d.Timeout(schema.TimeoutCreate))
+
+		if err != nil {
+			return err
+		}
+
+		d.SetId(string(createStatusResult.Results.ID))
+	} else {
+		var createResult CreateResourceResult
+
+		decoder := json.NewDecoder(createResponse.Body)
+		err = decoder.Decode(&createResult)
+
+		if err != nil {
+			return err
+		}
+
+		d.SetId(string(createResult.ID))
+	}
+
+	return nil
+}
+
 func except(first []string, second []string) []string {
 	var result []string
 
@@ -222,12 +687,14 @@ func resourceKeboolaStorageTableRead(d *schema.ResourceData, meta interface{}) e
 	getResponse, err := client.GetFromStorage(fmt.Sprintf("storage/tables/%s", d.Id()))
 
 	if hasErrors(err, getResponse) {
-		if getResponse.StatusCode == 404 {
+		err = extractError(err, getResponse)
+
+		if isNotFoundError(err) {
 			d.SetId("")
 			return nil
 		}
 
-		return extractError(err, getResponse)
+		return err
 	}
 
 	var storageTable StorageTable
@@ -239,6 +706,7 @@ func resourceKeboolaStorageTableRead(d *schema.ResourceData, meta interface{}) e
 		return err
 	}
 
+	d.Set("bucket_id", storageTable.Bucket.ID)
 	d.Set("name", storageTable.Name)
 	d.Set("delimiter", storageTable.Delimiter)
 	d.Set("enclosure", storageTable.Enclosure)
@@ -246,14 +714,568 @@ func resourceKeboolaStorageTableRead(d *schema.ResourceData, meta interface{}) e
 	d.Set("primary_key", storageTable.PrimaryKey)
 	d.Set("indexed_columns", storageTable.IndexedColumns)
 	d.Set("columns", storageTable.Columns)
+	d.Set("metadata", storageMetadataValues(storageTable.Metadata, client.MetadataProvider))
+
+	var columnMetadata []map[string]interface{}
+
+	for _, column := range storageTable.Columns {
+		if values := storageMetadataValues(storageTable.ColumnMetadata[column], client.MetadataProvider); len(values) > 0 {
+			columnMetadata = append(columnMetadata, map[string]interface{}{
+				"column":   column,
+				"metadata": values,
+			})
+		}
+	}
+
+	d.Set("column_metadata", columnMetadata)
+
+	if storageTable.IsTyped && storageTable.Definition != nil {
+		d.Set("column", orderedStorageColumns(storageTable.Definition.Columns, d.Get("column").([]interface{})))
+	} else {
+		d.Set("column", nil)
+	}
+
+	//Tables which have been imported (or created before deletion_protection was added) are protected.
+	if _, ok := d.GetOkExists("deletion_protection"); !ok {
+		d.Set("deletion_protection", true)
+	}
+
+	return nil
+}
+
+//orderedStorageColumns returns the column blocks of a typed table, in the order they are already in, so that
+//columns which have been added to the middle of the blocks (but to the end of the table) do not show as a diff.
+func orderedStorageColumns(columnDefinitions []StorageColumnDefinition, existing []interface{}) []map[string]interface{} {
+	positions := make(map[string]int)
+
+	for index, column := range existing {
+		positions[column.(map[string]interface{})["name"].(string)] = index
+	}
+
+	ordered := make([]StorageColumnDefinition, len(columnDefinitions))
+	copy(ordered, columnDefinitions)
+
+	sort.SliceStable(ordered, func(i, j int) bool {
+		positionI, knownI := positions[ordered[i].Name]
+		positionJ, knownJ := positions[ordered[j].Name]
+
+		if knownI && knownJ {
+			return positionI < positionJ
+		}
+
+		return knownI && !knownJ
+	})
+
+	columns := make([]map[string]interface{}, 0, len(ordered))
+
+	for _, column := range ordered {
+		columns = append(columns, map[string]interface{}{
+			"name":     column.Name,
+			"type":     column.Definition.Type,
+			"length":   column.Definition.Length,
+			"nullable": column.Definition.Nullable,
+			"default":  column.Definition.Default,
+		})
+	}
+
+	return columns
+}
+
+//storageColumnsWithOwnLengths returns the column blocks of a typed table, with the blocks which have moved to the
+//position of another column given back their own length, along with the positions of those blocks. A length which
+//is not set is taken from the block which was at the same position before, so a block after an added column would
+//otherwise get the length of another column.
+func storageColumnsWithOwnLengths(oldColumns []interface{}, newColumns []interface{}) ([]interface{}, []int) {
+	oldLengths := make(map[string]string)
+
+	for _, column := range oldColumns {
+		column := column.(map[string]interface{})
+		oldLengths[column["name"].(string)] = column["length"].(string)
+	}
+
+	columns := make([]interface{}, 0, len(newColumns))
+	var moved []int
+
+	for index, column := range newColumns {
+		column := column.(map[string]interface{})
+
+		if index < len(oldColumns) {
+			oldColumn := oldColumns[index].(map[string]interface{})
+			columnName := column["name"].(string)
+
+			if oldColumn["name"] != columnName && oldColumn["length"] == column["length"] && oldLengths[columnName] != column["length"] {
+				ownLengthColumn := make(map[string]interface{})
+
+				for key, value := range column {
+					ownLengthColumn[key] = value
+				}
+
+				ownLengthColumn["length"] = oldLengths[columnName]
+				column = ownLengthColumn
+				moved = append(moved, index)
+			}
+		}
+
+		columns = append(columns, column)
+	}
+
+	return columns, moved
+}
+
+//resourceKeboolaStorageTableCustomizeDiff checks that the primary key of a table only uses columns
+//of the table, and stops a plan that would drop columns from an existing table (and so lose their
+//data), unless this has been explicitly allowed with allow_column_drop. The columns of a typed
+//table follow its column blocks, and changing the data type of any of them recreates the table.
+func resourceKeboolaStorageTableCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("source_file") {
+		d.SetNewComputed("source_file_hash")
+	} else if sourceFile, ok := d.GetOk("source_file"); ok {
+		sourceFileContent, err := ioutil.ReadFile(sourceFile.(string))
+
+		if err != nil {
+			return err
+		}
+
+		if sourceFileHash := fmt.Sprintf("%x", sha256.Sum256(sourceFileContent)); sourceFileHash != d.Get("source_file_hash").(string) {
+			d.SetNew("source_file_hash", sourceFileHash)
+		}
+
+		//A new table without columns is created with the columns of the header row of its source_file.
+		if d.Id() == "" && d.Get("columns").(*schema.Set).Len() == 0 {
+			columns, err := storageTableSourceFileColumns(sourceFileContent, d.Get("delimiter").(string))
+
+			if err != nil {
+				return err
+			}
+
+			if err := d.SetNew("columns", columns); err != nil {
+				return err
+			}
+		}
+	} else if d.Get("source_file_hash").(string) != "" {
+		d.SetNew("source_file_hash", "")
+	}
+
+	//A new table restored from a snapshot without columns has the columns of the snapshot.
+	restoredFromSnapshot := !d.NewValueKnown("from_snapshot_id") || d.Get("from_snapshot_id").(string) != ""
+
+	if d.Id() == "" && restoredFromSnapshot && d.Get("columns").(*schema.Set).Len() == 0 {
+		if err := d.SetNewComputed("columns"); err != nil {
+			return err
+		}
+	}
+
+	if d.Id() != "" && d.HasChange("column") && d.NewValueKnown("column") {
+		oldColumns, newColumns := d.GetChange("column")
+
+		if columns, moved := storageColumnsWithOwnLengths(oldColumns.([]interface{}), newColumns.([]interface{})); len(moved) > 0 {
+			for _, index := range moved {
+				//An empty length would be planned as the carried over length again, so it is known after apply instead.
+				if column := columns[index].(map[string]interface{}); column["length"] == "" {
+					column["length"] = hcl2shim.UnknownVariableValue
+				}
+			}
+
+			if err := d.SetNew("column", columns); err != nil {
+				return err
+			}
+		}
+	}
+
+	if d.HasChange("column") && d.NewValueKnown("column") {
+		oldColumns, newColumns := d.GetChange("column")
+		newColumnTypes := storageColumnTypes(newColumns.([]interface{}))
+
+		if len(newColumnTypes) > 0 {
+			var columnNames []interface{}
+
+			for _, column := range newColumns.([]interface{}) {
+				columnNames = append(columnNames, column.(map[string]interface{})["name"])
+			}
+
+			if err := d.SetNew("columns", columnNames); err != nil {
+				return err
+			}
+		}
+
+		if d.Id() != "" && len(newColumnTypes) > 0 {
+			oldColumnTypes := storageColumnTypes(oldColumns.([]interface{}))
+
+			//An untyped table cannot be given data types, so it has to be recreated as a typed table.
+			if len(oldColumnTypes) == 0 {
+				return d.ForceNew("column")
+			}
+
+			for index, column := range newColumns.([]interface{}) {
+				columnName := column.(map[string]interface{})["name"].(string)
+
+				oldColumnType, ok := oldColumnTypes[columnName]
+
+				if ok && normalizedStorageColumnType(oldColumnType).Type != normalizedStorageColumnType(newColumnTypes[columnName]).Type {
+					log.Printf("[INFO] Data type of column %s of Storage Table %s changes from %s to %s", columnName, d.Id(), oldColumnType.Type, newColumnTypes[columnName].Type)
+					return d.ForceNew(fmt.Sprintf("column.%d.type", index))
+				}
+			}
+		}
+	}
+
+	if d.NewValueKnown("columns") && d.NewValueKnown("primary_key") {
+		columns := d.Get("columns").(*schema.Set)
+
+		for _, keyColumn := range AsStringArray(d.Get("primary_key").([]interface{})) {
+			if !columns.Contains(keyColumn) {
+				return fmt.Errorf("primary_key column %s is not one of the table's columns", keyColumn)
+			}
+		}
+	}
+
+	if d.NewValueKnown("columns") && d.NewValueKnown("column_metadata") {
+		columns := d.Get("columns").(*schema.Set)
+
+		//Blocks being removed from the set are read back with an empty column while planning.
+		for _, columnMetadata := range d.Get("column_metadata").(*schema.Set).List() {
+			if column := columnMetadata.(map[string]interface{})["column"].(string); column != "" && !columns.Contains(column) {
+				return fmt.Errorf("column_metadata column %s is not one of the table's columns", column)
+			}
+		}
+	}
+
+	if d.Id() == "" || !d.HasChange("columns") {
+		return nil
+	}
+
+	//A table which is being replaced loses all of its data anyway.
+	for _, forceNewKey := range []string{"bucket_id", "name", "delimiter", "enclosure", "transactional"} {
+		if d.HasChange(forceNewKey) {
+			return nil
+		}
+	}
+
+	oldColumns, newColumns := d.GetChange("columns")
+	droppedColumns := AsStringArray(oldColumns.(*schema.Set).Difference(newColumns.(*schema.Set)).List())
+
+	if len(droppedColumns) > 0 && !d.Get("allow_column_drop").(bool) {
+		return fmt.Errorf("removing columns %s would drop them from table %s along with their data, set allow_column_drop = true to allow this", strings.Join(droppedColumns, ", "), d.Id())
+	}
+
+	return nil
+}
+
+//resourceKeboolaStorageTableUpdate adds any new columns first, so that they can be used in the new
+//primary key, and drops removed columns last, once they are no longer part of the primary key.
+func resourceKeboolaStorageTableUpdate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[INFO] Updating Storage Table in Keboola: %s", d.Id())
+
+	client := meta.(*KBCClient)
+
+	oldColumns, newColumns := d.GetChange("columns")
+	addedColumns := AsStringArray(newColumns.(*schema.Set).Difference(oldColumns.(*schema.Set)).List())
+	droppedColumns := AsStringArray(oldColumns.(*schema.Set).Difference(newColumns.(*schema.Set)).List())
+
+	//The lengths of column blocks which have moved are not part of the diff, so they are given back as when planning.
+	oldColumnBlocks, newColumnBlocks := d.GetChange("column")
+	columnBlocks, _ := storageColumnsWithOwnLengths(oldColumnBlocks.([]interface{}), newColumnBlocks.([]interface{}))
+	columnTypes := storageColumnTypes(columnBlocks)
+
+	err := addStorageTableColumns(d, client, addedColumns, columnTypes)
+
+	if err == nil && d.HasChange("column") {
+		err = updateStorageColumnTypes(d, client, storageColumnTypes(oldColumnBlocks.([]interface{})), columnTypes)
+	}
+
+	if err == nil && d.HasChange("primary_key") {
+		err = updateStorageTablePrimaryKey(d, client)
+	}
+
+	if err == nil {
+		err = dropStorageTableColumns(d, client, droppedColumns)
+	}
+
+	sourceFileLoaded := false
+
+	if _, ok := d.GetOk("source_file"); ok && err == nil && d.HasChange("source_file_hash") {
+		err = importStorageTableSourceFile(d, client, schema.TimeoutUpdate)
+		sourceFileLoaded = err == nil
+	}
+
+	if err == nil && (d.HasChange("metadata") || d.HasChange("column_metadata")) {
+		err = updateStorageTableMetadata(d, client)
+	}
+
+	if err != nil {
+		//The planned hash would otherwise be saved, so that a source_file which failed to load is not loaded again.
+		if !sourceFileLoaded {
+			oldSourceFileHash, _ := d.GetChange("source_file_hash")
+			d.Set("source_file_hash", oldSourceFileHash)
+		}
+
+		//Refresh the table, so that the state reflects any changes made before the error.
+		resourceKeboolaStorageTableRead(d, meta)
+		return err
+	}
+
+	return resourceKeboolaStorageTableRead(d, meta)
+}
+
+func addStorageTableColumns(d *schema.ResourceData, client *KBCClient, columns []string, columnTypes map[string]StorageColumnType) error {
+	for _, column := range columns {
+		log.Printf("[INFO] Adding column %s to Storage Table %s", column, d.Id())
+
+		addColumnForm := url.Values{}
+		addColumnForm.Add("name", column)
+
+		if columnType, ok := columnTypes[column]; ok {
+			addColumnForm.Add("definition[type]", columnType.Type)
+			addColumnForm.Add("definition[nullable]", strconv.FormatBool(columnType.Nullable))
+
+			if columnType.Length != "" {
+				addColumnForm.Add("definition[length]", columnType.Length)
+			}
+
+			if columnType.Default != "" {
+				addColumnForm.Add("definition[default]", columnType.Default)
+			}
+		}
+
+		addColumnBuffer := buffer.FromForm(addColumnForm)
+
+		addColumnResponse, err := client.PostToStorage(fmt.Sprintf("storage/tables/%s/columns", d.Id()), addColumnBuffer)
+
+		if hasErrors(err, addColumnResponse) {
+			return extractError(err, addColumnResponse)
+		}
+
+		if _, err := client.waitForAcceptedStorageJob(addColumnResponse, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+//updateStorageColumnTypes changes the length, nullability and default value of the existing columns of a
+//typed table in place. Columns whose data type changes are not updated here, as they recreate the table.
+func updateStorageColumnTypes(d *schema.ResourceData, client *KBCClient, oldColumnTypes map[string]StorageColumnType, newColumnTypes map[string]StorageColumnType) error {
+	for columnName, newColumnType := range newColumnTypes {
+		oldColumnType, ok := oldColumnTypes[columnName]
+		oldColumnType, newColumnType = normalizedStorageColumnType(oldColumnType), normalizedStorageColumnType(newColumnType)
+
+		//The data type cannot be changed in place, so it is left out of the comparison and the update.
+		oldColumnType.Type, newColumnType.Type = "", ""
+
+		if !ok || oldColumnType == newColumnType {
+			continue
+		}
+
+		log.Printf("[INFO] Updating definition of column %s of Storage Table %s", columnName, d.Id())
+
+		columnTypeJSON, err := json.Marshal(newColumnType)
+
+		if err != nil {
+			return err
+		}
+
+		updateResponse, err := client.PutJSONToStorage(fmt.Sprintf("storage/tables/%s/columns/%s/definition", d.Id(), url.PathEscape(columnName)), bytes.NewBuffer(columnTypeJSON))
+
+		if hasErrors(err, updateResponse) {
+			return extractError(err, updateResponse)
+		}
+
+		if _, err := client.waitForAcceptedStorageJob(updateResponse, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func dropStorageTableColumns(d *schema.ResourceData, client *KBCClient, columns []string) error {
+	for _, column := range columns {
+		log.Printf("[INFO] Dropping column %s from Storage Table %s", column, d.Id())
+
+		dropColumnResponse, err := client.DeleteFromStorage(fmt.Sprintf("storage/tables/%s/columns/%s", d.Id(), url.PathEscape(column)))
+
+		if hasErrors(err, dropColumnResponse) {
+			return extractError(err, dropColumnResponse)
+		}
+
+		if _, err := client.waitForAcceptedStorageJob(dropColumnResponse, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+//updateStorageTablePrimaryKey replaces the primary key of a table, by removing the existing key and creating the
+//new one. If the new key cannot be created (e.g. because the data in the table is not unique by the new key),
+//the previous key is restored, and the error (including that of the failed job) is returned.
+func updateStorageTablePrimaryKey(d *schema.ResourceData, client *KBCClient) error {
+	oldPrimaryKey, newPrimaryKey := d.GetChange("primary_key")
+	previousKey := AsStringArray(oldPrimaryKey.([]interface{}))
+
+	if len(previousKey) > 0 {
+		log.Printf("[INFO] Removing primary key of Storage Table %s", d.Id())
+
+		if err := deleteStorageTablePrimaryKey(d, client); err != nil {
+			return err
+		}
+	}
+
+	err := createStorageTablePrimaryKey(d, client, AsStringArray(newPrimaryKey.([]interface{})))
+
+	if err != nil && len(previousKey) > 0 {
+		log.Printf("[WARN] Restoring previous primary key (%s) of Storage Table %s", strings.Join(previousKey, ", "), d.Id())
+
+		if restoreErr := createStorageTablePrimaryKey(d, client, previousKey); restoreErr != nil {
+			log.Printf("[ERROR] Unable to restore previous primary key of Storage Table %s: %s", d.Id(), restoreErr)
+		}
+	}
+
+	return err
+}
+
+func deleteStorageTablePrimaryKey(d *schema.ResourceData, client *KBCClient) error {
+	deleteKeyResponse, err := client.DeleteFromStorage(fmt.Sprintf("storage/tables/%s/primary-key", d.Id()))
+
+	if hasErrors(err, deleteKeyResponse) {
+		return extractError(err, deleteKeyResponse)
+	}
+
+	_, err = client.waitForAcceptedStorageJob(deleteKeyResponse, d.Timeout(schema.TimeoutUpdate))
+
+	return err
+}
+
+func createStorageTablePrimaryKey(d *schema.ResourceData, client *KBCClient, primaryKey []string) error {
+	if len(primaryKey) == 0 {
+		return nil
+	}
+
+	log.Printf("[INFO] Creating primary key (%s) of Storage Table %s", strings.Join(primaryKey, ", "), d.Id())
+
+	createKeyForm := url.Values{}
+	for _, column := range primaryKey {
+		createKeyForm.Add("columns[]", column)
+	}
+
+	createKeyBuffer := buffer.FromForm(createKeyForm)
+
+	createKeyResponse, err := client.PostToStorage(fmt.Sprintf("storage/tables/%s/primary-key", d.Id()), createKeyBuffer)
+
+	if hasErrors(err, createKeyResponse) {
+		return extractError(err, createKeyResponse)
+	}
+
+	_, err = client.waitForAcceptedStorageJob(createKeyResponse, d.Timeout(schema.TimeoutUpdate))
+
+	return err
+}
+
+//updateStorageTableMetadata sets and deletes the metadata of a table and its columns (under the provider's
+//metadata_provider), so that it matches the metadata and column_metadata attributes. All new and changed
+//values are set by a single request, while each removed entry has to be deleted on its own.
+func updateStorageTableMetadata(d *schema.ResourceData, client *KBCClient) error {
+	tableURI := fmt.Sprintf("storage/tables/%s", d.Id())
+	getResponse, err := client.GetFromStorage(tableURI)
+
+	if hasErrors(err, getResponse) {
+		return extractError(err, getResponse)
+	}
+
+	var storageTable StorageTable
+
+	decoder := json.NewDecoder(getResponse.Body)
+	err = decoder.Decode(&storageTable)
+
+	if err != nil {
+		return err
+	}
+
+	metadataForm := url.Values{}
+
+	changedMetadata, removedMetadata := diffStorageMetadata(storageTable.Metadata, client.MetadataProvider, d.Get("metadata").(map[string]interface{}))
+	addStorageMetadataForm(metadataForm, "metadata", changedMetadata)
+
+	desiredColumnMetadata := make(map[string]map[string]interface{})
+
+	for _, columnMetadata := range d.Get("column_metadata").(*schema.Set).List() {
+		columnMetadata := columnMetadata.(map[string]interface{})
+		desiredColumnMetadata[columnMetadata["column"].(string)] = columnMetadata["metadata"].(map[string]interface{})
+	}
+
+	removedColumnMetadata := make(map[string][]StorageMetadata)
+
+	for _, column := range storageTable.Columns {
+		changedValues, removedValues := diffStorageMetadata(storageTable.ColumnMetadata[column], client.MetadataProvider, desiredColumnMetadata[column])
+		addStorageMetadataForm(metadataForm, fmt.Sprintf("columnsMetadata[%s]", column), changedValues)
+
+		if len(removedValues) > 0 {
+			removedColumnMetadata[column] = removedValues
+		}
+	}
+
+	if len(metadataForm) > 0 {
+		log.Printf("[INFO] Setting metadata of Storage Table %s", d.Id())
+
+		err = postStorageMetadata(client, tableURI, metadataForm)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	err = deleteStorageMetadata(client, tableURI, removedMetadata)
+
+	if err != nil {
+		return err
+	}
+
+	for column, removedValues := range removedColumnMetadata {
+		err = deleteStorageMetadata(client, fmt.Sprintf("storage/columns/%s.%s", d.Id(), url.PathEscape(column)), removedValues)
+
+		if err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
 
+//resourceKeboolaStorageTableDelete refuses to delete a table which has rows, unless deletion_protection is turned off.
 func resourceKeboolaStorageTableDelete(d *schema.ResourceData, meta interface{}) error {
 	log.Printf("[INFO] Deleting Storage Table in Keboola: %s", d.Id())
 
 	client := meta.(*KBCClient)
+
+	if d.Get("deletion_protection").(bool) {
+		getResponse, err := client.GetFromStorage(fmt.Sprintf("storage/tables/%s", d.Id()))
+
+		if hasErrors(err, getResponse) {
+			err = extractError(err, getResponse)
+
+			if isNotFoundError(err) {
+				d.SetId("")
+				return nil
+			}
+
+			return err
+		}
+
+		var storageTable StorageTable
+
+		decoder := json.NewDecoder(getResponse.Body)
+		err = decoder.Decode(&storageTable)
+
+		if err != nil {
+			return err
+		}
+
+		if storageTable.RowsCount > 0 {
+			return fmt.Errorf("table %s has %d rows, set deletion_protection = false to delete it along with its data", d.Id(), storageTable.RowsCount)
+		}
+	}
+
 	destroyResponse, err := client.DeleteFromStorage(fmt.Sprintf("storage/tables/%s", d.Id()))
 
 	if hasErrors(err, destroyResponse) {
@@ -0,0 +1,342 @@
+package keboola
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/paybyphone/terraform-provider-keboola/plugin/providers/keboola/buffer"
+)
+
+//region Keboola API Contracts
+
+//StorageTableAlias is the data model for alias tables within the Keboola Storage API,
+//which expose (a filtered subset of) a table from another bucket.
+type StorageTableAlias struct {
+	ID          string   `json:"id,omitempty"`
+	Name        string   `json:"name"`
+	IsAlias     bool     `json:"isAlias"`
+	Columns     []string `json:"columns"`
+	SourceTable struct {
+		ID string `json:"id"`
+	} `json:"sourceTable"`
+	AliasFilter          *AliasFilter `json:"aliasFilter,omitempty"`
+	AliasColumnsAutoSync bool         `json:"aliasColumnsAutoSync"`
+	Bucket               struct {
+		ID string `json:"id"`
+	} `json:"bucket"`
+}
+
+//AliasFilter restricts the rows of an alias table to those where a column does (eq),
+//or does not (ne), equal one of the values.
+type AliasFilter struct {
+	Column   string   `json:"column"`
+	Operator string   `json:"operator"`
+	Values   []string `json:"values"`
+}
+
+//endregion
+
+func resourceKeboolaStorageTableAlias() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceKeboolaStorageTableAliasCreate,
+		Read:   resourceKeboolaStorageTableAliasRead,
+		Update: resourceKeboolaStorageTableAliasUpdate,
+		Delete: resourceKeboolaStorageTableAliasDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		CustomizeDiff: resourceKeboolaStorageTableAliasCustomizeDiff,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultJobTimeout),
+			Update: schema.DefaultTimeout(defaultJobTimeout),
+			Delete: schema.DefaultTimeout(defaultJobTimeout),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"bucket_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"source_table": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the table that the alias exposes.",
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"alias_columns": {
+				Type:             schema.TypeList,
+				Optional:         true,
+				ForceNew:         true,
+				Elem:             &schema.Schema{Type: schema.TypeString},
+				DiffSuppressFunc: suppressAliasColumnsDiff,
+				Description:      "The columns of the source table to expose. Defaults to all of its columns. Read back from the alias whenever auto_sync_columns is false.",
+			},
+			"alias_filter": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"column": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"operator": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "eq",
+							ValidateFunc: validateAliasFilterOperator,
+						},
+						"values": {
+							Type:     schema.TypeList,
+							Required: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+			"auto_sync_columns": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "Whether columns added to or removed from the source table are also added to or removed from the alias. Defaults to true, unless alias_columns are set.",
+			},
+			"columns": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+//resourceKeboolaStorageTableAliasCustomizeDiff stops a plan that asks for the columns of an alias
+//to be synchronised with its source table, while also choosing which of those columns to expose.
+func resourceKeboolaStorageTableAliasCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	autoSyncColumns, autoSyncColumnsSet := d.GetOkExists("auto_sync_columns")
+
+	if autoSyncColumnsSet && autoSyncColumns.(bool) && len(d.Get("alias_columns").([]interface{})) > 0 {
+		return fmt.Errorf("auto_sync_columns cannot be true when alias_columns are set")
+	}
+
+	return nil
+}
+
+//suppressAliasColumnsDiff suppresses the removal of alias_columns which were read back from an alias that does not
+//synchronise its columns, when none are set. Such an alias keeps the columns its source table had when synchronisation
+//was disabled, and only exposes all of the columns of its source table again once synchronisation is enabled.
+//noinspection GoUnusedParameter
+func suppressAliasColumnsDiff(k, old, new string, d *schema.ResourceData) bool {
+	if d.Get("auto_sync_columns").(bool) {
+		return false
+	}
+
+	if strings.HasSuffix(k, ".#") {
+		return new == "0"
+	}
+
+	return new == ""
+}
+
+//aliasFilterForm adds the alias_filter of an alias table to a form, as aliasFilter[column] etc.
+func aliasFilterForm(form url.Values, aliasFilter map[string]interface{}) {
+	form.Add("aliasFilter[column]", aliasFilter["column"].(string))
+	form.Add("aliasFilter[operator]", aliasFilter["operator"].(string))
+
+	for _, value := range AsStringArray(aliasFilter["values"].([]interface{})) {
+		form.Add("aliasFilter[values][]", value)
+	}
+}
+
+func resourceKeboolaStorageTableAliasCreate(d *schema.ResourceData, meta interface{}) error {
+	log.Println("[INFO] Creating Storage Table Alias in Keboola.")
+
+	createAliasForm := url.Values{}
+	createAliasForm.Add("name", d.Get("name").(string))
+	createAliasForm.Add("sourceTable", d.Get("source_table").(string))
+
+	for _, column := range AsStringArray(d.Get("alias_columns").([]interface{})) {
+		createAliasForm.Add("aliasColumns[]", column)
+	}
+
+	if aliasFilter := d.Get("alias_filter").([]interface{}); len(aliasFilter) > 0 {
+		aliasFilterForm(createAliasForm, aliasFilter[0].(map[string]interface{}))
+	}
+
+	createAliasBuffer := buffer.FromForm(createAliasForm)
+
+	client := meta.(*KBCClient)
+	createResponse, err := client.PostToStorage(fmt.Sprintf("storage/buckets/%s/table-aliases", d.Get("bucket_id").(string)), createAliasBuffer)
+
+	if hasErrors(err, createResponse) {
+		return extractError(err, createResponse)
+	}
+
+	//Depending on the backend, the alias is either created straight away, or by an asynchronous job.
+	if createResponse.StatusCode == http.StatusAccepted {
+		createStatusResult, err := client.waitForAcceptedStorageJob(createResponse, d.Timeout(schema.TimeoutCreate))
+
+		if err != nil {
+			return err
+		}
+
+		d.SetId(string(createStatusResult.Results.ID))
+	} else {
+		var createResult CreateResourceResult
+
+		decoder := json.NewDecoder(createResponse.Body)
+		err = decoder.Decode(&createResult)
+
+		if err != nil {
+			return err
+		}
+
+		d.SetId(string(createResult.ID))
+	}
+
+	autoSyncColumns, autoSyncColumnsSet := d.GetOkExists("auto_sync_columns")
+
+	if autoSyncColumnsSet && !autoSyncColumns.(bool) && len(d.Get("alias_columns").([]interface{})) == 0 {
+		log.Printf("[INFO] Disabling automatic columns synchronisation of Storage Table Alias %s", d.Id())
+
+		disableResponse, err := client.PostToStorage(fmt.Sprintf("storage/tables/%s/alias-disable-auto-sync", d.Id()), buffer.Empty())
+
+		if hasErrors(err, disableResponse) {
+			return extractError(err, disableResponse)
+		}
+	}
+
+	return resourceKeboolaStorageTableAliasRead(d, meta)
+}
+
+func resourceKeboolaStorageTableAliasRead(d *schema.ResourceData, meta interface{}) error {
+	log.Println("[INFO] Reading Storage Table Alias from Keboola.")
+
+	if d.Id() == "" {
+		return nil
+	}
+
+	client := meta.(*KBCClient)
+	getResponse, err := client.GetFromStorage(fmt.Sprintf("storage/tables/%s", d.Id()))
+
+	if hasErrors(err, getResponse) {
+		err = extractError(err, getResponse)
+
+		if isNotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+
+		return err
+	}
+
+	var alias StorageTableAlias
+
+	decoder := json.NewDecoder(getResponse.Body)
+	err = decoder.Decode(&alias)
+
+	if err != nil {
+		return err
+	}
+
+	if !alias.IsAlias {
+		return fmt.Errorf("table %s is not an alias table, so cannot be managed as a keboola_storage_table_alias", d.Id())
+	}
+
+	d.Set("bucket_id", alias.Bucket.ID)
+	d.Set("source_table", alias.SourceTable.ID)
+	d.Set("name", alias.Name)
+	d.Set("auto_sync_columns", alias.AliasColumnsAutoSync)
+	d.Set("columns", alias.Columns)
+
+	//An alias which does not synchronise its columns exposes either the columns it was created with, or those
+	//which the source table had when synchronisation was disabled, and both are kept in alias_columns.
+	if alias.AliasColumnsAutoSync {
+		d.Set("alias_columns", nil)
+	} else {
+		d.Set("alias_columns", alias.Columns)
+	}
+
+	var aliasFilter []map[string]interface{}
+
+	if alias.AliasFilter != nil {
+		aliasFilter = append(aliasFilter, map[string]interface{}{
+			"column":   alias.AliasFilter.Column,
+			"operator": alias.AliasFilter.Operator,
+			"values":   alias.AliasFilter.Values,
+		})
+	}
+
+	d.Set("alias_filter", aliasFilter)
+
+	return nil
+}
+
+//resourceKeboolaStorageTableAliasUpdate changes or removes the filter of an alias in place,
+//which is the only attribute of an alias that can be changed without recreating it.
+func resourceKeboolaStorageTableAliasUpdate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[INFO] Updating Storage Table Alias in Keboola: %s", d.Id())
+
+	client := meta.(*KBCClient)
+	aliasFilterURI := fmt.Sprintf("storage/tables/%s/alias-filter", d.Id())
+
+	if aliasFilter := d.Get("alias_filter").([]interface{}); len(aliasFilter) > 0 {
+		updateFilterForm := url.Values{}
+		aliasFilterForm(updateFilterForm, aliasFilter[0].(map[string]interface{}))
+
+		updateFilterResponse, err := client.PostToStorage(aliasFilterURI, buffer.FromForm(updateFilterForm))
+
+		if hasErrors(err, updateFilterResponse) {
+			return extractError(err, updateFilterResponse)
+		}
+
+		if _, err := client.waitForAcceptedStorageJob(updateFilterResponse, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return err
+		}
+	} else {
+		removeFilterResponse, err := client.DeleteFromStorage(aliasFilterURI)
+
+		if hasErrors(err, removeFilterResponse) {
+			return extractError(err, removeFilterResponse)
+		}
+
+		if _, err := client.waitForAcceptedStorageJob(removeFilterResponse, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return err
+		}
+	}
+
+	return resourceKeboolaStorageTableAliasRead(d, meta)
+}
+
+func resourceKeboolaStorageTableAliasDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[INFO] Deleting Storage Table Alias in Keboola: %s", d.Id())
+
+	client := meta.(*KBCClient)
+	destroyResponse, err := client.DeleteFromStorage(fmt.Sprintf("storage/tables/%s", d.Id()))
+
+	if hasErrors(err, destroyResponse) {
+		return extractError(err, destroyResponse)
+	}
+
+	if _, err := client.waitForAcceptedStorageJob(destroyResponse, d.Timeout(schema.TimeoutDelete)); err != nil {
+		return err
+	}
+
+	d.SetId("")
+
+	return nil
+}
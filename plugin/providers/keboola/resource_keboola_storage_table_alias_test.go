@@ -0,0 +1,242 @@
+package keboola
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccStorageTableAlias_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testAccCheckStorageTableAliasDestroy,
+			testAccCheckStorageTableDestroy,
+			testAccCheckStorageBucketDestroy,
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: testStorageTableAlias(testStorageTableAliasFilter),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("keboola_storage_table_alias.test_alias", "name", "test_alias"),
+					resource.TestCheckResourceAttr("keboola_storage_table_alias.test_alias", "alias_filter.0.column", "country"),
+				),
+			},
+			{
+				Config: testStorageTableAlias(testStorageTableAliasFilterUpdate),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("keboola_storage_table_alias.test_alias", "alias_filter.0.operator", "ne"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckStorageTableAliasDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*KBCClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "keboola_storage_table_alias" {
+			continue
+		}
+
+		getResp, err := client.GetFromStorage(fmt.Sprintf("storage/tables/%s", rs.Primary.ID))
+
+		if err == nil && getResp.StatusCode == 200 {
+			return fmt.Errorf("Storage table alias still exists")
+		}
+	}
+
+	return nil
+}
+
+func testStorageTableAlias(alias string) string {
+	return fmt.Sprintf(`
+	resource "keboola_storage_bucket" "source_bucket" {
+		name = "source_bucket"
+		stage = "in"
+		backend = "snowflake"
+	}
+
+	resource "keboola_storage_bucket" "alias_bucket" {
+		name = "alias_bucket"
+		stage = "out"
+		backend = "snowflake"
+	}
+
+	resource "keboola_storage_table" "source_table" {
+		bucket_id = "${keboola_storage_bucket.source_bucket.id}"
+		name = "customers"
+		columns = [ "id", "country", "name" ]
+		primary_key = [ "id" ]
+	}
+
+	resource "keboola_storage_table_alias" "test_alias" {
+		bucket_id = "${keboola_storage_bucket.alias_bucket.id}"
+		source_table = "${keboola_storage_table.source_table.id}"
+		name = "test_alias"
+		%s
+	}`, alias)
+}
+
+const testStorageTableAliasFilter = `
+		alias_filter {
+			column = "country"
+			values = [ "CZ", "SK" ]
+		}`
+
+const testStorageTableAliasFilterUpdate = `
+		alias_filter {
+			column = "country"
+			operator = "ne"
+			values = [ "US" ]
+		}`
+
+func TestUnitStorageTableAlias_Basic(t *testing.T) {
+	mock := newMockKeboolaAPI()
+	defer mock.Close()
+
+	const aliasID = "out.c-alias_bucket.test_alias"
+	notRecreated := testCheckStorageTableNotRecreated(mock, aliasID)
+
+	testCheckAliasFilter := func(expected string) resource.TestCheckFunc {
+		return func(s *terraform.State) error {
+			mock.mutex.Lock()
+			defer mock.mutex.Unlock()
+
+			actual := ""
+			if filter := mock.tables[aliasID].AliasFilter; filter != nil {
+				actual = fmt.Sprintf("%s %s %v", filter.Column, filter.Operator, filter.Values)
+			}
+
+			if actual != expected {
+				return fmt.Errorf("Expected alias filter %q, got %q", expected, actual)
+			}
+
+			return nil
+		}
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    mock.providers(),
+		CheckDestroy: mock.testCheckDestroy("keboola_storage_table_alias", "/v2/storage/tables/%s", "id"),
+		Steps: []resource.TestStep{
+			{
+				Config: mock.config(testStorageTableAlias(testStorageTableAliasFilter)),
+				Check: resource.ComposeTestCheckFunc(
+					notRecreated,
+					mock.testCheckExists("keboola_storage_table_alias.test_alias", "/v2/storage/tables/%s", "id"),
+					resource.TestCheckResourceAttr("keboola_storage_table_alias.test_alias", "id", aliasID),
+					resource.TestCheckResourceAttr("keboola_storage_table_alias.test_alias", "source_table", "in.c-source_bucket.customers"),
+					resource.TestCheckResourceAttr("keboola_storage_table_alias.test_alias", "auto_sync_columns", "true"),
+					resource.TestCheckResourceAttr("keboola_storage_table_alias.test_alias", "columns.#", "3"),
+					resource.TestCheckResourceAttr("keboola_storage_table_alias.test_alias", "alias_filter.0.operator", "eq"),
+					resource.TestCheckResourceAttr("keboola_storage_table_alias.test_alias", "alias_filter.0.values.#", "2"),
+					testCheckAliasFilter("country eq [CZ SK]"),
+				),
+			},
+			{
+				Config: mock.config(testStorageTableAlias(testStorageTableAliasFilterUpdate)),
+				Check: resource.ComposeTestCheckFunc(
+					notRecreated,
+					resource.TestCheckResourceAttr("keboola_storage_table_alias.test_alias", "alias_filter.0.operator", "ne"),
+					resource.TestCheckResourceAttr("keboola_storage_table_alias.test_alias", "alias_filter.0.values.0", "US"),
+					testCheckAliasFilter("country ne [US]"),
+				),
+			},
+			{
+				Config:            mock.config(testStorageTableAlias(testStorageTableAliasFilterUpdate)),
+				ResourceName:      "keboola_storage_table_alias.test_alias",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: mock.config(testStorageTableAlias("")),
+				Check: resource.ComposeTestCheckFunc(
+					notRecreated,
+					resource.TestCheckResourceAttr("keboola_storage_table_alias.test_alias", "alias_filter.#", "0"),
+					testCheckAliasFilter(""),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitStorageTableAlias_Columns(t *testing.T) {
+	mock := newMockKeboolaAPI()
+	defer mock.Close()
+
+	const aliasID = "out.c-alias_bucket.test_alias"
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    mock.providers(),
+		CheckDestroy: mock.testCheckDestroy("keboola_storage_table_alias", "/v2/storage/tables/%s", "id"),
+		Steps: []resource.TestStep{
+			{
+				Config: mock.config(testStorageTableAlias(`
+		alias_columns = [ "id", "name" ]
+		auto_sync_columns = true`)),
+				ExpectError: regexp.MustCompile("auto_sync_columns cannot be true when alias_columns are set"),
+			},
+			{
+				Config: mock.config(testStorageTableAlias(`
+		alias_columns = [ "id", "name" ]`)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("keboola_storage_table_alias.test_alias", "auto_sync_columns", "false"),
+					resource.TestCheckResourceAttr("keboola_storage_table_alias.test_alias", "columns.#", "2"),
+					resource.TestCheckResourceAttr("keboola_storage_table_alias.test_alias", "columns.1", "name"),
+					resource.TestCheckResourceAttr("keboola_storage_table_alias.test_alias", "alias_columns.#", "2"),
+				),
+			},
+			{
+				Config: mock.config(testStorageTableAlias(`
+		alias_columns = [ "id", "name" ]`)),
+				ResourceName:      "keboola_storage_table_alias.test_alias",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				//Leaving out alias_columns keeps the columns of an alias which does not synchronise them.
+				Config: mock.config(testStorageTableAlias(`
+		auto_sync_columns = false`)),
+				PlanOnly: true,
+			},
+			{
+				Config: mock.config(testStorageTableAlias(`
+		auto_sync_columns = true`)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("keboola_storage_table_alias.test_alias", "auto_sync_columns", "true"),
+					resource.TestCheckResourceAttr("keboola_storage_table_alias.test_alias", "columns.#", "3"),
+					resource.TestCheckResourceAttr("keboola_storage_table_alias.test_alias", "alias_columns.#", "0"),
+				),
+			},
+			{
+				PreConfig: func() {
+					mock.mutex.Lock()
+					defer mock.mutex.Unlock()
+
+					//Synchronisation is disabled outside of Terraform.
+					mock.tables[aliasID].AliasColumnsAutoSync = false
+				},
+				Config: mock.config(testStorageTableAlias(`
+		auto_sync_columns = false`)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("keboola_storage_table_alias.test_alias", "auto_sync_columns", "false"),
+					resource.TestCheckResourceAttr("keboola_storage_table_alias.test_alias", "columns.#", "3"),
+					resource.TestCheckResourceAttr("keboola_storage_table_alias.test_alias", "alias_columns.#", "3"),
+				),
+			},
+			{
+				Config: mock.config(testStorageTableAlias(`
+		auto_sync_columns = false`)),
+				ResourceName:      "keboola_storage_table_alias.test_alias",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -0,0 +1,144 @@
+package keboola
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/url"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/paybyphone/terraform-provider-keboola/plugin/providers/keboola/buffer"
+)
+
+//region Keboola API Contracts
+
+//StorageTableSnapshot is the data model for a snapshot of a Storage Table within the Keboola Storage API.
+type StorageTableSnapshot struct {
+	ID          KBCNumberString `json:"id,omitempty"`
+	Description string          `json:"description"`
+	CreatedTime string          `json:"createdTime,omitempty"`
+	Table       struct {
+		ID string `json:"id"`
+	} `json:"table"`
+}
+
+//endregion
+
+func resourceKeboolaStorageTableSnapshot() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceKeboolaStorageTableSnapshotCreate,
+		Read:   resourceKeboolaStorageTableSnapshotRead,
+		Delete: resourceKeboolaStorageTableSnapshotDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultJobTimeout),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"table_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"created_time": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceKeboolaStorageTableSnapshotCreate(d *schema.ResourceData, meta interface{}) error {
+	tableID := d.Get("table_id").(string)
+
+	log.Printf("[INFO] Creating Snapshot of Storage Table %s in Keboola.", tableID)
+
+	createSnapshotForm := url.Values{}
+	createSnapshotForm.Add("description", d.Get("description").(string))
+
+	client := meta.(*KBCClient)
+	createResponse, err := client.PostToStorage(fmt.Sprintf("storage/tables/%s/snapshots", tableID), buffer.FromForm(createSnapshotForm))
+
+	if hasErrors(err, createResponse) {
+		return extractError(err, createResponse)
+	}
+
+	var createJob StorageJobStatus
+
+	decoder := json.NewDecoder(createResponse.Body)
+	err = decoder.Decode(&createJob)
+
+	if err != nil {
+		return err
+	}
+
+	createStatus, err := client.waitForStorageJob(createJob.ID, d.Timeout(schema.TimeoutCreate))
+
+	if err != nil {
+		return err
+	}
+
+	d.SetId(string(createStatus.Results.ID))
+
+	return resourceKeboolaStorageTableSnapshotRead(d, meta)
+}
+
+func resourceKeboolaStorageTableSnapshotRead(d *schema.ResourceData, meta interface{}) error {
+	log.Println("[INFO] Reading Storage Table Snapshot from Keboola.")
+
+	if d.Id() == "" {
+		return nil
+	}
+
+	client := meta.(*KBCClient)
+	getResponse, err := client.GetFromStorage(fmt.Sprintf("storage/snapshots/%s", d.Id()))
+
+	if hasErrors(err, getResponse) {
+		err = extractError(err, getResponse)
+
+		if isNotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+
+		return err
+	}
+
+	var snapshot StorageTableSnapshot
+
+	decoder := json.NewDecoder(getResponse.Body)
+	err = decoder.Decode(&snapshot)
+
+	if err != nil {
+		return err
+	}
+
+	d.Set("table_id", snapshot.Table.ID)
+	d.Set("description", snapshot.Description)
+	d.Set("created_time", snapshot.CreatedTime)
+
+	return nil
+}
+
+func resourceKeboolaStorageTableSnapshotDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[INFO] Deleting Storage Table Snapshot in Keboola: %s", d.Id())
+
+	client := meta.(*KBCClient)
+	destroyResponse, err := client.DeleteFromStorage(fmt.Sprintf("storage/snapshots/%s", d.Id()))
+
+	if hasErrors(err, destroyResponse) {
+		return extractError(err, destroyResponse)
+	}
+
+	d.SetId("")
+
+	return nil
+}
@@ -0,0 +1,179 @@
+package keboola
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccStorageTableSnapshot_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testAccCheckStorageTableSnapshotDestroy,
+			testAccCheckStorageTableDestroy,
+			testAccCheckStorageBucketDestroy,
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: testStorageTableSnapshot("before migration", `"id"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("keboola_storage_table_snapshot.test_snapshot", "description", "before migration"),
+					resource.TestCheckResourceAttrSet("keboola_storage_table_snapshot.test_snapshot", "created_time"),
+					resource.TestCheckResourceAttr("keboola_storage_table.restored_table", "columns.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckStorageTableSnapshotDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*KBCClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "keboola_storage_table_snapshot" {
+			continue
+		}
+
+		getResp, err := client.GetFromStorage(fmt.Sprintf("storage/snapshots/%s", rs.Primary.ID))
+
+		if err == nil && getResp.StatusCode == 200 {
+			return fmt.Errorf("Storage table snapshot still exists")
+		}
+	}
+
+	return nil
+}
+
+const testStorageTableSnapshotSource = `
+	resource "keboola_storage_bucket" "test_bucket" {
+		name = "snapshot_bucket"
+		stage = "in"
+		backend = "snowflake"
+	}
+
+	resource "keboola_storage_table" "source_table" {
+		bucket_id = "${keboola_storage_bucket.test_bucket.id}"
+		name = "customers"
+		columns = [ "id", "name" ]
+		primary_key = [ "id" ]
+		deletion_protection = false
+	}`
+
+func testStorageTableSnapshot(description string, restoredPrimaryKey string) string {
+	return testStorageTableSnapshotSource + fmt.Sprintf(`
+
+	resource "keboola_storage_table_snapshot" "test_snapshot" {
+		table_id = "${keboola_storage_table.source_table.id}"
+		description = "%s"
+	}
+
+	resource "keboola_storage_table" "restored_table" {
+		bucket_id = "${keboola_storage_bucket.test_bucket.id}"
+		name = "customers_restored"
+		from_snapshot_id = "${keboola_storage_table_snapshot.test_snapshot.id}"
+		primary_key = [ %s ]
+		deletion_protection = false
+	}`, description, restoredPrimaryKey)
+}
+
+func TestUnitStorageTableSnapshot_Basic(t *testing.T) {
+	mock := newMockKeboolaAPI()
+	defer mock.Close()
+
+	const sourceTableID = "in.c-snapshot_bucket.customers"
+	const restoredTableID = "in.c-snapshot_bucket.customers_restored"
+
+	rows := [][]string{{"1", "Alice"}, {"2", "Bob"}}
+
+	insertRows := func() {
+		mock.mutex.Lock()
+		defer mock.mutex.Unlock()
+
+		mock.tables[sourceTableID].rows = rows
+		mock.tables[sourceTableID].RowsCount = len(rows)
+	}
+
+	testCheckRestoredTable := func(expectedPrimaryKey []string) resource.TestCheckFunc {
+		return func(s *terraform.State) error {
+			mock.mutex.Lock()
+			defer mock.mutex.Unlock()
+
+			table := mock.tables[restoredTableID]
+
+			if table == nil {
+				return fmt.Errorf("Storage table %s not found", restoredTableID)
+			}
+
+			if !reflect.DeepEqual(table.Columns, []string{"id", "name"}) {
+				return fmt.Errorf("Expected restored columns [id name], got %v", table.Columns)
+			}
+
+			if !reflect.DeepEqual(table.PrimaryKey, expectedPrimaryKey) {
+				return fmt.Errorf("Expected primary key %v, got %v", expectedPrimaryKey, table.PrimaryKey)
+			}
+
+			if !reflect.DeepEqual(table.rows, rows) {
+				return fmt.Errorf("Expected restored rows %v, got %v", rows, table.rows)
+			}
+
+			//The restored table must not share its rows with the table the snapshot was taken of.
+			if len(mock.tables[sourceTableID].rows) > 0 && &table.rows[0][0] == &mock.tables[sourceTableID].rows[0][0] {
+				return fmt.Errorf("Restored table %s shares its rows with %s", restoredTableID, sourceTableID)
+			}
+
+			return nil
+		}
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers: mock.providers(),
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			mock.testCheckDestroy("keboola_storage_table_snapshot", "/v2/storage/snapshots/%s", "id"),
+			mock.testCheckDestroy("keboola_storage_table", "/v2/storage/tables/%s", "id"),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: mock.config(testStorageTableSnapshotSource),
+			},
+			{
+				PreConfig: insertRows,
+				Config:    mock.config(testStorageTableSnapshot("before migration", `"id"`)),
+				Check: resource.ComposeTestCheckFunc(
+					mock.testCheckExists("keboola_storage_table_snapshot.test_snapshot", "/v2/storage/snapshots/%s", "id"),
+					resource.TestCheckResourceAttr("keboola_storage_table_snapshot.test_snapshot", "table_id", sourceTableID),
+					resource.TestCheckResourceAttr("keboola_storage_table_snapshot.test_snapshot", "description", "before migration"),
+					resource.TestCheckResourceAttrSet("keboola_storage_table_snapshot.test_snapshot", "created_time"),
+					resource.TestCheckResourceAttr("keboola_storage_table.restored_table", "id", restoredTableID),
+					resource.TestCheckResourceAttr("keboola_storage_table.restored_table", "columns.#", "2"),
+					testCheckRestoredTable([]string{"id"}),
+				),
+			},
+			{
+				Config:            mock.config(testStorageTableSnapshot("before migration", `"id"`)),
+				ResourceName:      "keboola_storage_table_snapshot.test_snapshot",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: mock.config(testStorageTableSnapshot("before the second migration", `"name"`)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("keboola_storage_table_snapshot.test_snapshot", "description", "before the second migration"),
+					resource.TestCheckResourceAttr("keboola_storage_table.restored_table", "primary_key.0", "name"),
+					testCheckRestoredTable([]string{"name"}),
+				),
+			},
+			{
+				Config: mock.config(testStorageTableSnapshot("before the second migration", "")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("keboola_storage_table.restored_table", "primary_key.#", "0"),
+					testCheckRestoredTable([]string{}),
+				),
+			},
+		},
+	})
+}
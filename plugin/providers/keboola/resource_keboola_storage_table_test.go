@@ -2,6 +2,11 @@ package keboola
 
 import (
 	"fmt"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform/helper/resource"
@@ -60,3 +65,952 @@ const testStorageTableBasic = `
   	name = "test_table"
   	columns = [ "first", "second", "third" ]
 	}`
+
+func TestUnitStorageTable_Basic(t *testing.T) {
+	mock := newMockKeboolaAPI()
+	defer mock.Close()
+
+	const tablePath = "/v2/storage/tables/%s"
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers: mock.providers(),
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			mock.testCheckDestroy("keboola_storage_table", tablePath, "id"),
+			mock.testCheckDestroy("keboola_storage_bucket", "/v2/storage/buckets/%s", "id"),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: mock.config(testStorageTableBasic),
+				Check: resource.ComposeTestCheckFunc(
+					mock.testCheckExists("keboola_storage_table.test_table", tablePath, "id"),
+					resource.TestCheckResourceAttr("keboola_storage_table.test_table", "id", "out.c-test_bucket_name.test_table"),
+					resource.TestCheckResourceAttr("keboola_storage_table.test_table", "name", "test_table"),
+					resource.TestCheckResourceAttr("keboola_storage_table.test_table", "columns.#", "3"),
+				),
+			},
+			{
+				Config: mock.config(testStorageTableUpdated),
+				Check: resource.ComposeTestCheckFunc(
+					mock.testCheckExists("keboola_storage_table.test_table", tablePath, "id"),
+					resource.TestCheckResourceAttr("keboola_storage_table.test_table", "columns.#", "2"),
+					resource.TestCheckResourceAttr("keboola_storage_table.test_table", "primary_key.0", "first"),
+				),
+			},
+			{
+				Config:                  mock.config(testStorageTableUpdated),
+				ResourceName:            "keboola_storage_table.test_table",
+				ImportState:             true,
+				ImportStateId:           "out.c-test_bucket_name.test_table",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"allow_column_drop", "incremental"},
+			},
+			{
+				Config:        mock.config(testStorageTableUpdated),
+				ResourceName:  "keboola_storage_table.test_table",
+				ImportState:   true,
+				ImportStateId: "out.c-test_bucket_name.missing_table",
+				ExpectError:   regexp.MustCompile("Cannot import non-existent remote object"),
+			},
+		},
+	})
+}
+
+const testStorageTableUpdated = `
+	resource "keboola_storage_bucket" "test_bucket" {
+		name = "test_bucket_name"
+		description = "test description"
+		stage = "out"
+		backend = "snowflake"
+	}
+
+	resource "keboola_storage_table" "test_table" {
+		bucket_id = "${keboola_storage_bucket.test_bucket.id}"
+		name = "test_table"
+		columns = [ "first", "second" ]
+		primary_key = [ "first" ]
+		allow_column_drop = true
+	}`
+
+const testStorageTableAddedColumn = `
+	resource "keboola_storage_bucket" "test_bucket" {
+		name = "test_bucket_name"
+		description = "test description"
+		stage = "out"
+		backend = "snowflake"
+	}
+
+	resource "keboola_storage_table" "test_table" {
+		bucket_id = "${keboola_storage_bucket.test_bucket.id}"
+		name = "test_table"
+		columns = [ "first", "second", "third", "fourth" ]
+	}`
+
+const testStorageTableDroppedColumn = `
+	resource "keboola_storage_bucket" "test_bucket" {
+		name = "test_bucket_name"
+		description = "test description"
+		stage = "out"
+		backend = "snowflake"
+	}
+
+	resource "keboola_storage_table" "test_table" {
+		bucket_id = "${keboola_storage_bucket.test_bucket.id}"
+		name = "test_table"
+		columns = [ "first", "third", "fourth" ]
+	}`
+
+const testStorageTableAllowedColumnDrop = `
+	resource "keboola_storage_bucket" "test_bucket" {
+		name = "test_bucket_name"
+		description = "test description"
+		stage = "out"
+		backend = "snowflake"
+	}
+
+	resource "keboola_storage_table" "test_table" {
+		bucket_id = "${keboola_storage_bucket.test_bucket.id}"
+		name = "test_table"
+		columns = [ "first", "third", "fourth" ]
+		allow_column_drop = true
+	}`
+
+//testCheckStorageTableNotRecreated checks that a table is still the same table in the mock API as in the
+//first step, i.e. that it has been updated in place, rather than being dropped and created again.
+func testCheckStorageTableNotRecreated(mock *mockKeboolaAPI, tableID string) resource.TestCheckFunc {
+	var original *mockTable
+
+	return func(s *terraform.State) error {
+		mock.mutex.Lock()
+		defer mock.mutex.Unlock()
+
+		table := mock.tables[tableID]
+
+		if original == nil {
+			original = table
+		}
+
+		if table == nil || table != original {
+			return fmt.Errorf("Storage table %s has been recreated", tableID)
+		}
+
+		return nil
+	}
+}
+
+func TestUnitStorageTable_AddAndDropColumns(t *testing.T) {
+	mock := newMockKeboolaAPI()
+	defer mock.Close()
+
+	const tableID = "out.c-test_bucket_name.test_table"
+	notRecreated := testCheckStorageTableNotRecreated(mock, tableID)
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    mock.providers(),
+		CheckDestroy: mock.testCheckDestroy("keboola_storage_table", "/v2/storage/tables/%s", "id"),
+		Steps: []resource.TestStep{
+			{
+				Config: mock.config(testStorageTableBasic),
+				Check: resource.ComposeTestCheckFunc(
+					notRecreated,
+					resource.TestCheckResourceAttr("keboola_storage_table.test_table", "columns.#", "3"),
+				),
+			},
+			{
+				Config: mock.config(testStorageTableAddedColumn),
+				Check: resource.ComposeTestCheckFunc(
+					notRecreated,
+					resource.TestCheckResourceAttr("keboola_storage_table.test_table", "columns.#", "4"),
+				),
+			},
+			{
+				Config:      mock.config(testStorageTableDroppedColumn),
+				ExpectError: regexp.MustCompile("removing columns second would drop them from table out.c-test_bucket_name.test_table along with their data, set allow_column_drop = true"),
+			},
+			{
+				Config: mock.config(testStorageTableAllowedColumnDrop),
+				Check: resource.ComposeTestCheckFunc(
+					notRecreated,
+					resource.TestCheckResourceAttr("keboola_storage_table.test_table", "columns.#", "3"),
+					func(s *terraform.State) error {
+						mock.mutex.Lock()
+						defer mock.mutex.Unlock()
+
+						if columns := strings.Join(mock.tables[tableID].Columns, ","); columns != "first,third,fourth" {
+							return fmt.Errorf("Expected the table to have columns first,third,fourth, got %s", columns)
+						}
+
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func testStorageTableWithPrimaryKey(primaryKey string) string {
+	return fmt.Sprintf(`
+	resource "keboola_storage_bucket" "test_bucket" {
+		name = "test_bucket_name"
+		stage = "in"
+		backend = "snowflake"
+	}
+
+	resource "keboola_storage_table" "test_table" {
+		bucket_id = "${keboola_storage_bucket.test_bucket.id}"
+		name = "users"
+		columns = [ "id", "email", "name" ]
+		primary_key = [ %s ]
+	}`, primaryKey)
+}
+
+func TestUnitStorageTable_PrimaryKey(t *testing.T) {
+	mock := newMockKeboolaAPI()
+	defer mock.Close()
+
+	const tableID = "in.c-test_bucket_name.users"
+	notRecreated := testCheckStorageTableNotRecreated(mock, tableID)
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    mock.providers(),
+		CheckDestroy: mock.testCheckDestroy("keboola_storage_table", "/v2/storage/tables/%s", "id"),
+		Steps: []resource.TestStep{
+			{
+				Config: mock.config(testStorageTableWithPrimaryKey(`"id"`)),
+				Check: resource.ComposeTestCheckFunc(
+					notRecreated,
+					resource.TestCheckResourceAttr("keboola_storage_table.test_table", "primary_key.#", "1"),
+					resource.TestCheckResourceAttr("keboola_storage_table.test_table", "primary_key.0", "id"),
+				),
+			},
+			{
+				PreConfig: func() {
+					mock.mutex.Lock()
+					defer mock.mutex.Unlock()
+
+					mock.tables[tableID].rows = [][]string{
+						{"1", "Ann", "Ann"},
+						{"2", "Ann", "Ann"},
+					}
+				},
+				Config: mock.config(testStorageTableWithPrimaryKey(`"email", "id"`)),
+				Check: resource.ComposeTestCheckFunc(
+					notRecreated,
+					resource.TestCheckResourceAttr("keboola_storage_table.test_table", "primary_key.#", "2"),
+					resource.TestCheckResourceAttr("keboola_storage_table.test_table", "primary_key.0", "email"),
+					resource.TestCheckResourceAttr("keboola_storage_table.test_table", "primary_key.1", "id"),
+				),
+			},
+			{
+				Config:      mock.config(testStorageTableWithPrimaryKey(`"name"`)),
+				ExpectError: regexp.MustCompile(`Primary key \(name\) contains duplicate values`),
+			},
+			{
+				Config: mock.config(testStorageTableWithPrimaryKey(`"email", "id"`)),
+				Check: resource.ComposeTestCheckFunc(
+					notRecreated,
+					resource.TestCheckResourceAttr("keboola_storage_table.test_table", "primary_key.#", "2"),
+					func(s *terraform.State) error {
+						mock.mutex.Lock()
+						defer mock.mutex.Unlock()
+
+						if primaryKey := strings.Join(mock.tables[tableID].PrimaryKey, ","); primaryKey != "email,id" {
+							return fmt.Errorf("Expected the previous primary key to be restored, got %s", primaryKey)
+						}
+
+						return nil
+					},
+				),
+			},
+			{
+				Config:      mock.config(testStorageTableWithPrimaryKey(`"username"`)),
+				ExpectError: regexp.MustCompile("primary_key column username is not one of the table's columns"),
+			},
+			{
+				Config: mock.config(testStorageTableWithPrimaryKey("")),
+				Check: resource.ComposeTestCheckFunc(
+					notRecreated,
+					resource.TestCheckResourceAttr("keboola_storage_table.test_table", "primary_key.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testStorageTableWithMetadata(metadata string) string {
+	return fmt.Sprintf(`
+	resource "keboola_storage_bucket" "test_bucket" {
+		name = "test_bucket_name"
+		stage = "in"
+		backend = "snowflake"
+	}
+
+	resource "keboola_storage_table" "test_table" {
+		bucket_id = "${keboola_storage_bucket.test_bucket.id}"
+		name = "users"
+		columns = [ "id", "name" ]
+		%s
+	}`, metadata)
+}
+
+func TestUnitStorageTable_Metadata(t *testing.T) {
+	mock := newMockKeboolaAPI()
+	defer mock.Close()
+
+	const tableID = "in.c-test_bucket_name.users"
+	notRecreated := testCheckStorageTableNotRecreated(mock, tableID)
+
+	tableMetadata := func() []*mockMetadata {
+		return mock.tables[tableID].Metadata
+	}
+
+	columnMetadata := func(column string) func() []*mockMetadata {
+		return func() []*mockMetadata {
+			return mock.tables[tableID].ColumnMetadata[column]
+		}
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    mock.providers(),
+		CheckDestroy: mock.testCheckDestroy("keboola_storage_table", "/v2/storage/tables/%s", "id"),
+		Steps: []resource.TestStep{
+			{
+				Config: mock.config(testStorageTableWithMetadata(`
+		metadata = {
+			"KBC.description" = "Registered users"
+		}
+
+		column_metadata {
+			column = "name"
+			metadata = {
+				"KBC.description" = "Full name"
+			}
+		}`)),
+				Check: resource.ComposeTestCheckFunc(
+					notRecreated,
+					resource.TestCheckResourceAttr("keboola_storage_table.test_table", "metadata.KBC.description", "Registered users"),
+					resource.TestCheckResourceAttr("keboola_storage_table.test_table", "column_metadata.#", "1"),
+					mock.testCheckMetadata(tableMetadata, map[string]string{
+						"terraform/KBC.description": "Registered users",
+					}),
+					mock.testCheckMetadata(columnMetadata("name"), map[string]string{
+						"terraform/KBC.description": "Full name",
+					}),
+				),
+			},
+			{
+				Config: mock.config(testStorageTableWithMetadata(`
+		column_metadata {
+			column = "id"
+			metadata = {
+				"KBC.description" = "User ID"
+			}
+		}`)),
+				Check: resource.ComposeTestCheckFunc(
+					notRecreated,
+					resource.TestCheckResourceAttr("keboola_storage_table.test_table", "metadata.%", "0"),
+					resource.TestCheckResourceAttr("keboola_storage_table.test_table", "column_metadata.#", "1"),
+					mock.testCheckMetadata(tableMetadata, map[string]string{}),
+					mock.testCheckMetadata(columnMetadata("name"), map[string]string{}),
+					mock.testCheckMetadata(columnMetadata("id"), map[string]string{
+						"terraform/KBC.description": "User ID",
+					}),
+				),
+			},
+			{
+				Config: mock.config(testStorageTableWithMetadata(`
+		column_metadata {
+			column = "email"
+			metadata = {
+				"KBC.description" = "E-mail address"
+			}
+		}`)),
+				ExpectError: regexp.MustCompile("column_metadata column email is not one of the table's columns"),
+			},
+		},
+	})
+}
+
+func testStorageTableTyped(columns string) string {
+	return fmt.Sprintf(`
+	resource "keboola_storage_bucket" "test_bucket" {
+		name = "test_bucket_name"
+		stage = "in"
+		backend = "snowflake"
+	}
+
+	resource "keboola_storage_table" "test_table" {
+		bucket_id = "${keboola_storage_bucket.test_bucket.id}"
+		name = "orders"
+		primary_key = [ "id" ]
+
+		column {
+			name = "id"
+			type = "INTEGER"
+			nullable = false
+		}
+		%s
+	}`, columns)
+}
+
+const testStorageTableTypedColumns = `
+		column {
+			name = "customer"
+			type = "varchar"
+			length = "255"
+		}
+
+		column {
+			name = "amount"
+			type = "NUMBER"
+			length = "38,2"
+			default = "0"
+		}`
+
+const testStorageTableTypedColumnsUpdated = `
+		column {
+			name = "customer"
+			type = "varchar"
+			length = "1024"
+		}
+
+		column {
+			name = "created"
+			type = "TIMESTAMP"
+		}
+
+		column {
+			name = "amount"
+			type = "NUMBER"
+			length = "38,2"
+			nullable = false
+		}`
+
+const testStorageTableTypedColumnsRetyped = `
+		column {
+			name = "customer"
+			type = "varchar"
+			length = "1024"
+		}
+
+		column {
+			name = "created"
+			type = "TIMESTAMP"
+		}
+
+		column {
+			name = "amount"
+			type = "FLOAT"
+		}`
+
+func TestUnitStorageTable_Typed(t *testing.T) {
+	mock := newMockKeboolaAPI()
+	defer mock.Close()
+
+	const tableID = "in.c-test_bucket_name.orders"
+	notRecreated := testCheckStorageTableNotRecreated(mock, tableID)
+	recreated := testCheckStorageTableNotRecreated(mock, tableID)
+
+	testCheckColumnType := func(column string, expected string) resource.TestCheckFunc {
+		return func(s *terraform.State) error {
+			mock.mutex.Lock()
+			defer mock.mutex.Unlock()
+
+			table := mock.tables[tableID]
+
+			if table == nil || !table.IsTyped {
+				return fmt.Errorf("Expected typed table %s", tableID)
+			}
+
+			definition := table.Definition.column(column)
+
+			if definition == nil {
+				return fmt.Errorf("Column %s not found in table %s", column, tableID)
+			}
+
+			actual := definition.Definition.Type
+			if definition.Definition.Length != nil {
+				actual = fmt.Sprintf("%s(%s)", actual, *definition.Definition.Length)
+			}
+
+			if !definition.Definition.Nullable {
+				actual += " NOT NULL"
+			}
+
+			if actual != expected {
+				return fmt.Errorf("Expected column %s to be %s, got %s", column, expected, actual)
+			}
+
+			return nil
+		}
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    mock.providers(),
+		CheckDestroy: mock.testCheckDestroy("keboola_storage_table", "/v2/storage/tables/%s", "id"),
+		Steps: []resource.TestStep{
+			{
+				Config: mock.config(testStorageTableTyped(`
+		columns = [ "id" ]`)),
+				ExpectError: regexp.MustCompile(`"column": conflicts with columns`),
+			},
+			{
+				Config: mock.config(testStorageTableTyped(testStorageTableTypedColumns)),
+				Check: resource.ComposeTestCheckFunc(
+					notRecreated,
+					resource.TestCheckResourceAttr("keboola_storage_table.test_table", "columns.#", "3"),
+					resource.TestCheckResourceAttr("keboola_storage_table.test_table", "column.#", "3"),
+					resource.TestCheckResourceAttr("keboola_storage_table.test_table", "column.1.type", "VARCHAR"),
+					resource.TestCheckResourceAttr("keboola_storage_table.test_table", "column.2.default", "0"),
+					testCheckColumnType("id", "NUMBER(38,0) NOT NULL"),
+					testCheckColumnType("customer", "VARCHAR(255)"),
+					testCheckColumnType("amount", "NUMBER(38,2)"),
+				),
+			},
+			{
+				Config:                  mock.config(testStorageTableTyped(testStorageTableTypedColumns)),
+				ResourceName:            "keboola_storage_table.test_table",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"allow_column_drop", "incremental"},
+			},
+			{
+				Config: mock.config(testStorageTableTyped(testStorageTableTypedColumnsUpdated)),
+				Check: resource.ComposeTestCheckFunc(
+					notRecreated,
+					resource.TestCheckResourceAttr("keboola_storage_table.test_table", "columns.#", "4"),
+					resource.TestCheckResourceAttr("keboola_storage_table.test_table", "column.2.name", "created"),
+					resource.TestCheckResourceAttr("keboola_storage_table.test_table", "column.3.default", ""),
+					testCheckColumnType("customer", "VARCHAR(1024)"),
+					testCheckColumnType("created", "TIMESTAMP"),
+					testCheckColumnType("amount", "NUMBER(38,2) NOT NULL"),
+				),
+			},
+			{
+				PreConfig: func() {
+					mock.mutex.Lock()
+					defer mock.mutex.Unlock()
+
+					mock.tables[tableID].Definition.column("customer").Definition.Type = "BOOLEAN"
+				},
+				Config:             mock.config(testStorageTableTyped(testStorageTableTypedColumnsUpdated)),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: mock.config(testStorageTableTyped(testStorageTableTypedColumnsUpdated)),
+				Check: resource.ComposeTestCheckFunc(
+					recreated,
+					testCheckColumnType("customer", "VARCHAR(1024)"),
+				),
+			},
+			{
+				Config: mock.config(testStorageTableTyped(testStorageTableTypedColumnsRetyped)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("keboola_storage_table.test_table", "column.3.type", "FLOAT"),
+					testCheckColumnType("amount", "FLOAT"),
+					func(s *terraform.State) error {
+						if recreated(s) == nil {
+							return fmt.Errorf("Expected table %s to be recreated with the new column type", tableID)
+						}
+
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestUnitStorageTable_TypedCreatedSynchronously(t *testing.T) {
+	mock := newMockKeboolaAPI()
+	defer mock.Close()
+
+	mock.synchronousStorageJobs = true
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    mock.providers(),
+		CheckDestroy: mock.testCheckDestroy("keboola_storage_table", "/v2/storage/tables/%s", "id"),
+		Steps: []resource.TestStep{
+			{
+				Config: mock.config(testStorageTableTyped(testStorageTableTypedColumns)),
+				Check: resource.ComposeTestCheckFunc(
+					mock.testCheckExists("keboola_storage_table.test_table", "/v2/storage/tables/%s", "id"),
+					resource.TestCheckResourceAttr("keboola_storage_table.test_table", "id", "in.c-test_bucket_name.orders"),
+					resource.TestCheckResourceAttr("keboola_storage_table.test_table", "column.#", "3"),
+				),
+			},
+		},
+	})
+}
+
+const testStorageTableTypedAliases = `
+		column {
+			name = "customer"
+			type = "string"
+		}
+
+		column {
+			name = "amount"
+			type = "decimal"
+		}
+
+		column {
+			name = "rate"
+			type = "double"
+		}`
+
+const testStorageTableTypedAliasesSwapped = `
+		column {
+			name = "customer"
+			type = "text"
+		}
+
+		column {
+			name = "amount"
+			type = "NUMBER"
+			length = "38, 0"
+		}
+
+		column {
+			name = "rate"
+			type = "float"
+		}`
+
+const testStorageTableTypedAliasesResized = `
+		column {
+			name = "customer"
+			type = "string"
+			length = "255"
+		}
+
+		column {
+			name = "amount"
+			type = "decimal"
+		}
+
+		column {
+			name = "rate"
+			type = "double"
+		}`
+
+func TestUnitStorageTable_TypedNormalized(t *testing.T) {
+	mock := newMockKeboolaAPI()
+	defer mock.Close()
+
+	const tableID = "in.c-test_bucket_name.orders"
+	notRecreated := testCheckStorageTableNotRecreated(mock, tableID)
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    mock.providers(),
+		CheckDestroy: mock.testCheckDestroy("keboola_storage_table", "/v2/storage/tables/%s", "id"),
+		Steps: []resource.TestStep{
+			{
+				Config: mock.config(testStorageTableTyped(testStorageTableTypedAliases)),
+				Check: resource.ComposeTestCheckFunc(
+					notRecreated,
+					resource.TestCheckResourceAttr("keboola_storage_table.test_table", "column.0.type", "NUMBER"),
+					resource.TestCheckResourceAttr("keboola_storage_table.test_table", "column.0.length", "38,0"),
+					resource.TestCheckResourceAttr("keboola_storage_table.test_table", "column.1.type", "VARCHAR"),
+					resource.TestCheckResourceAttr("keboola_storage_table.test_table", "column.1.length", "16777216"),
+					resource.TestCheckResourceAttr("keboola_storage_table.test_table", "column.2.type", "NUMBER"),
+					resource.TestCheckResourceAttr("keboola_storage_table.test_table", "column.2.length", "38,0"),
+					resource.TestCheckResourceAttr("keboola_storage_table.test_table", "column.3.type", "FLOAT"),
+					resource.TestCheckResourceAttr("keboola_storage_table.test_table", "column.3.length", ""),
+				),
+			},
+			{
+				Config:   mock.config(testStorageTableTyped(testStorageTableTypedAliases)),
+				PlanOnly: true,
+			},
+			{
+				Config:                  mock.config(testStorageTableTyped(testStorageTableTypedAliases)),
+				ResourceName:            "keboola_storage_table.test_table",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"allow_column_drop", "incremental"},
+			},
+			{
+				Config:   mock.config(testStorageTableTyped(testStorageTableTypedAliasesSwapped)),
+				PlanOnly: true,
+			},
+			{
+				Config: mock.config(testStorageTableTyped(testStorageTableTypedAliasesResized)),
+				Check: resource.ComposeTestCheckFunc(
+					notRecreated,
+					resource.TestCheckResourceAttr("keboola_storage_table.test_table", "column.1.type", "VARCHAR"),
+					resource.TestCheckResourceAttr("keboola_storage_table.test_table", "column.1.length", "255"),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitStorageTable_TypedReplacesUntyped(t *testing.T) {
+	mock := newMockKeboolaAPI()
+	defer mock.Close()
+
+	const tableID = "in.c-test_bucket_name.orders"
+
+	testCheckTyped := func(expected bool) resource.TestCheckFunc {
+		return func(s *terraform.State) error {
+			mock.mutex.Lock()
+			defer mock.mutex.Unlock()
+
+			if table := mock.tables[tableID]; table == nil || table.IsTyped != expected {
+				return fmt.Errorf("Expected table %s to be typed: %t", tableID, expected)
+			}
+
+			return nil
+		}
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    mock.providers(),
+		CheckDestroy: mock.testCheckDestroy("keboola_storage_table", "/v2/storage/tables/%s", "id"),
+		Steps: []resource.TestStep{
+			{
+				Config: mock.config(`
+	resource "keboola_storage_bucket" "test_bucket" {
+		name = "test_bucket_name"
+		stage = "in"
+		backend = "snowflake"
+	}
+
+	resource "keboola_storage_table" "test_table" {
+		bucket_id = "${keboola_storage_bucket.test_bucket.id}"
+		name = "orders"
+		primary_key = [ "id" ]
+		columns = [ "id" ]
+	}`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("keboola_storage_table.test_table", "column.#", "0"),
+					testCheckTyped(false),
+				),
+			},
+			{
+				Config: mock.config(testStorageTableTyped("")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("keboola_storage_table.test_table", "column.#", "1"),
+					resource.TestCheckResourceAttr("keboola_storage_table.test_table", "columns.#", "1"),
+					testCheckTyped(true),
+				),
+			},
+		},
+	})
+}
+
+func testStorageTableWithSourceFile(sourceFile string, incremental bool) string {
+	return fmt.Sprintf(`
+	resource "keboola_storage_bucket" "test_bucket" {
+		name = "test_bucket_name"
+		stage = "in"
+		backend = "snowflake"
+	}
+
+	resource "keboola_storage_table" "test_table" {
+		bucket_id = "${keboola_storage_bucket.test_bucket.id}"
+		name = "countries"
+		primary_key = [ "code" ]
+		source_file = %q
+		incremental = %t
+		deletion_protection = false
+	}`, sourceFile, incremental)
+}
+
+func TestUnitStorageTable_SourceFile(t *testing.T) {
+	mock := newMockKeboolaAPI()
+	defer mock.Close()
+
+	const tableID = "in.c-test_bucket_name.countries"
+	notRecreated := testCheckStorageTableNotRecreated(mock, tableID)
+
+	sourceFile, err := ioutil.TempFile("", "countries-*.csv")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sourceFile.Close()
+	defer os.Remove(sourceFile.Name())
+
+	writeSourceFile := func(content string) func() {
+		return func() {
+			if err := ioutil.WriteFile(sourceFile.Name(), []byte(content), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	testCheckRows := func(expected ...[]string) resource.TestCheckFunc {
+		return func(s *terraform.State) error {
+			mock.mutex.Lock()
+			defer mock.mutex.Unlock()
+
+			table := mock.tables[tableID]
+
+			if table == nil {
+				return fmt.Errorf("Storage table %s not found", tableID)
+			}
+
+			var actual [][]string
+			for _, row := range table.rows {
+				values := make(map[string]string)
+				for index, column := range table.Columns {
+					values[column] = row[index]
+				}
+
+				actual = append(actual, []string{values["code"], values["name"]})
+			}
+
+			if !reflect.DeepEqual(actual, expected) {
+				return fmt.Errorf("Expected rows %v, got %v", expected, actual)
+			}
+
+			return nil
+		}
+	}
+
+	writeSourceFile("code,name\nCZ,Czechia\nSK,Slovakia\n")()
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    mock.providers(),
+		CheckDestroy: mock.testCheckDestroy("keboola_storage_table", "/v2/storage/tables/%s", "id"),
+		Steps: []resource.TestStep{
+			{
+				Config: mock.config(testStorageTableWithSourceFile(sourceFile.Name(), false)),
+				Check: resource.ComposeTestCheckFunc(
+					notRecreated,
+					resource.TestCheckResourceAttr("keboola_storage_table.test_table", "columns.#", "2"),
+					resource.TestCheckResourceAttrSet("keboola_storage_table.test_table", "source_file_hash"),
+					testCheckRows([]string{"CZ", "Czechia"}, []string{"SK", "Slovakia"}),
+				),
+			},
+			{
+				PreConfig: writeSourceFile("code,name\nCZ,Czech Republic\nAT,Austria\n"),
+				Config:    mock.config(testStorageTableWithSourceFile(sourceFile.Name(), false)),
+				Check: resource.ComposeTestCheckFunc(
+					notRecreated,
+					testCheckRows([]string{"CZ", "Czech Republic"}, []string{"AT", "Austria"}),
+				),
+			},
+			{
+				PreConfig: writeSourceFile("code,name\nCZ,Czechia\nPL,Poland\n"),
+				Config:    mock.config(testStorageTableWithSourceFile(sourceFile.Name(), true)),
+				Check: resource.ComposeTestCheckFunc(
+					notRecreated,
+					testCheckRows([]string{"CZ", "Czechia"}, []string{"AT", "Austria"}, []string{"PL", "Poland"}),
+				),
+			},
+			{
+				Config: mock.config(testStorageTableWithSourceFile(sourceFile.Name(), false)),
+				Check: resource.ComposeTestCheckFunc(
+					notRecreated,
+					testCheckRows([]string{"CZ", "Czechia"}, []string{"AT", "Austria"}, []string{"PL", "Poland"}),
+				),
+			},
+			{
+				PreConfig:   writeSourceFile("code,name,capital\nCZ,Czechia,Prague\n"),
+				Config:      mock.config(testStorageTableWithSourceFile(sourceFile.Name(), false)),
+				ExpectError: regexp.MustCompile("Column capital is not a column"),
+			},
+			{
+				Config:             mock.config(testStorageTableWithSourceFile(sourceFile.Name(), false)),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				PreConfig: writeSourceFile("code,name\nCZ,Czechia\n"),
+				Config:    mock.config(testStorageTableWithSourceFile(sourceFile.Name(), false)),
+				Check: resource.ComposeTestCheckFunc(
+					notRecreated,
+					testCheckRows([]string{"CZ", "Czechia"}),
+				),
+			},
+			{
+				Config:      mock.config(testStorageTableWithSourceFile(sourceFile.Name()+".missing", false)),
+				ExpectError: regexp.MustCompile("no such file or directory"),
+			},
+		},
+	})
+}
+
+func testStorageTableWithDeletionProtection(name string, deletionProtection string) string {
+	return fmt.Sprintf(`
+	resource "keboola_storage_bucket" "test_bucket" {
+		name = "test_bucket_name"
+		stage = "in"
+		backend = "snowflake"
+	}
+
+	resource "keboola_storage_table" "test_table" {
+		bucket_id = "${keboola_storage_bucket.test_bucket.id}"
+		name = "%s"
+		columns = [ "id", "name" ]
+		%s
+	}`, name, deletionProtection)
+}
+
+func TestUnitStorageTable_DeletionProtection(t *testing.T) {
+	mock := newMockKeboolaAPI()
+	defer mock.Close()
+
+	const tableID = "in.c-test_bucket_name.customers"
+
+	insertRows := func() {
+		mock.mutex.Lock()
+		defer mock.mutex.Unlock()
+
+		mock.tables[tableID].RowsCount = 3
+	}
+
+	testCheckTableDeleted := func(s *terraform.State) error {
+		mock.mutex.Lock()
+		defer mock.mutex.Unlock()
+
+		if _, ok := mock.tables[tableID]; ok {
+			return fmt.Errorf("Storage table %s has not been deleted", tableID)
+		}
+
+		return nil
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    mock.providers(),
+		CheckDestroy: mock.testCheckDestroy("keboola_storage_table", "/v2/storage/tables/%s", "id"),
+		Steps: []resource.TestStep{
+			{
+				Config: mock.config(testStorageTableWithDeletionProtection("customers", "")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("keboola_storage_table.test_table", "deletion_protection", "true"),
+				),
+			},
+			{
+				Config:                  mock.config(testStorageTableWithDeletionProtection("customers", "")),
+				ResourceName:            "keboola_storage_table.test_table",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"allow_column_drop", "incremental"},
+			},
+			{
+				PreConfig:   insertRows,
+				Config:      mock.config(testStorageTableWithDeletionProtection("all_customers", "")),
+				ExpectError: regexp.MustCompile("table in.c-test_bucket_name.customers has 3 rows, set deletion_protection = false"),
+			},
+			{
+				Config: mock.config(testStorageTableWithDeletionProtection("customers", "deletion_protection = false")),
+				Check: resource.ComposeTestCheckFunc(
+					mock.testCheckExists("keboola_storage_table.test_table", "/v2/storage/tables/%s", "id"),
+					resource.TestCheckResourceAttr("keboola_storage_table.test_table", "deletion_protection", "false"),
+				),
+			},
+			{
+				Config: mock.config(testStorageTableWithDeletionProtection("all_customers", "deletion_protection = false")),
+				Check: resource.ComposeTestCheckFunc(
+					mock.testCheckExists("keboola_storage_table.test_table", "/v2/storage/tables/%s", "id"),
+					testCheckTableDeleted,
+					resource.TestCheckResourceAttr("keboola_storage_table.test_table", "id", "in.c-test_bucket_name.all_customers"),
+				),
+			},
+		},
+	})
+}
@@ -2,7 +2,6 @@ package keboola
 
 import (
 	"encoding/json"
-	"fmt"
 	"log"
 	"net/url"
 
@@ -66,12 +65,15 @@ type Transformation struct {
 
 func resourceKeboolaTransformation() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceKeboolaTransformCreate,
-		Read:   resourceKeboolaTransformRead,
-		Update: resourceKeboolaTransformUpdate,
-		Delete: resourceKeboolaTransformDelete,
+		Create:        resourceKeboolaTransformCreate,
+		Read:          resourceKeboolaTransformRead,
+		Update:        resourceKeboolaTransformUpdate,
+		Delete:        resourceKeboolaTransformDelete,
+		CustomizeDiff: customizeBranchIDDiff,
 
 		Schema: map[string]*schema.Schema{
+			"branch_id":               &branchIDSchema,
+			"branch_id_from_provider": &branchIDFromProviderSchema,
 			"bucket_id": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -146,7 +148,7 @@ func resourceKeboolaTransformCreate(d *schema.ResourceData, meta interface{}) er
 	createTransformBuffer := buffer.FromForm(createTransformForm)
 
 	client := meta.(*KBCClient)
-	createResponse, err := client.PostToStorage(fmt.Sprintf("storage/components/transformation/configs/%s/rows", bucketID), createTransformBuffer)
+	createResponse, err := client.PostToStorage(componentsURI(d, client, "transformation/configs/%s/rows", bucketID), createTransformBuffer)
 
 	if hasErrors(err, createResponse) {
 		return extractError(err, createResponse)
@@ -170,15 +172,17 @@ func resourceKeboolaTransformRead(d *schema.ResourceData, meta interface{}) erro
 	log.Println("[INFO] Reading Transformations from Keboola.")
 
 	client := meta.(*KBCClient)
-	getResponse, err := client.GetFromStorage(fmt.Sprintf("storage/components/transformation/configs/%s/rows", d.Get("bucket_id")))
+	getResponse, err := client.GetFromStorage(componentsURI(d, client, "transformation/configs/%s/rows", d.Get("bucket_id")))
 
 	if hasErrors(err, getResponse) {
-		if getResponse.StatusCode == 404 {
+		err = extractError(err, getResponse)
+
+		if isNotFoundError(err) {
 			d.SetId("")
 			return nil
 		}
 
-		return extractError(err, getResponse)
+		return err
 	}
 
 	var transformation []Transformation
@@ -208,6 +212,8 @@ func resourceKeboolaTransformRead(d *schema.ResourceData, meta interface{}) erro
 		}
 	}
 
+	setBranchID(d, client)
+
 	return nil
 }
 
@@ -244,7 +250,7 @@ func resourceKeboolaTransformUpdate(d *schema.ResourceData, meta interface{}) er
 	updateTransformBuffer := buffer.FromForm(updateTransformForm)
 
 	client := meta.(*KBCClient)
-	updateResponse, err := client.PutToStorage(fmt.Sprintf("storage/components/transformation/configs/%s/rows/%s", bucketID, d.Id()), updateTransformBuffer)
+	updateResponse, err := client.PutToStorage(componentsURI(d, client, "transformation/configs/%s/rows/%s", bucketID, d.Id()), updateTransformBuffer)
 
 	if hasErrors(err, updateResponse) {
 		return extractError(err, updateResponse)
@@ -259,7 +265,7 @@ func resourceKeboolaTransformDelete(d *schema.ResourceData, meta interface{}) er
 	bucketID := d.Get("bucket_id").(string)
 
 	client := meta.(*KBCClient)
-	destroyResponse, err := client.DeleteFromStorage(fmt.Sprintf("storage/components/transformation/configs/%s/rows/%s", bucketID, d.Id()))
+	destroyResponse, err := client.DeleteFromStorage(componentsURI(d, client, "transformation/configs/%s/rows/%s", bucketID, d.Id()))
 
 	if hasErrors(err, destroyResponse) {
 		return extractError(err, destroyResponse)
@@ -2,7 +2,6 @@ package keboola
 
 import (
 	"encoding/json"
-	"fmt"
 	"log"
 	"net/url"
 
@@ -31,8 +30,11 @@ func resourceKeboolaTransformationBucket() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: customizeBranchIDDiff,
 
 		Schema: map[string]*schema.Schema{
+			"branch_id":               &branchIDSchema,
+			"branch_id_from_provider": &branchIDFromProviderSchema,
 			"name": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -55,7 +57,7 @@ func resourceKeboolaTransformBucketCreate(d *schema.ResourceData, meta interface
 	createBucketBuffer := buffer.FromForm(createBucketForm)
 
 	client := meta.(*KBCClient)
-	createResponse, err := client.PostToStorage("storage/components/transformation/configs", createBucketBuffer)
+	createResponse, err := client.PostToStorage(componentsURI(d, client, "transformation/configs"), createBucketBuffer)
 
 	if hasErrors(err, createResponse) {
 		return extractError(err, createResponse)
@@ -79,19 +81,21 @@ func resourceKeboolaTransformBucketRead(d *schema.ResourceData, meta interface{}
 	log.Println("[INFO] Reading Transformation Buckets from Keboola.")
 
 	client := meta.(*KBCClient)
-	getResponse, err := client.GetFromStorage(fmt.Sprintf("storage/components/transformation/configs/%s", d.Id()))
+	getResponse, err := client.GetFromStorage(componentsURI(d, client, "transformation/configs/%s", d.Id()))
 
 	if d.Id() == "" {
 		return nil
 	}
 
 	if hasErrors(err, getResponse) {
-		if getResponse.StatusCode == 404 {
+		err = extractError(err, getResponse)
+
+		if isNotFoundError(err) {
 			d.SetId("")
 			return nil
 		}
 
-		return extractError(err, getResponse)
+		return err
 	}
 
 	var transformBucket TransformationBucket
@@ -107,6 +111,8 @@ func resourceKeboolaTransformBucketRead(d *schema.ResourceData, meta interface{}
 	d.Set("name", transformBucket.Name)
 	d.Set("description", transformBucket.Description)
 
+	setBranchID(d, client)
+
 	return nil
 }
 
@@ -120,7 +126,7 @@ func resourceKeboolaTransformBucketUpdate(d *schema.ResourceData, meta interface
 	updateBucketBuffer := buffer.FromForm(updateBucketForm)
 
 	client := meta.(*KBCClient)
-	updateResponse, err := client.PutToStorage(fmt.Sprintf("storage/components/transformation/configs/%s", d.Id()), updateBucketBuffer)
+	updateResponse, err := client.PutToStorage(componentsURI(d, client, "transformation/configs/%s", d.Id()), updateBucketBuffer)
 
 	if hasErrors(err, updateResponse) {
 		return extractError(err, updateResponse)
@@ -133,7 +139,7 @@ func resourceKeboolaTransformBucketDelete(d *schema.ResourceData, meta interface
 	log.Printf("[INFO] Deleting Transformation Bucket in Keboola: %s", d.Id())
 
 	client := meta.(*KBCClient)
-	destroyResponse, err := client.DeleteFromStorage(fmt.Sprintf("storage/components/transformation/configs/%s", d.Id()))
+	destroyResponse, err := client.DeleteFromStorage(componentsURI(d, client, "transformation/configs/%s", d.Id()))
 
 	if hasErrors(err, destroyResponse) {
 		return extractError(err, destroyResponse)
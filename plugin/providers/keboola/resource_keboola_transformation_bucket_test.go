@@ -130,3 +130,39 @@ resource "keboola_transformation_bucket" "test_bucket" {
 	name = "new test name"
 	description = "new test description"
 }`
+
+func TestUnitTransformationBucket_Basic(t *testing.T) {
+	mock := newMockKeboolaAPI()
+	defer mock.Close()
+
+	const bucketPath = "/v2/storage/components/transformation/configs/%s"
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    mock.providers(),
+		CheckDestroy: mock.testCheckDestroy("keboola_transformation_bucket", bucketPath, "id"),
+		Steps: []resource.TestStep{
+			{
+				Config: mock.config(testTransformationBucketBasic),
+				Check: resource.ComposeTestCheckFunc(
+					mock.testCheckExists("keboola_transformation_bucket.test_bucket", bucketPath, "id"),
+					resource.TestCheckResourceAttr("keboola_transformation_bucket.test_bucket", "name", "test name"),
+					resource.TestCheckResourceAttr("keboola_transformation_bucket.test_bucket", "description", "test description"),
+				),
+			},
+			{
+				Config: mock.config(testTransformationBucketUpdate),
+				Check: resource.ComposeTestCheckFunc(
+					mock.testCheckExists("keboola_transformation_bucket.test_bucket", bucketPath, "id"),
+					resource.TestCheckResourceAttr("keboola_transformation_bucket.test_bucket", "name", "new test name"),
+					resource.TestCheckResourceAttr("keboola_transformation_bucket.test_bucket", "description", "new test description"),
+				),
+			},
+			{
+				Config:            mock.config(testTransformationBucketUpdate),
+				ResourceName:      "keboola_transformation_bucket.test_bucket",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -62,3 +62,61 @@ const testTransformBasic = `
 		type = "simple"
 		backend = "snowflake"
 	}`
+
+func TestUnitTransformation_Basic(t *testing.T) {
+	mock := newMockKeboolaAPI()
+	defer mock.Close()
+
+	const transformationPath = "/v2/storage/components/transformation/configs/%s/rows/%s"
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    mock.providers(),
+		CheckDestroy: mock.testCheckDestroy("keboola_transformation_bucket", "/v2/storage/components/transformation/configs/%s", "id"),
+		Steps: []resource.TestStep{
+			{
+				Config: mock.config(testTransformBasic),
+				Check: resource.ComposeTestCheckFunc(
+					mock.testCheckExists("keboola_transformation.test_transform", transformationPath, "bucket_id", "id"),
+					resource.TestCheckResourceAttr("keboola_transformation.test_transform", "name", "test name"),
+					resource.TestCheckResourceAttr("keboola_transformation.test_transform", "description", "test description"),
+					resource.TestCheckResourceAttr("keboola_transformation.test_transform", "type", "simple"),
+					resource.TestCheckResourceAttr("keboola_transformation.test_transform", "backend", "snowflake"),
+				),
+			},
+			{
+				Config: mock.config(testTransformUpdate),
+				Check: resource.ComposeTestCheckFunc(
+					mock.testCheckExists("keboola_transformation.test_transform", transformationPath, "bucket_id", "id"),
+					resource.TestCheckResourceAttr("keboola_transformation.test_transform", "description", "updated test description"),
+					resource.TestCheckResourceAttr("keboola_transformation.test_transform", "queries.#", "1"),
+					resource.TestCheckResourceAttr("keboola_transformation.test_transform", "input.0.source", "in.c-source.table"),
+					resource.TestCheckResourceAttr("keboola_transformation.test_transform", "output.0.destination", "out.c-destination.table"),
+				),
+			},
+		},
+	})
+}
+
+const testTransformUpdate = `
+	resource "keboola_transformation_bucket" "test_bucket" {
+		name = "test name"
+	}
+
+	resource "keboola_transformation" "test_transform" {
+		bucket_id = "${keboola_transformation_bucket.test_bucket.id}"
+		name = "test name"
+		description = "updated test description"
+		type = "simple"
+		backend = "snowflake"
+		queries = [ "CREATE TABLE \"destination\" AS SELECT * FROM \"source\";" ]
+
+		input {
+			source = "in.c-source.table"
+			destination = "source"
+		}
+
+		output {
+			source = "destination"
+			destination = "out.c-destination.table"
+		}
+	}`
@@ -0,0 +1,279 @@
+package keboola
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/paybyphone/terraform-provider-keboola/plugin/providers/keboola/buffer"
+)
+
+//region Keboola API Contracts
+
+//Workspace is the data model for a workspace (a Snowflake or Redshift schema, with its
+//own credentials, into which tables can be loaded) within the Keboola Storage API.
+type Workspace struct {
+	ID         KBCNumberString     `json:"id,omitempty"`
+	Connection WorkspaceConnection `json:"connection"`
+}
+
+//WorkspaceConnection holds the credentials of a workspace. The password is only returned
+//when the workspace is created, or when its password is reset.
+type WorkspaceConnection struct {
+	Backend   string `json:"backend"`
+	Host      string `json:"host"`
+	Database  string `json:"database"`
+	Schema    string `json:"schema"`
+	Warehouse string `json:"warehouse,omitempty"`
+	User      string `json:"user"`
+	Password  string `json:"password,omitempty"`
+}
+
+//WorkspaceLoad is the request to load tables into a workspace.
+type WorkspaceLoad struct {
+	Input    []Input `json:"input"`
+	Preserve bool    `json:"preserve"`
+}
+
+//endregion
+
+func resourceKeboolaWorkspace() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceKeboolaWorkspaceCreate,
+		Read:   resourceKeboolaWorkspaceRead,
+		Update: resourceKeboolaWorkspaceUpdate,
+		Delete: resourceKeboolaWorkspaceDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultJobTimeout),
+			Update: schema.DefaultTimeout(defaultJobTimeout),
+			Delete: schema.DefaultTimeout(defaultJobTimeout),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"backend": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "snowflake",
+				ValidateFunc: validateWorkspaceBackend,
+			},
+			"input": &inputSchema,
+			"password_reset_trigger": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "An arbitrary value which resets the password of the workspace in place whenever it changes. Tainting the workspace instead recreates it, with new credentials but an empty schema.",
+			},
+			"host": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"database": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"schema": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"warehouse": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"user": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"password": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+		},
+	}
+}
+
+func resourceKeboolaWorkspaceCreate(d *schema.ResourceData, meta interface{}) error {
+	log.Println("[INFO] Creating Workspace in Keboola.")
+
+	createWorkspaceJSON, err := json.Marshal(map[string]string{"backend": d.Get("backend").(string)})
+
+	if err != nil {
+		return err
+	}
+
+	client := meta.(*KBCClient)
+	createResponse, err := client.PostJSONToStorage("storage/workspaces", bytes.NewBuffer(createWorkspaceJSON))
+
+	if hasErrors(err, createResponse) {
+		return extractError(err, createResponse)
+	}
+
+	var workspace Workspace
+
+	decoder := json.NewDecoder(createResponse.Body)
+	err = decoder.Decode(&workspace)
+
+	if err != nil {
+		return err
+	}
+
+	d.SetId(string(workspace.ID))
+	d.Set("password", workspace.Connection.Password)
+
+	if len(d.Get("input").([]interface{})) > 0 {
+		err = loadWorkspaceTables(d, client, schema.TimeoutCreate)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return resourceKeboolaWorkspaceRead(d, meta)
+}
+
+//loadWorkspaceTables loads the tables of the input mapping into a workspace, replacing any tables loaded before.
+func loadWorkspaceTables(d *schema.ResourceData, client *KBCClient, timeout string) error {
+	log.Printf("[INFO] Loading tables into Workspace %s", d.Id())
+
+	loadJSON, err := json.Marshal(WorkspaceLoad{
+		Input: mapInputSchemaToModel(d.Get("input").([]interface{})),
+	})
+
+	if err != nil {
+		return err
+	}
+
+	loadResponse, err := client.PostJSONToStorage(fmt.Sprintf("storage/workspaces/%s/load", d.Id()), bytes.NewBuffer(loadJSON))
+
+	if hasErrors(err, loadResponse) {
+		return extractError(err, loadResponse)
+	}
+
+	_, err = client.waitForAcceptedStorageJob(loadResponse, d.Timeout(timeout))
+
+	return err
+}
+
+func resourceKeboolaWorkspaceRead(d *schema.ResourceData, meta interface{}) error {
+	log.Println("[INFO] Reading Workspace from Keboola.")
+
+	if d.Id() == "" {
+		return nil
+	}
+
+	client := meta.(*KBCClient)
+	getResponse, err := client.GetFromStorage(fmt.Sprintf("storage/workspaces/%s", d.Id()))
+
+	if hasErrors(err, getResponse) {
+		err = extractError(err, getResponse)
+
+		if isNotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+
+		return err
+	}
+
+	var workspace Workspace
+
+	decoder := json.NewDecoder(getResponse.Body)
+	err = decoder.Decode(&workspace)
+
+	if err != nil {
+		return err
+	}
+
+	d.Set("backend", workspace.Connection.Backend)
+	d.Set("host", workspace.Connection.Host)
+	d.Set("database", workspace.Connection.Database)
+	d.Set("schema", workspace.Connection.Schema)
+	d.Set("warehouse", workspace.Connection.Warehouse)
+	d.Set("user", workspace.Connection.User)
+
+	return nil
+}
+
+//resourceKeboolaWorkspaceUpdate resets the password of a workspace when password_reset_trigger
+//changes, and reloads its tables when the input mapping changes.
+func resourceKeboolaWorkspaceUpdate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[INFO] Updating Workspace in Keboola: %s", d.Id())
+
+	client := meta.(*KBCClient)
+
+	//Only the changes which have been applied are saved if the update fails, so that the others are retried.
+	d.Partial(true)
+
+	if d.HasChange("password_reset_trigger") {
+		err := resetWorkspacePassword(d, client)
+
+		if err != nil {
+			return err
+		}
+
+		d.SetPartial("password_reset_trigger")
+		d.SetPartial("password")
+	}
+
+	if d.HasChange("input") {
+		err := loadWorkspaceTables(d, client, schema.TimeoutUpdate)
+
+		if err != nil {
+			return err
+		}
+
+		d.SetPartial("input")
+	}
+
+	d.Partial(false)
+
+	return resourceKeboolaWorkspaceRead(d, meta)
+}
+
+func resetWorkspacePassword(d *schema.ResourceData, client *KBCClient) error {
+	log.Printf("[INFO] Resetting password of Workspace %s", d.Id())
+
+	resetResponse, err := client.PostToStorage(fmt.Sprintf("storage/workspaces/%s/password", d.Id()), buffer.Empty())
+
+	if hasErrors(err, resetResponse) {
+		return extractError(err, resetResponse)
+	}
+
+	var connection WorkspaceConnection
+
+	decoder := json.NewDecoder(resetResponse.Body)
+	err = decoder.Decode(&connection)
+
+	if err != nil {
+		return err
+	}
+
+	d.Set("password", connection.Password)
+
+	return nil
+}
+
+func resourceKeboolaWorkspaceDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[INFO] Deleting Workspace in Keboola: %s", d.Id())
+
+	client := meta.(*KBCClient)
+	destroyResponse, err := client.DeleteFromStorage(fmt.Sprintf("storage/workspaces/%s?async=1", d.Id()))
+
+	if hasErrors(err, destroyResponse) {
+		return extractError(err, destroyResponse)
+	}
+
+	if _, err := client.waitForAcceptedStorageJob(destroyResponse, d.Timeout(schema.TimeoutDelete)); err != nil {
+		return err
+	}
+
+	d.SetId("")
+
+	return nil
+}
@@ -0,0 +1,198 @@
+package keboola
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccWorkspace_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testAccCheckWorkspaceDestroy,
+			testAccCheckStorageTableDestroy,
+			testAccCheckStorageBucketDestroy,
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: testWorkspace("customers", ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("keboola_workspace.test_workspace", "backend", "snowflake"),
+					resource.TestCheckResourceAttrSet("keboola_workspace.test_workspace", "host"),
+					resource.TestCheckResourceAttrSet("keboola_workspace.test_workspace", "user"),
+					resource.TestCheckResourceAttrSet("keboola_workspace.test_workspace", "password"),
+				),
+			},
+			{
+				Config: testWorkspace("all_customers", "1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("keboola_workspace.test_workspace", "input.0.destination", "all_customers"),
+					resource.TestCheckResourceAttrSet("keboola_workspace.test_workspace", "password"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckWorkspaceDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*KBCClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "keboola_workspace" {
+			continue
+		}
+
+		getResp, err := client.GetFromStorage(fmt.Sprintf("storage/workspaces/%s", rs.Primary.ID))
+
+		if err == nil && getResp.StatusCode == 200 {
+			return fmt.Errorf("Workspace still exists")
+		}
+	}
+
+	return nil
+}
+
+func testWorkspace(destination string, passwordResetTrigger string) string {
+	return testWorkspaceWithInput("${keboola_storage_table.test_table.id}", destination, passwordResetTrigger)
+}
+
+func testWorkspaceWithInput(source string, destination string, passwordResetTrigger string) string {
+	return fmt.Sprintf(`
+	resource "keboola_storage_bucket" "test_bucket" {
+		name = "workspace_bucket"
+		stage = "in"
+		backend = "snowflake"
+	}
+
+	resource "keboola_storage_table" "test_table" {
+		bucket_id = "${keboola_storage_bucket.test_bucket.id}"
+		name = "customers"
+		columns = [ "id", "name" ]
+		primary_key = [ "id" ]
+	}
+
+	resource "keboola_workspace" "test_workspace" {
+		password_reset_trigger = "%s"
+
+		input {
+			source = "%s"
+			destination = "%s"
+		}
+	}`, passwordResetTrigger, source, destination)
+}
+
+const testWorkspaceInvalidBackend = `
+resource "keboola_workspace" "test_workspace" {
+	backend = "bigquery"
+}`
+
+func TestUnitWorkspace_Basic(t *testing.T) {
+	mock := newMockKeboolaAPI()
+	defer mock.Close()
+
+	const workspacePath = "/v2/storage/workspaces/%s"
+
+	var workspaceID, password string
+
+	testCheckWorkspace := func(expectedTables map[string]string, passwordChanged bool) resource.TestCheckFunc {
+		return func(s *terraform.State) error {
+			mock.mutex.Lock()
+			defer mock.mutex.Unlock()
+
+			rs := s.RootModule().Resources["keboola_workspace.test_workspace"]
+			workspace, ok := mock.workspaces[rs.Primary.ID]
+
+			if !ok {
+				return fmt.Errorf("Workspace %s not found", rs.Primary.ID)
+			}
+
+			if workspaceID != "" && workspaceID != rs.Primary.ID {
+				return fmt.Errorf("Workspace was recreated: %s became %s", workspaceID, rs.Primary.ID)
+			}
+
+			if rs.Primary.Attributes["password"] != workspace.password {
+				return fmt.Errorf("Expected password %q, got %q", workspace.password, rs.Primary.Attributes["password"])
+			}
+
+			if passwordChanged == (password == workspace.password) {
+				return fmt.Errorf("Expected password changed to be %t", passwordChanged)
+			}
+
+			if fmt.Sprint(workspace.tables) != fmt.Sprint(expectedTables) {
+				return fmt.Errorf("Expected loaded tables %v, got %v", expectedTables, workspace.tables)
+			}
+
+			workspaceID = rs.Primary.ID
+			password = workspace.password
+
+			return nil
+		}
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    mock.providers(),
+		CheckDestroy: mock.testCheckDestroy("keboola_workspace", workspacePath, "id"),
+		Steps: []resource.TestStep{
+			{
+				Config:      mock.config(testWorkspaceInvalidBackend),
+				ExpectError: regexp.MustCompile(`"backend" must be set to one of`),
+			},
+			{
+				Config: mock.config(testWorkspace("customers", "")),
+				Check: resource.ComposeTestCheckFunc(
+					mock.testCheckExists("keboola_workspace.test_workspace", workspacePath, "id"),
+					resource.TestCheckResourceAttr("keboola_workspace.test_workspace", "backend", "snowflake"),
+					resource.TestCheckResourceAttr("keboola_workspace.test_workspace", "host", "mock.snowflakecomputing.com"),
+					resource.TestCheckResourceAttr("keboola_workspace.test_workspace", "warehouse", "KEBOOLA_PROD"),
+					resource.TestCheckResourceAttrSet("keboola_workspace.test_workspace", "schema"),
+					resource.TestCheckResourceAttrSet("keboola_workspace.test_workspace", "user"),
+					testCheckWorkspace(map[string]string{"customers": "in.c-workspace_bucket.customers"}, true),
+				),
+			},
+			{
+				Config: mock.config(testWorkspace("all_customers", "")),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckWorkspace(map[string]string{"all_customers": "in.c-workspace_bucket.customers"}, false),
+				),
+			},
+			{
+				Config: mock.config(testWorkspace("all_customers", "1")),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckWorkspace(map[string]string{"all_customers": "in.c-workspace_bucket.customers"}, true),
+				),
+			},
+			{
+				Config:      mock.config(testWorkspaceWithInput("in.c-workspace_bucket.missing", "all_customers", "2")),
+				ExpectError: regexp.MustCompile("Table in.c-workspace_bucket.missing not found"),
+			},
+			{
+				Config:             mock.config(testWorkspaceWithInput("in.c-workspace_bucket.missing", "all_customers", "2")),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config:   mock.config(testWorkspace("all_customers", "2")),
+				PlanOnly: true,
+			},
+			{
+				Config: mock.config(testWorkspace("all_customers", "2")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("keboola_workspace.test_workspace", "password_reset_trigger", "2"),
+					testCheckWorkspace(map[string]string{"all_customers": "in.c-workspace_bucket.customers"}, true),
+				),
+			},
+			{
+				Config:                  mock.config(testWorkspace("all_customers", "2")),
+				ResourceName:            "keboola_workspace.test_workspace",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"password", "password_reset_trigger", "input"},
+			},
+		},
+	})
+}
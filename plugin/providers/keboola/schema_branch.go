@@ -0,0 +1,88 @@
+package keboola
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+//branchIDSchema is the schema of the branch_id attribute shared by all resources that manage
+//component configurations. Leaving it empty uses the provider's branch_id, if there is one,
+//and the branch the resource ends up in is always written to the state.
+var branchIDSchema = schema.Schema{
+	Type:        schema.TypeString,
+	Optional:    true,
+	Computed:    true,
+	ForceNew:    true,
+	Description: "The ID of the development branch to manage the configuration in. Defaults to the provider's branch_id, or the default branch.",
+}
+
+//branchIDFromProviderSchema records whether branch_id was taken from the provider. The planned state of an unset
+//Optional+Computed attribute holds its previous value, so this cannot be told from the configuration at plan time.
+var branchIDFromProviderSchema = schema.Schema{
+	Type:        schema.TypeBool,
+	Computed:    true,
+	Description: "Whether branch_id was taken from the provider, in which case changing the provider's branch_id recreates the resource in the new branch.",
+}
+
+//branchID returns the development branch that a resource is managed in, or an
+//empty string if it is managed in the default (production) branch.
+func branchID(d *schema.ResourceData, client *KBCClient) string {
+	if resourceBranchID, ok := d.GetOk("branch_id"); ok {
+		return resourceBranchID.(string)
+	}
+
+	return client.BranchID
+}
+
+//setBranchID writes the branch a resource is managed in to the state, along with whether
+//it was taken from the provider (which is only known when the resource is created or imported).
+func setBranchID(d *schema.ResourceData, client *KBCClient) {
+	if _, ok := d.GetOkExists("branch_id_from_provider"); !ok {
+		d.Set("branch_id_from_provider", d.Get("branch_id").(string) == "")
+	}
+
+	d.Set("branch_id", branchID(d, client))
+}
+
+//customizeBranchIDDiff recreates a resource which takes its branch_id from the provider
+//when the provider's branch_id changes, as a configuration cannot be moved between branches.
+func customizeBranchIDDiff(d *schema.ResourceDiff, meta interface{}) error {
+	client := meta.(*KBCClient)
+
+	if !d.Get("branch_id_from_provider").(bool) || d.Get("branch_id").(string) == client.BranchID {
+		return nil
+	}
+
+	//A replaced resource is diffed a second time without its state, when an unset branch_id can no longer
+	//be told from one set in the configuration, so the diff from the first time (below) is kept instead.
+	if d.Id() == "" {
+		if err := d.Clear("branch_id"); err != nil {
+			return err
+		}
+
+		return d.Clear("branch_id_from_provider")
+	}
+
+	//A branch_id newly set on the resource itself replaces it through the schema.
+	if d.HasChange("branch_id") {
+		return d.SetNew("branch_id_from_provider", false)
+	}
+
+	//Otherwise the provider's branch_id has changed, and the resource is replaced in the provider's new branch.
+	return d.SetNewComputed("branch_id")
+}
+
+//componentsURI builds the Storage API endpoint for a component configuration (e.g.
+//storage/components/{component}/configs/{id}), routing it to the development branch
+//the resource is managed in (storage/branch/{branch}/components/...) if there is one.
+func componentsURI(d *schema.ResourceData, client *KBCClient, format string, args ...interface{}) string {
+	path := fmt.Sprintf(format, args...)
+
+	if branch := branchID(d, client); branch != "" {
+		return fmt.Sprintf("storage/branch/%s/components/%s", branch, strings.TrimLeft(path, "/"))
+	}
+
+	return fmt.Sprintf("storage/components/%s", strings.TrimLeft(path, "/"))
+}
@@ -1,6 +1,8 @@
 package keboola
 
 import (
+	"encoding/json"
+	"reflect"
 	"strings"
 	"unicode"
 
@@ -21,3 +23,16 @@ func stripWhitespace(str string) string {
 func suppressEquivalentJSON(k, old, new string, d *schema.ResourceData) bool {
 	return stripWhitespace(old) == stripWhitespace(new)
 }
+
+//suppressSemanticallyEquivalentJSON suppresses diffs between JSON documents which only differ
+//in formatting or key order. Values which are not valid JSON fall back to a whitespace comparison.
+//noinspection GoUnusedParameter
+func suppressSemanticallyEquivalentJSON(k, old, new string, d *schema.ResourceData) bool {
+	var oldValue, newValue interface{}
+
+	if json.Unmarshal([]byte(old), &oldValue) != nil || json.Unmarshal([]byte(new), &newValue) != nil {
+		return suppressEquivalentJSON(k, old, new, d)
+	}
+
+	return reflect.DeepEqual(oldValue, newValue)
+}
@@ -0,0 +1,14 @@
+package keboola
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSuppressSemanticallyEquivalentJSON(t *testing.T) {
+	assert.True(t, suppressSemanticallyEquivalentJSON("", `{"a": 1, "b": [1, 2]}`, "{\n\t\"b\": [1,2],\n\t\"a\": 1\n}", nil), "Reordered and reformatted JSON should be equivalent")
+	assert.False(t, suppressSemanticallyEquivalentJSON("", `{"a": 1, "b": [1, 2]}`, `{"a": 1, "b": [2, 1]}`, nil), "Reordered arrays should not be equivalent")
+	assert.False(t, suppressSemanticallyEquivalentJSON("", `{"a": 1}`, `{"a": "1"}`, nil), "Values of different types should not be equivalent")
+	assert.True(t, suppressSemanticallyEquivalentJSON("", "not json", "not  json", nil), "Invalid JSON should fall back to comparing without whitespace")
+}
@@ -1,7 +1,10 @@
 package keboola
 
 import (
+	"encoding/json"
 	"fmt"
+	"regexp"
+	"sort"
 	"strings"
 )
 
@@ -41,6 +44,56 @@ func validateStorageBucketBackend(v interface{}, k string) (ws []string, errors
 	return
 }
 
+func validateWorkspaceBackend(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if value != "snowflake" && value != "redshift" {
+		errors = append(errors, fmt.Errorf(
+			"%q must be set to one of %s or %s, got %q",
+			k, "snowflake", "redshift", value))
+	}
+
+	return
+}
+
+func validateAliasFilterOperator(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if value != "eq" && value != "ne" {
+		errors = append(errors, fmt.Errorf(
+			"%q must be set to one of %s or %s, got %q",
+			k, "eq", "ne", value))
+	}
+
+	return
+}
+
+func validateBucketSharing(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if _, ok := bucketSharingEndpoints[value]; !ok {
+		errors = append(errors, fmt.Errorf(
+			"%q must be set to one of %s, %s, %s or %s, got %q",
+			k, "organization", "organization-project", "selected-projects", "selected-users", value))
+	}
+
+	return
+}
+
+func validateKBCStack(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if _, ok := kbcStacks[value]; !ok {
+		stacks := make([]string, 0, len(kbcStacks))
+		for stack := range kbcStacks {
+			stacks = append(stacks, stack)
+		}
+		sort.Strings(stacks)
+
+		errors = append(errors, fmt.Errorf(
+			"%q must be set to one of %s, got %q",
+			k, strings.Join(stacks, ", "), value))
+	}
+
+	return
+}
+
 func validateOrchestrationNotificationChannel(v interface{}, k string) (ws []string, errors []error) {
 	value := v.(string)
 	if value != "error" && value != "warning" && value != "processing" {
@@ -56,7 +109,7 @@ func validateKBCEncryptedValue(v interface{}, k string) (ws []string, errors []e
 	value := v.(string)
 	if !strings.HasPrefix(value, "KBC::ProjectSecure::") {
 		errors = append(errors, fmt.Errorf(
-			"%q must be a value encrypted using the KBC Encryption API (https://developers.keboola.com/overview/encryption/), and is expected to start with 'KBC::ProjectSecure::'", k))
+			"%q must be a value encrypted using the KBC Encryption API (https://developers.keboola.com/overview/encryption/) or a keboola_encrypted_value, and is expected to start with 'KBC::ProjectSecure::'", k))
 	}
 
 	return
@@ -72,3 +125,22 @@ func validateFTPConnectionType(v interface{}, k string) (ws []string, errors []e
 
 	return
 }
+
+func validateJSONObject(v interface{}, k string) (ws []string, errors []error) {
+	var value map[string]interface{}
+	if err := json.Unmarshal([]byte(v.(string)), &value); err != nil {
+		errors = append(errors, fmt.Errorf(
+			"%q must be a JSON object: %s", k, err))
+	}
+
+	return
+}
+
+func validateRegexp(v interface{}, k string) (ws []string, errors []error) {
+	if _, err := regexp.Compile(v.(string)); err != nil {
+		errors = append(errors, fmt.Errorf(
+			"%q must be a valid regular expression: %s", k, err))
+	}
+
+	return
+}